@@ -154,6 +154,8 @@ APP_VERSION = "1.0.1"
 
 # Server API URL (Cloudflare Images 업로드용)
 SERVER_API_URL = os.getenv("SERVER_API_URL", "http://localhost:3000/ojeomneo/v1")
+# Server API 호출 공유 키 (X-Admin-Key 헤더, 서버의 ADMIN_API_KEY와 같은 값)
+ADMIN_API_KEY = os.getenv("ADMIN_API_KEY", "")
 
 # ============================================
 # 로깅 설정
@@ -61,7 +61,15 @@ def upload_menu_image(request):
             files = {"file": (image.name, file_content, image.content_type)}
             data = {"type": "menu"}
 
-            response = requests.post(api_url, files=files, data=data, timeout=30)
+            # 사용자 토큰 대신 서버와 공유하는 관리자 키로 인증
+            headers = {}
+            admin_api_key = getattr(settings, "ADMIN_API_KEY", "")
+            if admin_api_key:
+                headers["X-Admin-Key"] = admin_api_key
+            else:
+                logger.warning("ADMIN_API_KEY not configured, server will reject the upload")
+
+            response = requests.post(api_url, files=files, data=data, headers=headers, timeout=30)
             logger.info(f"Server response: status={response.status_code}")
 
             try:
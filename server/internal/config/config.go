@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config 애플리케이션 설정
@@ -20,13 +21,37 @@ type Config struct {
 	RedisPort     string
 	RedisPassword string
 
+	// LLM 제공자 설정 (gemini, openai, mock)
+	// 비어 있으면 GEMINI_API_KEY가 있을 때 gemini, 없으면 mock
+	LLMProvider string
+
 	// Gemini 설정
 	GeminiAPIKey string
 	GeminiModel  string
 
+	// OpenAI 호환 API 설정 (OpenAI, Ollama, vLLM 등)
+	OpenAIBaseURL string
+	OpenAIAPIKey  string // 인증 없는 로컬 모델 서버면 비워 둠
+	OpenAIModel   string
+
+	// LLM 대체 순서 및 재시도/서킷 브레이커 설정
+	// LLMFallbacks: 주 제공자 실패 시 차례로 시도할 단계 (쉼표 구분 provider[:model], none이면 사용 안 함)
+	LLMFallbacks              string
+	LLMMaxRetries             int
+	LLMRetryBaseDelayMs       int
+	LLMRetryMaxDelayMs        int
+	LLMBreakerFailures        int
+	LLMBreakerCooldownSeconds int
+
 	// OpenTelemetry 설정
 	OTLPEndpoint string
 
+	// 관리자(Django admin) 서비스 호출 공유 키 (X-Admin-Key 헤더, 비어 있으면 사용 안 함)
+	AdminAPIKey string
+
+	// X-Forwarded-For를 덧붙이는 신뢰 프록시 (IP 또는 CIDR, 인그레스/로드밸런서)
+	TrustedProxies []string
+
 	// Cloudflare Images 설정
 	CloudflareAccountID   string
 	CloudflareAccountHash string
@@ -39,12 +64,71 @@ type Config struct {
 	JWTSecretKey              string
 	JWTAccessTokenExpireMin   int
 	JWTRefreshTokenExpireDays int
+	// 비대칭 서명 키 (JSON 배열: kid, alg, private_key/public_key) 및 서명에 사용할 kid
+	// 비어 있으면 JWTSecretKey로 HS256 서명
+	JWTKeys         string
+	JWTSigningKeyID string
+	// 비대칭 서명 전환 후에도 JWTSecretKey로 서명된 기존 HS256 토큰을 허용할지 (전환 기간에만 true)
+	JWTAcceptHS256 bool
 
 	// SNS Login 설정
-	AppleClientID  string
-	AppleTeamID    string
-	AppleKeyID     string
-	KakaoRestAPIKey string
+	AppleClientID     string
+	AppleTeamID       string
+	AppleKeyID        string
+	ApplePrivateKey   string // Sign in with Apple .p8 키 (client_secret 서명, 탈퇴 시 토큰 폐기용)
+	KakaoRestAPIKey   string
+	KakaoAdminKey     string // 회원 탈퇴 시 연결 끊기, 연결 끊기 콜백 검증용
+	NaverClientID     string
+	NaverClientSecret string
+
+	// SNS 제공자 API 주소 (테스트용 stub 서버로 교체 가능)
+	AppleKeysURL     string
+	AppleAuthBaseURL string // /auth/token, /auth/revoke 호출 주소
+	KakaoAPIBaseURL  string
+	NaverAPIBaseURL  string
+
+	// 계정 연결 설정
+	// 신규 로그인/가입 이메일이 기존 계정과 겹칠 때 처리 방식 (reject, link, separate)
+	AuthEmailCollisionPolicy string
+
+	// 로그인 무차별 대입 방지 설정
+	LoginMaxFailuresPerAccount int // 계정별 잠금 기준 실패 횟수
+	LoginMaxFailuresPerIP      int // IP별 잠금 기준 실패 횟수
+	LoginFailureWindowMin      int // 실패 횟수 집계 기간 (분)
+	LoginLockoutMin            int // 잠금 시간 (분)
+
+	// 2단계 인증(TOTP) 설정
+	// MFAEncryptionKey는 TOTP 비밀키 암호화 키 (base64 인코딩 32바이트), 비어 있으면 JWTSecretKey에서 파생
+	MFAEncryptionKey string
+	MFAIssuer        string // 인증 앱에 표시되는 서비스 이름
+
+	// Passkey(WebAuthn) 설정
+	// WebAuthnRPID가 비어 있으면 passkey 비활성
+	WebAuthnRPID    string
+	WebAuthnRPName  string   // 인증기에 표시되는 서비스 이름
+	WebAuthnOrigins []string // 허용 origin (웹 도메인, Android 앱 서명 origin)
+
+	// 회원 탈퇴 설정
+	AccountDeletionGraceDays int // 탈퇴 후 복구 가능 기간 (일), 지나면 완전 삭제
+	AccountPurgeIntervalMin  int // 탈퇴 계정 정리 작업 주기 (분)
+
+	// 개인 데이터 내보내기 설정
+	DataExportPath          string // 내보내기 ZIP 저장 경로 (업로드 경로와 분리, 외부 공개 금지)
+	DataExportLinkTTLHours  int    // 다운로드 링크 유효 시간 (시간), 지나면 파일 삭제
+	DataExportCooldownHours int    // 완료된 내보내기 이후 재요청 대기 시간 (시간), 0이면 제한 없음
+
+	// 이메일 인증코드 설정 (회원가입, 비밀번호 재설정 공통)
+	EmailCodeSecret             string // 인증코드/토큰 HMAC 키 (미설정 시 JWT secret에서 파생)
+	EmailCodeMaxAttempts        int    // 코드 하나당 최대 확인 시도 횟수
+	EmailCodeResendCooldownSec  int    // 같은 이메일 재발송 대기 시간 (초)
+	EmailCodeDailyLimitPerEmail int    // 이메일별 24시간 발송 한도
+	EmailCodeDailyLimitPerIP    int    // IP별 24시간 발송 한도
+	EmailCleanupIntervalMin     int    // 만료/사용된 인증 기록 정리 작업 주기 (분)
+	MagicLinkURL                string // 로그인 링크 주소 (email, code 쿼리 추가), 비어 있으면 인증코드만 발송
+
+	// 비동기 스케치 분석 설정 (POST /sketch/analyze?async=true)
+	SketchJobWorkers   int // 인스턴스별 분석 작업자 수
+	SketchJobQueueSize int // Redis 미사용 시 프로세스 안 대기열 크기 (가득 차면 503)
 
 	// SMTP 이메일 발송 설정
 	SMTPHost     string
@@ -71,11 +155,28 @@ func Load() *Config {
 		RedisPort:     getEnv("REDIS_PORT", "6379"),
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 
+		LLMProvider: getEnv("LLM_PROVIDER", ""),
+
 		GeminiAPIKey: getEnv("GEMINI_API_KEY", ""),
 		GeminiModel:  getEnv("GEMINI_MODEL", "gemini-1.5-flash"),
 
+		OpenAIBaseURL: getEnv("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+		OpenAIAPIKey:  getEnv("OPENAI_API_KEY", ""),
+		OpenAIModel:   getEnv("OPENAI_MODEL", "gpt-4o-mini"),
+
+		LLMFallbacks:              getEnv("LLM_FALLBACKS", "mock"),
+		LLMMaxRetries:             getEnvAsInt("LLM_MAX_RETRIES", 2),
+		LLMRetryBaseDelayMs:       getEnvAsInt("LLM_RETRY_BASE_DELAY_MS", 300),
+		LLMRetryMaxDelayMs:        getEnvAsInt("LLM_RETRY_MAX_DELAY_MS", 5000),
+		LLMBreakerFailures:        getEnvAsInt("LLM_BREAKER_FAILURES", 5),
+		LLMBreakerCooldownSeconds: getEnvAsInt("LLM_BREAKER_COOLDOWN_SECONDS", 30),
+
 		OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
 
+		AdminAPIKey: getEnv("ADMIN_API_KEY", ""),
+
+		TrustedProxies: splitList(getEnv("TRUSTED_PROXIES", "127.0.0.1,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16")),
+
 		CloudflareAccountID:   getEnv("CLOUDFLARE_ACCOUNT_ID", ""),
 		CloudflareAccountHash: getEnv("CLOUDFLARE_ACCOUNT_HASH", ""),
 		CloudflareAPIKey:      getEnv("CLOUDFLARE_API_KEY", ""),
@@ -85,11 +186,55 @@ func Load() *Config {
 		JWTSecretKey:              getEnv("JWT_SECRET_KEY", ""),
 		JWTAccessTokenExpireMin:   getEnvAsInt("JWT_ACCESS_TOKEN_EXPIRE_MINUTES", 15),
 		JWTRefreshTokenExpireDays: getEnvAsInt("JWT_REFRESH_TOKEN_EXPIRE_DAYS", 7),
+		JWTKeys:                   getEnv("JWT_KEYS", ""),
+		JWTSigningKeyID:           getEnv("JWT_SIGNING_KEY_ID", ""),
+		JWTAcceptHS256:            getEnvAsBool("JWT_ACCEPT_HS256", false),
+
+		AppleClientID:     getEnv("APPLE_CLIENT_ID", ""),
+		AppleTeamID:       getEnv("APPLE_TEAM_ID", ""),
+		AppleKeyID:        getEnv("APPLE_KEY_ID", ""),
+		ApplePrivateKey:   getEnv("APPLE_PRIVATE_KEY", ""),
+		KakaoRestAPIKey:   getEnv("KAKAO_REST_API_KEY", ""),
+		KakaoAdminKey:     getEnv("KAKAO_ADMIN_KEY", ""),
+		NaverClientID:     getEnv("NAVER_CLIENT_ID", ""),
+		NaverClientSecret: getEnv("NAVER_CLIENT_SECRET", ""),
 
-		AppleClientID:   getEnv("APPLE_CLIENT_ID", ""),
-		AppleTeamID:     getEnv("APPLE_TEAM_ID", ""),
-		AppleKeyID:      getEnv("APPLE_KEY_ID", ""),
-		KakaoRestAPIKey: getEnv("KAKAO_REST_API_KEY", ""),
+		AppleKeysURL:     getEnv("APPLE_KEYS_URL", "https://appleid.apple.com/auth/keys"),
+		AppleAuthBaseURL: getEnv("APPLE_AUTH_BASE_URL", "https://appleid.apple.com"),
+		KakaoAPIBaseURL:  getEnv("KAKAO_API_BASE_URL", "https://kapi.kakao.com"),
+		NaverAPIBaseURL:  getEnv("NAVER_API_BASE_URL", "https://openapi.naver.com"),
+
+		AuthEmailCollisionPolicy: getEnv("AUTH_EMAIL_COLLISION_POLICY", "reject"),
+
+		LoginMaxFailuresPerAccount: getEnvAsInt("LOGIN_MAX_FAILURES_PER_ACCOUNT", 5),
+		LoginMaxFailuresPerIP:      getEnvAsInt("LOGIN_MAX_FAILURES_PER_IP", 20),
+		LoginFailureWindowMin:      getEnvAsInt("LOGIN_FAILURE_WINDOW_MINUTES", 15),
+		LoginLockoutMin:            getEnvAsInt("LOGIN_LOCKOUT_MINUTES", 15),
+
+		MFAEncryptionKey: getEnv("MFA_ENCRYPTION_KEY", ""),
+		MFAIssuer:        getEnv("MFA_ISSUER", "Ojeomneo"),
+
+		WebAuthnRPID:    getEnv("WEBAUTHN_RP_ID", ""),
+		WebAuthnRPName:  getEnv("WEBAUTHN_RP_NAME", "Ojeomneo"),
+		WebAuthnOrigins: getEnvAsList("WEBAUTHN_ORIGINS"),
+
+		AccountDeletionGraceDays: getEnvAsInt("ACCOUNT_DELETION_GRACE_DAYS", 30),
+		AccountPurgeIntervalMin:  getEnvAsInt("ACCOUNT_PURGE_INTERVAL_MINUTES", 60),
+
+		DataExportPath:          getEnv("DATA_EXPORT_PATH", "./exports"),
+		DataExportLinkTTLHours:  getEnvAsInt("DATA_EXPORT_LINK_TTL_HOURS", 24),
+		DataExportCooldownHours: getEnvAsInt("DATA_EXPORT_COOLDOWN_HOURS", 24),
+
+		EmailCodeSecret:             getEnv("EMAIL_CODE_SECRET", ""),
+		EmailCodeMaxAttempts:        getEnvAsInt("EMAIL_CODE_MAX_ATTEMPTS", 5),
+		EmailCodeResendCooldownSec:  getEnvAsInt("EMAIL_CODE_RESEND_COOLDOWN_SECONDS", 60),
+		EmailCodeDailyLimitPerEmail: getEnvAsInt("EMAIL_CODE_DAILY_LIMIT_PER_EMAIL", 10),
+		EmailCodeDailyLimitPerIP:    getEnvAsInt("EMAIL_CODE_DAILY_LIMIT_PER_IP", 50),
+		EmailCleanupIntervalMin:     getEnvAsInt("EMAIL_VERIFICATION_CLEANUP_INTERVAL_MINUTES", 60),
+		MagicLinkURL:                getEnv("MAGIC_LINK_URL", ""),
+
+		SketchJobWorkers:   getEnvAsInt("SKETCH_JOB_WORKERS", 4),
+		SketchJobQueueSize: getEnvAsInt("SKETCH_JOB_QUEUE_SIZE", 100),
 
 		SMTPHost:     getEnvWithFallback("EMAIL_HOST", "SMTP_HOST", "smtp.gmail.com"),
 		SMTPPort:     getEnvWithFallback("EMAIL_PORT", "SMTP_PORT", "587"),
@@ -128,3 +273,29 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsBool 환경변수를 불리언으로 조회 (기본값 지원, true/1/yes 등은 strconv.ParseBool 기준)
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList 쉼표로 구분된 환경변수를 목록으로 조회 (빈 항목 제외)
+func getEnvAsList(key string) []string {
+	return splitList(os.Getenv(key))
+}
+
+// splitList 쉼표로 구분된 문자열을 목록으로 변환 (빈 항목 제외)
+func splitList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
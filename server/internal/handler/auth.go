@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
 	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
 	"github.com/ggorockee/ojeomneo/server/internal/service"
 )
 
@@ -28,7 +30,8 @@ func NewAuthHandler(authService *service.AuthService, cfg *config.Config, logger
 
 // GoogleLoginRequest Google 로그인 요청 DTO
 type GoogleLoginRequest struct {
-	IDToken string `json:"id_token"`
+	IDToken    string  `json:"id_token"`
+	GuestToken *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
 }
 
 // GoogleLogin godoc
@@ -69,51 +72,14 @@ func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
 		})
 	}
 
-	result, err := h.authService.GoogleLogin(req.IDToken)
-	duration := time.Since(start)
-
-	// Fiber context 값 미리 캡처 (goroutine에서 사용)
-	ip := c.IP()
-	userAgent := c.Get("User-Agent")
-
-	if err != nil {
-		// 비동기로 로깅 (goroutine 사용)
-		go func() {
-			h.logger.Warn("Google login failed",
-				zap.Error(err),
-				zap.String("provider", "google"),
-				zap.String("ip", ip),
-				zap.String("user_agent", userAgent),
-				zap.Duration("duration", duration),
-			)
-		}()
-
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// 비동기로 성공 로깅 (goroutine 사용)
-	go func() {
-		h.logger.Info("Google login successful",
-			zap.String("provider", "google"),
-			zap.Uint("user_id", result.User.ID),
-			zap.String("email", result.User.Email),
-			zap.String("ip", ip),
-			zap.Duration("duration", duration),
-		)
-	}()
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    result,
-	})
+	return h.snsLogin(c, model.LoginMethodGoogle, SNSLoginRequest{Token: req.IDToken, GuestToken: req.GuestToken}, start)
 }
 
 // AppleLoginRequest Apple 로그인 요청 DTO
 type AppleLoginRequest struct {
-	IdentityToken string `json:"identity_token"`
+	IdentityToken     string  `json:"identity_token"`
+	AuthorizationCode string  `json:"authorization_code,omitempty"` // 탈퇴 시 Apple 토큰 폐기를 위해 refresh token 발급에 사용
+	GuestToken        *string `json:"guest_token,omitempty"`        // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
 }
 
 // AppleLogin godoc
@@ -153,47 +119,17 @@ func (h *AuthHandler) AppleLogin(c *fiber.Ctx) error {
 		})
 	}
 
-	result, err := h.authService.AppleLogin(req.IdentityToken)
-	duration := time.Since(start)
-
-	// Fiber context 값 미리 캡처 (goroutine에서 사용)
-	ip := c.IP()
-
-	if err != nil {
-		go func() {
-			h.logger.Warn("Apple login failed",
-				zap.Error(err),
-				zap.String("provider", "apple"),
-				zap.String("ip", ip),
-				zap.Duration("duration", duration),
-			)
-		}()
-
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	go func() {
-		h.logger.Info("Apple login successful",
-			zap.String("provider", "apple"),
-			zap.Uint("user_id", result.User.ID),
-			zap.String("email", result.User.Email),
-			zap.String("ip", ip),
-			zap.Duration("duration", duration),
-		)
-	}()
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    result,
-	})
+	return h.snsLogin(c, model.LoginMethodApple, SNSLoginRequest{
+		Token:             req.IdentityToken,
+		AuthorizationCode: req.AuthorizationCode,
+		GuestToken:        req.GuestToken,
+	}, start)
 }
 
 // KakaoLoginRequest Kakao 로그인 요청 DTO
 type KakaoLoginRequest struct {
-	AccessToken string `json:"access_token"`
+	AccessToken string  `json:"access_token"`
+	GuestToken  *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
 }
 
 // KakaoLogin godoc
@@ -233,42 +169,53 @@ func (h *AuthHandler) KakaoLogin(c *fiber.Ctx) error {
 		})
 	}
 
-	result, err := h.authService.KakaoLogin(req.AccessToken)
-	duration := time.Since(start)
+	return h.snsLogin(c, model.LoginMethodKakao, SNSLoginRequest{Token: req.AccessToken, GuestToken: req.GuestToken}, start)
+}
 
-	// Fiber context 값 미리 캡처 (goroutine에서 사용)
-	ip := c.IP()
+// NaverLoginRequest Naver 로그인 요청 DTO
+type NaverLoginRequest struct {
+	AccessToken string  `json:"access_token"`
+	GuestToken  *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
+}
 
-	if err != nil {
-		go func() {
-			h.logger.Warn("Kakao login failed",
-				zap.Error(err),
-				zap.String("provider", "kakao"),
-				zap.String("ip", ip),
-				zap.Duration("duration", duration),
-			)
-		}()
+// NaverLogin godoc
+// @Summary Naver 로그인
+// @Description Naver Access Token을 검증하고 사용자를 인증합니다
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body NaverLoginRequest true "Naver 로그인 요청"
+// @Success 200 {object} service.AuthResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/naver [post]
+func (h *AuthHandler) NaverLogin(c *fiber.Ctx) error {
+	start := time.Now()
 
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+	var req NaverLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Warn("Naver login request parse failed",
+			zap.Error(err),
+			zap.String("ip", c.IP()),
+		)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
-			"error":   err.Error(),
+			"error":   "invalid request body",
 		})
 	}
 
-	go func() {
-		h.logger.Info("Kakao login successful",
-			zap.String("provider", "kakao"),
-			zap.Uint("user_id", result.User.ID),
-			zap.String("email", result.User.Email),
-			zap.String("ip", ip),
-			zap.Duration("duration", duration),
+	if req.AccessToken == "" {
+		h.logger.Warn("Naver login missing token",
+			zap.String("ip", c.IP()),
 		)
-	}()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "access_token is required",
+		})
+	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    result,
-	})
+	return h.snsLogin(c, model.LoginMethodNaver, SNSLoginRequest{Token: req.AccessToken, GuestToken: req.GuestToken}, start)
 }
 
 // GuestLoginRequest 익명 로그인 요청 DTO
@@ -313,7 +260,7 @@ func (h *AuthHandler) GuestLogin(c *fiber.Ctx) error {
 		})
 	}
 
-	result, err := h.authService.GuestLogin(req.DeviceID)
+	result, err := h.authService.GuestLogin(req.DeviceID, clientInfo(c))
 	duration := time.Since(start)
 
 	// Fiber context 값 미리 캡처 (goroutine에서 사용)
@@ -349,3 +296,51 @@ func (h *AuthHandler) GuestLogin(c *fiber.Ctx) error {
 		"data":    result,
 	})
 }
+
+// upgradeGuest 로그인/가입 요청에 익명 토큰이 포함되면 익명 계정의 기록을 로그인한 계정으로 이관
+// 이관 실패는 로그인 자체를 실패시키지 않음
+func (h *AuthHandler) upgradeGuest(guestToken *string, response *service.AuthResponse, method string) {
+	if guestToken == nil || *guestToken == "" || response == nil || response.User == nil {
+		return
+	}
+
+	result, err := h.authService.UpgradeGuest(*guestToken, response.User.ID, method)
+	if err != nil {
+		h.logger.Warn("Guest upgrade skipped",
+			zap.Error(err),
+			zap.Uint("user_id", response.User.ID),
+			zap.String("method", method),
+		)
+		return
+	}
+
+	response.GuestUpgrade = result
+}
+
+// loginErrorStatus 로그인 실패 응답 상태 코드 (이메일 충돌은 409, 지원하지 않는 제공자는 404, 이메일 미동의는 400, 그 외 401)
+func loginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrEmailCollision):
+		return fiber.StatusConflict
+	case errors.Is(err, service.ErrUnsupportedProvider):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrSNSEmailRequired):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusUnauthorized
+}
+
+// loginErrorMessage 로그인 실패 응답 메시지
+// 사용자가 조치할 수 있는 오류만 그대로 전달하고, 제공자 API 응답 등 상세 오류는 로그에만 남긴다
+func loginErrorMessage(err error) string {
+	for _, known := range []error{
+		service.ErrEmailCollision,
+		service.ErrUnsupportedProvider,
+		service.ErrSNSEmailRequired,
+	} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return service.ErrSNSTokenInvalid.Error()
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// AppleNotificationRequest Apple 서버 알림 요청 DTO (Apple이 서명한 JWT)
+type AppleNotificationRequest struct {
+	Payload string `json:"payload"`
+}
+
+// AppleNotification godoc
+// @Summary Apple 서버 알림 수신
+// @Description Sign in with Apple 서버 알림(email-disabled, email-enabled, consent-revoked, account-delete)을 검증하고 사용자 계정에 반영합니다. Apple Developer에 등록하는 알림 URL입니다.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body AppleNotificationRequest true "Apple 서버 알림"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/apple/notifications [post]
+func (h *AuthHandler) AppleNotification(c *fiber.Ctx) error {
+	var req AppleNotificationRequest
+	if err := c.BodyParser(&req); err != nil || req.Payload == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "payload is required",
+		})
+	}
+
+	if err := h.authService.HandleAppleNotification(req.Payload); err != nil {
+		if errors.Is(err, service.ErrAppleNotificationInvalid) {
+			h.logger.Warn("Apple notification rejected",
+				zap.Error(err),
+				zap.String("ip", c.IP()),
+			)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   service.ErrAppleNotificationInvalid.Error(),
+			})
+		}
+
+		h.logger.Error("Apple notification handling failed", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "알림 처리에 실패했습니다",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+	})
+}
@@ -1,14 +1,15 @@
 package handler
 
 import (
-	"strings"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
+	"github.com/ggorockee/ojeomneo/server/internal/middleware"
 	"github.com/ggorockee/ojeomneo/server/internal/service"
-	"github.com/ggorockee/ojeomneo/server/pkg/auth"
 )
 
 // SendEmailCodeRequest 이메일 인증코드 발송 요청
@@ -18,11 +19,13 @@ type SendEmailCodeRequest struct {
 
 // SendEmailCode godoc
 // @Summary 이메일 인증코드 발송
+// @Description 같은 이메일로는 일정 시간(기본 60초) 안에 다시 요청할 수 없고, 이메일/IP별 24시간 발송 한도를 넘으면 429와 Retry-After를 반환합니다
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body SendEmailCodeRequest true "이메일"
 // @Success 200 {object} map[string]string
+// @Failure 429 {object} map[string]interface{}
 // @Router /auth/email/send-code [post]
 func (h *AuthHandler) SendEmailCode(c *fiber.Ctx) error {
 	var req SendEmailCodeRequest
@@ -44,7 +47,15 @@ func (h *AuthHandler) SendEmailCode(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.authService.SendEmailCode(req.Email); err != nil {
+	if err := h.authService.SendEmailCode(req.Email, clientInfo(c)); err != nil {
+		var throttleErr *service.EmailCodeThrottleError
+		if errors.As(err, &throttleErr) {
+			c.Set("Retry-After", strconv.Itoa(throttleErr.RetryAfterSeconds()))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
 			"error":   err.Error(),
@@ -118,6 +129,7 @@ type SignupRequest struct {
 	FirstName        *string `json:"first_name,omitempty"`
 	LastName         *string `json:"last_name,omitempty"`
 	VerificationToken *string `json:"verification_token,omitempty"`
+	GuestToken       *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
 }
 
 // Signup godoc
@@ -154,16 +166,24 @@ func (h *AuthHandler) Signup(c *fiber.Ctx) error {
 		FirstName:        req.FirstName,
 		LastName:         req.LastName,
 		VerificationToken: req.VerificationToken,
+		Client:           clientInfo(c),
 	}
 
 	response, err := h.authService.Signup(serviceReq)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		status := fiber.StatusBadRequest
+		if errors.Is(err, service.ErrEmailCollision) || errors.Is(err, service.ErrAccountPendingDeletion) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
 			"success": false,
 			"error":   err.Error(),
 		})
 	}
 
+	// 익명 사용 기록 이관 (guest_token이 있는 경우)
+	h.upgradeGuest(req.GuestToken, response, "email")
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
 		"data":    response,
@@ -172,17 +192,20 @@ func (h *AuthHandler) Signup(c *fiber.Ctx) error {
 
 // LoginRequest 이메일 로그인 요청
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string  `json:"email"`
+	Password   string  `json:"password"`
+	GuestToken *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
 }
 
 // Login godoc
 // @Summary 이메일 로그인
+// @Description 2단계 인증을 사용하는 계정은 토큰 대신 mfa_required와 mfa_token을 반환합니다
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "로그인 정보"
 // @Success 200 {object} service.AuthResponse
+// @Failure 429 {object} map[string]interface{}
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var req LoginRequest
@@ -207,22 +230,40 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	serviceReq := &service.LoginRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		Client:   clientInfo(c),
 	}
 
 	response, err := h.authService.EmailLogin(serviceReq)
 	if err != nil {
+		var throttleErr *service.LoginThrottleError
+		if errors.As(err, &throttleErr) {
+			return loginThrottled(c, throttleErr)
+		}
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"error":   err.Error(),
 		})
 	}
 
+	// 익명 사용 기록 이관 (guest_token이 있는 경우)
+	// 2단계 인증이 필요한 경우에는 /auth/mfa/verify 완료 시 이관
+	h.upgradeGuest(req.GuestToken, response, "email")
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    response,
 	})
 }
 
+// loginThrottled 로그인 시도 제한 응답 (429, Retry-After)
+func loginThrottled(c *fiber.Ctx, err *service.LoginThrottleError) error {
+	c.Set("Retry-After", strconv.Itoa(err.RetryAfterSeconds()))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+		"success": false,
+		"error":   err.Error(),
+	})
+}
+
 // RefreshTokenRequest Refresh Token 요청
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token"`
@@ -256,7 +297,7 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.authService.RefreshToken(req.RefreshToken)
+	response, err := h.authService.RefreshToken(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
@@ -303,8 +344,8 @@ func (h *AuthHandler) PasswordResetRequest(c *fiber.Ctx) error {
 		})
 	}
 
-	// 보안상 이유로 실패해도 성공 메시지 반환
-	if err := h.authService.PasswordResetRequest(req.Email); err != nil {
+	// 보안상 이유로 실패(미가입 이메일, 발송 제한 포함)해도 성공 메시지 반환
+	if err := h.authService.PasswordResetRequest(req.Email, clientInfo(c)); err != nil {
 		h.logger.Warn("PasswordResetRequest failed",
 			zap.Error(err),
 			zap.String("email", req.Email),
@@ -427,32 +468,15 @@ func (h *AuthHandler) PasswordResetConfirm(c *fiber.Ctx) error {
 // @Success 200 {object} map[string]interface{}
 // @Router /auth/me [get]
 func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
-	// Authorization 헤더에서 토큰 추출
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
+	// 인증 미들웨어에서 주입한 사용자 정보
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"error":   "로그인이 필요합니다",
 		})
 	}
 
-	parts := strings.Split(authHeader, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "잘못된 인증 형식입니다",
-		})
-	}
-
-	token := parts[1]
-	claims, err := auth.ValidateAccessToken(token, h.cfg.JWTSecretKey)
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "로그인이 만료되었습니다. 다시 로그인해 주세요",
-		})
-	}
-
 	user, err := h.authService.GetMe(claims.UserID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -474,6 +498,7 @@ type DeleteAccountRequest struct {
 }
 
 // @Summary 회원 탈퇴
+// @Description 탈퇴 요청 후 유예 기간(purge_after) 내에 다시 로그인하면 계정이 복구되며, 기간이 지나면 완전히 삭제됩니다
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -487,32 +512,15 @@ type DeleteAccountRequest struct {
 func (h *AuthHandler) DeleteMe(c *fiber.Ctx) error {
 	start := time.Now()
 
-	// Authorization 헤더에서 토큰 추출
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
+	// 인증 미들웨어에서 주입한 사용자 정보
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"error":   "로그인이 필요합니다",
 		})
 	}
 
-	parts := strings.Split(authHeader, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "잘못된 인증 형식입니다",
-		})
-	}
-
-	token := parts[1]
-	claims, err := auth.ValidateAccessToken(token, h.cfg.JWTSecretKey)
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "로그인이 만료되었습니다. 다시 로그인해 주세요",
-		})
-	}
-
 	// 익명 사용자는 탈퇴 불가
 	if claims.IsGuest {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -534,7 +542,8 @@ func (h *AuthHandler) DeleteMe(c *fiber.Ctx) error {
 	// Fiber context 값 미리 캡처 (goroutine에서 사용)
 	ip := c.IP()
 
-	if err := h.authService.DeleteMe(claims.UserID, req.Reason); err != nil {
+	deletion, err := h.authService.DeleteMe(claims.UserID, req.Reason)
+	if err != nil {
 		duration := time.Since(start)
 		go func() {
 			h.logger.Warn("Account deletion failed",
@@ -562,7 +571,74 @@ func (h *AuthHandler) DeleteMe(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "회원 탈퇴가 완료되었습니다",
+		"message": "회원 탈퇴가 접수되었습니다. 유예 기간 내에 다시 로그인하면 계정이 복구됩니다",
+		"data":    deletion,
+	})
+}
+
+// Logout godoc
+// @Summary 로그아웃 (현재 기기)
+// @Description 현재 Access Token과 같은 세션의 Refresh Token을 폐기합니다
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	// 인증 미들웨어에서 주입한 사용자 정보
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	if err := h.authService.Logout(claims.UserID, claims.TokenID, claims.SessionID, claims.ExpiresAt); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그아웃 처리에 실패했습니다",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "로그아웃되었습니다",
+	})
+}
+
+// LogoutAll godoc
+// @Summary 모든 기기에서 로그아웃
+// @Description 사용자에게 발급된 모든 Access/Refresh Token을 폐기합니다
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *fiber.Ctx) error {
+	// 인증 미들웨어에서 주입한 사용자 정보
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	if err := h.authService.LogoutAll(claims.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "전체 로그아웃 처리에 실패했습니다",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "모든 기기에서 로그아웃되었습니다",
 	})
 }
 
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ggorockee/ojeomneo/server/internal/middleware"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// LinkIdentityRequest 로그인 수단 연결 요청 DTO
+type LinkIdentityRequest struct {
+	Token             string `json:"token,omitempty"`              // Google ID Token / Apple Identity Token / Kakao·Naver Access Token
+	Email             string `json:"email,omitempty"`              // 이메일 연결 시
+	Password          string `json:"password,omitempty"`           // 이메일 연결 시
+	VerificationToken string `json:"verification_token,omitempty"` // 이메일 연결 시 (인증코드 확인 결과)
+}
+
+// ListIdentities godoc
+// @Summary 연결된 로그인 수단 목록
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.UserIdentity
+// @Failure 401 {object} map[string]interface{}
+// @Router /auth/identities [get]
+func (h *AuthHandler) ListIdentities(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	identities, err := h.authService.ListIdentities(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    identities,
+	})
+}
+
+// LinkIdentity godoc
+// @Summary 로그인 수단 연결
+// @Description 현재 계정에 Google/Apple/Kakao/Naver/이메일 로그인을 연결합니다
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "로그인 방식 (google, apple, kakao, naver, email)"
+// @Param request body LinkIdentityRequest true "연결할 로그인 수단 정보"
+// @Success 200 {object} model.UserIdentity
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /auth/identities/{provider} [post]
+func (h *AuthHandler) LinkIdentity(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	var req LinkIdentityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid request body",
+		})
+	}
+
+	identity, err := h.authService.LinkIdentity(claims.UserID, model.LoginMethod(c.Params("provider")), &service.LinkIdentityRequest{
+		Token:             req.Token,
+		Email:             req.Email,
+		Password:          req.Password,
+		VerificationToken: req.VerificationToken,
+	})
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if errors.Is(err, service.ErrIdentityAlreadyLinked) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    identity,
+	})
+}
+
+// UnlinkIdentity godoc
+// @Summary 로그인 수단 연결 해제
+// @Description 마지막 남은 로그인 수단은 해제할 수 없습니다
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "로그인 방식 (google, apple, kakao, naver, email)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /auth/identities/{provider} [delete]
+func (h *AuthHandler) UnlinkIdentity(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	if err := h.authService.UnlinkIdentity(claims.UserID, model.LoginMethod(c.Params("provider"))); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "로그인 수단 연결이 해제되었습니다",
+	})
+}
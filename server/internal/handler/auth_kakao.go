@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// KakaoUnlinkCallback godoc
+// @Summary Kakao 연결 끊기 콜백
+// @Description 사용자가 카카오톡에서 앱 연결을 끊으면 카카오가 호출합니다. Authorization 헤더(KakaoAK {Admin 키})를 검증한 뒤 해당 Kakao 연결을 해제합니다. Kakao가 유일한 로그인 수단이면 탈퇴 유예 기간이 시작되며, 기간 안에 다시 Kakao로 로그인하면 복구됩니다. 카카오 개발자 콘솔에 등록하는 연결 끊기 콜백 URL이며 GET, POST 모두 지원합니다.
+// @Tags auth
+// @Produce json
+// @Param Authorization header string true "KakaoAK {Admin 키}"
+// @Param app_id query string false "앱 ID"
+// @Param user_id query string true "Kakao 회원번호"
+// @Param referrer_type query string false "연결 끊기 요청 경로 (ACCOUNT_DELETE, FORCED_ACCOUNT_DELETE, UNLINK_FROM_APPS 등)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/kakao/unlink [post]
+func (h *AuthHandler) KakaoUnlinkCallback(c *fiber.Ctx) error {
+	// FormValue는 query string과 POST form을 모두 조회
+	userID := c.FormValue("user_id")
+	if userID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "user_id is required",
+		})
+	}
+	referrerType := c.FormValue("referrer_type")
+
+	if err := h.authService.HandleKakaoUnlink(c.Get(fiber.HeaderAuthorization), userID, referrerType); err != nil {
+		if errors.Is(err, service.ErrKakaoCallbackUnauthorized) {
+			h.logger.Warn("Kakao unlink callback rejected",
+				zap.String("ip", c.IP()),
+			)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+
+		h.logger.Error("Kakao unlink callback handling failed", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "콜백 처리에 실패했습니다",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+	})
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// MagicLinkRequest 로그인 링크 발송 요청
+type MagicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// RequestMagicLink godoc
+// @Summary 비밀번호 없는 로그인 링크 발송
+// @Description 로그인 링크와 6자리 인증코드를 이메일로 보냅니다 (15분간 유효, 한 번만 사용 가능). 인증코드와 같은 발송 제한을 적용하며, 넘으면 429와 Retry-After를 반환합니다
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body MagicLinkRequest true "이메일"
+// @Success 200 {object} map[string]string
+// @Failure 429 {object} map[string]interface{}
+// @Router /auth/email/magic-link [post]
+func (h *AuthHandler) RequestMagicLink(c *fiber.Ctx) error {
+	var req MagicLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Warn("RequestMagicLink request parse failed",
+			zap.Error(err),
+			zap.String("ip", c.IP()),
+		)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid request body",
+		})
+	}
+
+	if req.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "email is required",
+		})
+	}
+
+	if err := h.authService.RequestMagicLink(req.Email, clientInfo(c)); err != nil {
+		var throttleErr *service.EmailCodeThrottleError
+		if errors.As(err, &throttleErr) {
+			c.Set("Retry-After", strconv.Itoa(throttleErr.RetryAfterSeconds()))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "로그인 링크가 발송되었습니다",
+	})
+}
+
+// MagicLinkLoginRequest 로그인 링크(인증코드) 사용 요청
+type MagicLinkLoginRequest struct {
+	Email      string  `json:"email"`
+	Code       string  `json:"code"`                  // 링크의 code 쿼리 또는 이메일의 인증코드
+	GuestToken *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
+}
+
+// MagicLinkLogin godoc
+// @Summary 로그인 링크로 로그인
+// @Description 링크의 email, code 쿼리(또는 이메일의 인증코드)로 로그인합니다. 처음 사용하는 이메일이면 계정을 만들고, 2단계 인증을 사용하는 계정은 토큰 대신 mfa_required와 mfa_token을 반환합니다
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body MagicLinkLoginRequest true "이메일과 인증코드"
+// @Success 200 {object} service.AuthResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /auth/email/magic-link/verify [post]
+func (h *AuthHandler) MagicLinkLogin(c *fiber.Ctx) error {
+	var req MagicLinkLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Warn("MagicLinkLogin request parse failed",
+			zap.Error(err),
+			zap.String("ip", c.IP()),
+		)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid request body",
+		})
+	}
+
+	if req.Email == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "email and code are required",
+		})
+	}
+
+	response, err := h.authService.MagicLinkLogin(&service.MagicLinkLoginRequest{
+		Email:  req.Email,
+		Code:   req.Code,
+		Client: clientInfo(c),
+	})
+	if err != nil {
+		status := fiber.StatusUnauthorized
+		if errors.Is(err, service.ErrEmailCollision) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// 익명 사용 기록 이관 (guest_token이 있는 경우)
+	// 2단계 인증이 필요한 경우에는 /auth/mfa/verify 완료 시 이관
+	h.upgradeGuest(req.GuestToken, response, "email")
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ggorockee/ojeomneo/server/internal/middleware"
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// MFACodeRequest 2단계 인증 코드 요청 DTO
+type MFACodeRequest struct {
+	Code string `json:"code"` // 인증 앱의 6자리 코드 또는 복구 코드
+}
+
+// VerifyMFARequest 2단계 인증 로그인 완료 요청 DTO
+type VerifyMFARequest struct {
+	MFAToken   string  `json:"mfa_token"`
+	Code       string  `json:"code"`                  // 인증 앱의 6자리 코드 또는 복구 코드
+	GuestToken *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
+}
+
+// SetupTOTP godoc
+// @Summary 2단계 인증(TOTP) 등록 시작
+// @Description 인증 앱에 등록할 비밀키와 otpauth URI를 발급합니다. 코드 확인(/auth/mfa/totp/enable) 전까지는 적용되지 않습니다.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.TOTPSetupResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /auth/mfa/totp/setup [post]
+func (h *AuthHandler) SetupTOTP(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	setup, err := h.authService.SetupTOTP(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    setup,
+	})
+}
+
+// EnableTOTP godoc
+// @Summary 2단계 인증(TOTP) 활성화
+// @Description 인증 앱의 코드를 확인하고 2단계 인증을 켭니다. 복구 코드는 이 응답에서만 확인할 수 있습니다.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body MFACodeRequest true "인증 코드"
+// @Success 200 {object} service.TOTPEnableResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /auth/mfa/totp/enable [post]
+func (h *AuthHandler) EnableTOTP(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	var req MFACodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "code is required",
+		})
+	}
+
+	result, err := h.authService.EnableTOTP(claims.UserID, req.Code)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// DisableTOTP godoc
+// @Summary 2단계 인증(TOTP) 해제
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body MFACodeRequest true "인증 코드 또는 복구 코드"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /auth/mfa/totp/disable [post]
+func (h *AuthHandler) DisableTOTP(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	var req MFACodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "code is required",
+		})
+	}
+
+	if err := h.authService.DisableTOTP(claims.UserID, req.Code); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "2단계 인증이 해제되었습니다",
+	})
+}
+
+// VerifyMFA godoc
+// @Summary 2단계 인증 로그인 완료
+// @Description 이메일 로그인에서 받은 mfa_token과 인증 코드(또는 복구 코드)를 Access/Refresh Token으로 교환합니다
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyMFARequest true "challenge 토큰과 인증 코드"
+// @Success 200 {object} service.AuthResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *fiber.Ctx) error {
+	var req VerifyMFARequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid request body",
+		})
+	}
+
+	if req.MFAToken == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "mfa_token and code are required",
+		})
+	}
+
+	response, err := h.authService.VerifyMFA(req.MFAToken, req.Code, clientInfo(c))
+	if err != nil {
+		var throttleErr *service.LoginThrottleError
+		if errors.As(err, &throttleErr) {
+			return loginThrottled(c, throttleErr)
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// 익명 사용 기록 이관 (guest_token이 있는 경우)
+	h.upgradeGuest(req.GuestToken, response, "email")
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ggorockee/ojeomneo/server/internal/middleware"
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// PasskeyLoginRequest passkey 로그인 완료 요청 DTO
+type PasskeyLoginRequest struct {
+	service.PasskeyAssertion
+	GuestToken *string `json:"guest_token,omitempty"` // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
+}
+
+// passkeyErrorStatus passkey 오류 응답 상태 코드
+func passkeyErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, service.ErrPasskeyDisabled):
+		return fiber.StatusNotImplemented
+	case errors.Is(err, service.ErrPasskeyNotFound):
+		return fiber.StatusNotFound
+	}
+	return fallback
+}
+
+// BeginPasskeyRegistration godoc
+// @Summary Passkey 등록 시작
+// @Description navigator.credentials.create()에 전달할 옵션을 발급합니다. 바이너리 값은 base64url로 인코딩되어 있습니다.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} webauthn.CreationOptions
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 501 {object} map[string]interface{}
+// @Router /auth/passkey/register/options [post]
+func (h *AuthHandler) BeginPasskeyRegistration(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	options, err := h.authService.BeginPasskeyRegistration(claims.UserID)
+	if err != nil {
+		return c.Status(passkeyErrorStatus(err, fiber.StatusBadRequest)).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    options,
+	})
+}
+
+// FinishPasskeyRegistration godoc
+// @Summary Passkey 등록 완료
+// @Description 인증기 응답(attestation)을 검증하고 passkey를 계정에 등록합니다
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body service.PasskeyAttestation true "인증기 등록 응답"
+// @Success 200 {object} model.PasskeyCredential
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 501 {object} map[string]interface{}
+// @Router /auth/passkey/register [post]
+func (h *AuthHandler) FinishPasskeyRegistration(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	var req service.PasskeyAttestation
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid request body",
+		})
+	}
+
+	if req.ClientDataJSON == "" || req.AttestationObject == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "client_data_json and attestation_object are required",
+		})
+	}
+
+	credential, err := h.authService.FinishPasskeyRegistration(claims.UserID, &req)
+	if err != nil {
+		return c.Status(passkeyErrorStatus(err, fiber.StatusBadRequest)).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    credential,
+	})
+}
+
+// BeginPasskeyLogin godoc
+// @Summary Passkey 로그인 시작
+// @Description navigator.credentials.get()에 전달할 옵션을 발급합니다. 기기에 저장된 passkey 중 사용자가 선택합니다.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} webauthn.RequestOptions
+// @Failure 500 {object} map[string]interface{}
+// @Failure 501 {object} map[string]interface{}
+// @Router /auth/passkey/login/options [post]
+func (h *AuthHandler) BeginPasskeyLogin(c *fiber.Ctx) error {
+	options, err := h.authService.BeginPasskeyLogin()
+	if err != nil {
+		return c.Status(passkeyErrorStatus(err, fiber.StatusInternalServerError)).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    options,
+	})
+}
+
+// FinishPasskeyLogin godoc
+// @Summary Passkey 로그인
+// @Description 인증기 서명(assertion)을 검증하고 Access/Refresh Token을 발급합니다
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body PasskeyLoginRequest true "인증기 로그인 응답"
+// @Success 200 {object} service.AuthResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 501 {object} map[string]interface{}
+// @Router /auth/passkey/login [post]
+func (h *AuthHandler) FinishPasskeyLogin(c *fiber.Ctx) error {
+	var req PasskeyLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid request body",
+		})
+	}
+
+	if req.ID == "" || req.ClientDataJSON == "" || req.AuthenticatorData == "" || req.Signature == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "id, client_data_json, authenticator_data and signature are required",
+		})
+	}
+
+	response, err := h.authService.FinishPasskeyLogin(&req.PasskeyAssertion, clientInfo(c))
+	if err != nil {
+		status := fiber.StatusUnauthorized
+		if errors.Is(err, service.ErrPasskeyDisabled) {
+			status = fiber.StatusNotImplemented
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// 익명 사용 기록 이관 (guest_token이 있는 경우)
+	h.upgradeGuest(req.GuestToken, response, "passkey")
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// ListPasskeys godoc
+// @Summary 등록된 passkey 목록
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.PasskeyCredential
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/passkeys [get]
+func (h *AuthHandler) ListPasskeys(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	credentials, err := h.authService.ListPasskeys(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    credentials,
+	})
+}
+
+// DeletePasskey godoc
+// @Summary Passkey 삭제
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Passkey ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /auth/passkeys/{id} [delete]
+func (h *AuthHandler) DeletePasskey(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid passkey id",
+		})
+	}
+
+	if err := h.authService.DeletePasskey(claims.UserID, uint(id)); err != nil {
+		return c.Status(passkeyErrorStatus(err, fiber.StatusInternalServerError)).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "passkey가 삭제되었습니다",
+	})
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ggorockee/ojeomneo/server/internal/middleware"
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// 앱이 모든 인증 요청에 함께 보내는 기기 정보 헤더
+const (
+	headerDeviceID   = "X-Device-ID"   // 앱 설치 단위 식별자 (스케치 device_id와 동일)
+	headerPlatform   = "X-Platform"    // ios, android
+	headerAppVersion = "X-App-Version" // 앱 버전 (예: 1.2.0)
+)
+
+// clientInfo 요청 헤더에서 기기/클라이언트 정보 추출 (세션 기록용)
+func clientInfo(c *fiber.Ctx) service.ClientInfo {
+	return service.ClientInfo{
+		DeviceID:   c.Get(headerDeviceID),
+		Platform:   c.Get(headerPlatform),
+		AppVersion: c.Get(headerAppVersion),
+		IP:         middleware.ClientIP(c),
+		UserAgent:  c.Get("User-Agent"),
+	}
+}
+
+// ListSessions godoc
+// @Summary 로그인된 기기(세션) 목록
+// @Description 현재 로그인되어 있는 기기 목록을 최근 접속 순으로 반환합니다. 마지막 접속 시각은 토큰 갱신 시 기록됩니다.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} service.SessionResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	sessions, err := h.authService.ListSessions(claims.UserID, claims.SessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    sessions,
+	})
+}
+
+// RevokeSession godoc
+// @Summary 특정 기기 로그아웃
+// @Description 분실한 기기 등 선택한 세션의 토큰을 즉시 폐기합니다
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "세션 ID"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	if err := h.authService.RevokeSession(claims.UserID, c.Params("id")); err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, service.ErrSessionNotFound) {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "해당 기기에서 로그아웃되었습니다",
+	})
+}
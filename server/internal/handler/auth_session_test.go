@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+func TestClientInfo(t *testing.T) {
+	var info service.ClientInfo
+	app := fiber.New(fiber.Config{
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          []string{"0.0.0.0", "10.0.0.0/8"},
+	})
+	app.Post("/auth/email/send-code", func(c *fiber.Ctx) error {
+		info = clientInfo(c)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	t.Run("인그레스 뒤에서는 X-Forwarded-For의 클라이언트 IP 사용", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/email/send-code", nil)
+		req.Header.Set("X-Forwarded-For", "198.51.100.1, 10.0.0.2")
+		req.Header.Set(headerDeviceID, "device-1")
+		req.Header.Set(headerPlatform, "ios")
+		_, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "198.51.100.1", info.IP)
+		assert.Equal(t, "device-1", info.DeviceID)
+		assert.Equal(t, "ios", info.Platform)
+	})
+
+	t.Run("조작한 맨 앞 주소는 이메일 발송 한도 집계에 쓰지 않음", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/email/send-code", nil)
+		req.Header.Set("X-Forwarded-For", "6.6.6.6, 198.51.100.1, 10.0.0.2")
+		_, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "198.51.100.1", info.IP)
+	})
+}
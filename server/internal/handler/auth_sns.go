@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// SNSLoginRequest SNS 로그인 요청 DTO (제공자 공통)
+type SNSLoginRequest struct {
+	Token             string  `json:"token"`                        // Google ID Token / Apple Identity Token / Kakao·Naver Access Token
+	AuthorizationCode string  `json:"authorization_code,omitempty"` // Apple authorization code (탈퇴 시 Apple 토큰 폐기용)
+	GuestToken        *string `json:"guest_token,omitempty"`        // 익명 사용 중이었다면 익명 Access Token (기록 이관용)
+}
+
+// SNSLogin godoc
+// @Summary SNS 로그인 (제공자 공통)
+// @Description 제공자의 토큰을 검증하고 사용자를 인증합니다. 기존 /auth/google, /auth/apple, /auth/kakao, /auth/naver와 같은 흐름입니다.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "제공자 (google, apple, kakao, naver)"
+// @Param request body SNSLoginRequest true "SNS 로그인 요청"
+// @Success 200 {object} service.AuthResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /auth/sns/{provider} [post]
+func (h *AuthHandler) SNSLogin(c *fiber.Ctx) error {
+	start := time.Now()
+	provider := model.LoginMethod(c.Params("provider"))
+
+	var req SNSLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Warn("SNS login request parse failed",
+			zap.Error(err),
+			zap.String("provider", string(provider)),
+			zap.String("ip", c.IP()),
+		)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "invalid request body",
+		})
+	}
+
+	if req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "token is required",
+		})
+	}
+
+	return h.snsLogin(c, provider, req, start)
+}
+
+// snsLogin SNS 로그인 공통 처리 (토큰 검증, 오류 응답, Apple refresh token 저장, 익명 기록 이관)
+func (h *AuthHandler) snsLogin(c *fiber.Ctx, provider model.LoginMethod, req SNSLoginRequest, start time.Time) error {
+	result, err := h.authService.SNSLogin(provider, req.Token, clientInfo(c))
+	duration := time.Since(start)
+
+	// Fiber context 값 미리 캡처 (goroutine에서 사용)
+	ip := c.IP()
+	userAgent := c.Get("User-Agent")
+
+	if err != nil {
+		// 비동기로 로깅 (goroutine 사용)
+		go func() {
+			h.logger.Warn("SNS login failed",
+				zap.Error(err),
+				zap.String("provider", string(provider)),
+				zap.String("ip", ip),
+				zap.String("user_agent", userAgent),
+				zap.Duration("duration", duration),
+			)
+		}()
+
+		return c.Status(loginErrorStatus(err)).JSON(fiber.Map{
+			"success": false,
+			"error":   loginErrorMessage(err),
+		})
+	}
+
+	// 비동기로 성공 로깅 (goroutine 사용)
+	go func() {
+		h.logger.Info("SNS login successful",
+			zap.String("provider", string(provider)),
+			zap.Uint("user_id", result.User.ID),
+			zap.String("email", result.User.Email),
+			zap.String("ip", ip),
+			zap.Duration("duration", duration),
+		)
+	}()
+
+	// Apple refresh token 저장 (실패해도 로그인은 성공 처리)
+	if provider == model.LoginMethodApple && req.AuthorizationCode != "" {
+		if err := h.authService.StoreAppleAuthorization(result.User.ID, req.AuthorizationCode); err != nil {
+			h.logger.Warn("Apple authorization code not stored",
+				zap.Error(err),
+				zap.Uint("user_id", result.User.ID),
+			)
+		}
+	}
+
+	// 익명 사용 기록 이관 (guest_token이 있는 경우)
+	h.upgradeGuest(req.GuestToken, result, string(provider))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
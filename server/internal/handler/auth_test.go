@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+func TestLoginErrorMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+		status  int
+	}{
+		{
+			name:    "제공자 API 오류는 일반 메시지로 응답",
+			err:     fmt.Errorf("%w (kakao): %w", service.ErrSNSTokenInvalid, errors.New("kakao api returned 401: {\"msg\":\"this access token does not exist\"}")),
+			message: service.ErrSNSTokenInvalid.Error(),
+			status:  fiber.StatusUnauthorized,
+		},
+		{
+			name:    "내부 오류도 일반 메시지로 응답",
+			err:     fmt.Errorf("failed to create user: %w", errors.New("duplicate key value violates unique constraint")),
+			message: service.ErrSNSTokenInvalid.Error(),
+			status:  fiber.StatusUnauthorized,
+		},
+		{
+			name:    "사용자가 조치할 수 있는 오류는 그대로 전달",
+			err:     service.ErrSNSEmailRequired,
+			message: service.ErrSNSEmailRequired.Error(),
+			status:  fiber.StatusBadRequest,
+		},
+		{
+			name:    "이메일 충돌",
+			err:     service.ErrEmailCollision,
+			message: service.ErrEmailCollision.Error(),
+			status:  fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.message, loginErrorMessage(tt.err))
+			assert.Equal(t, tt.status, loginErrorStatus(tt.err))
+		})
+	}
+}
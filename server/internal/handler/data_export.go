@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/middleware"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// DataExportHandler 개인 데이터 내보내기 핸들러
+type DataExportHandler struct {
+	exportService *service.DataExportService
+	logger        *zap.Logger
+}
+
+// NewDataExportHandler 새 데이터 내보내기 핸들러 생성
+func NewDataExportHandler(exportService *service.DataExportService, logger *zap.Logger) *DataExportHandler {
+	return &DataExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// DataExportResponse 내보내기 요청 응답
+type DataExportResponse struct {
+	*model.DataExport
+	DownloadURL string `json:"download_url"` // 생성 완료 후 expires_at까지 인증 없이 다운로드 가능 (요청 시 한 번만 반환)
+}
+
+// RequestExport godoc
+// @Summary 개인 데이터 내보내기 요청
+// @Description 프로필, 스케치와 분석 결과, 원본 스케치 이미지, 추천 기록을 담은 ZIP을 백그라운드에서 생성합니다. 응답의 download_url은 생성이 끝난 뒤 expires_at까지 사용할 수 있으며, 상태는 GET /auth/me/export/{id}로 확인합니다. ZIP 구성은 docs/DATA_EXPORT.md를 참고하세요.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} DataExportResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/me/export [post]
+func (h *DataExportHandler) RequestExport(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	ticket, err := h.exportService.RequestExport(claims.UserID)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrDataExportInProgress):
+			status = fiber.StatusConflict
+		case errors.Is(err, service.ErrDataExportCooldown):
+			status = fiber.StatusTooManyRequests
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data": DataExportResponse{
+			DataExport:  ticket.Export,
+			DownloadURL: fmt.Sprintf("%s/%s/download?token=%s", c.Path(), ticket.Export.ID, ticket.Token),
+		},
+	})
+}
+
+// GetExport godoc
+// @Summary 개인 데이터 내보내기 상태 조회
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "내보내기 요청 ID"
+// @Success 200 {object} model.DataExport
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/me/export/{id} [get]
+func (h *DataExportHandler) GetExport(c *fiber.Ctx) error {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "로그인이 필요합니다",
+		})
+	}
+
+	export, err := h.exportService.GetExport(claims.UserID, c.Params("id"))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, service.ErrDataExportNotFound) {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    export,
+	})
+}
+
+// Download godoc
+// @Summary 개인 데이터 내보내기 ZIP 다운로드
+// @Description 내보내기 요청 시 받은 download_url로 호출합니다 (토큰으로 인증, expires_at까지 유효)
+// @Tags auth
+// @Produce application/zip
+// @Param id path string true "내보내기 요청 ID"
+// @Param token query string true "다운로드 토큰"
+// @Success 200 {file} file
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 410 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /auth/me/export/{id}/download [get]
+func (h *DataExportHandler) Download(c *fiber.Ctx) error {
+	export, path, err := h.exportService.OpenDownload(c.Params("id"), c.Query("token"))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrDataExportNotFound):
+			status = fiber.StatusNotFound
+		case errors.Is(err, service.ErrDataExportNotReady):
+			status = fiber.StatusConflict
+		case errors.Is(err, service.ErrDataExportExpired):
+			status = fiber.StatusGone
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	h.logger.Info("Data export downloaded",
+		zap.String("export_id", export.ID),
+		zap.Uint("user_id", export.UserID),
+		zap.String("ip", c.IP()),
+	)
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Download(path, fmt.Sprintf("ojeomneo-export-%s.zip", export.CreatedAt.Format(time.DateOnly)))
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// JWKSHandler JWT 공개키 핸들러
+type JWKSHandler struct {
+	keys *auth.KeySet
+}
+
+// NewJWKSHandler JWKSHandler 생성자
+func NewJWKSHandler(keys *auth.KeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+// GetJWKS godoc
+// @Summary JWT 검증용 공개키 (JWKS)
+// @Description Access/Refresh Token 서명 검증에 사용하는 공개키 목록을 반환합니다 (kid 헤더로 키 선택)
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	// 키 교체 시 새 키가 충분히 빨리 반영되도록 짧게 캐시
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.keys.JWKS())
+}
@@ -1,25 +1,30 @@
 package handler
 
 import (
+	"errors"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/ggorockee/ojeomneo/server/internal/middleware"
 	"github.com/ggorockee/ojeomneo/server/internal/service"
 )
 
 // SketchHandler 스케치 핸들러
 type SketchHandler struct {
 	sketchService *service.SketchService
+	jobQueue      *service.SketchJobQueue
 	logger        *zap.Logger
 }
 
 // NewSketchHandler 새 스케치 핸들러 생성
-func NewSketchHandler(sketchService *service.SketchService, logger *zap.Logger) *SketchHandler {
+func NewSketchHandler(sketchService *service.SketchService, jobQueue *service.SketchJobQueue, logger *zap.Logger) *SketchHandler {
 	return &SketchHandler{
 		sketchService: sketchService,
+		jobQueue:      jobQueue,
 		logger:        logger,
 	}
 }
@@ -32,30 +37,106 @@ type AnalyzeRequest struct {
 
 // Analyze godoc
 // @Summary 스케치 분석 및 메뉴 추천
-// @Description 스케치 이미지를 분석하여 감정/분위기를 파악하고 어울리는 메뉴를 추천합니다
+// @Description 스케치 이미지를 분석하여 감정/분위기를 파악하고 어울리는 메뉴를 추천합니다. async=true이면 분석 작업을 등록하고 202와 작업 ID를 즉시 반환하며, 결과는 GET /sketch/jobs/{id}로 조회합니다
 // @Tags sketch
 // @Accept multipart/form-data
 // @Produce json
+// @Param async query bool false "비동기 분석 여부"
 // @Param image formData file true "스케치 이미지 (PNG/JPEG, max 5MB)"
 // @Param text formData string false "추가 텍스트 입력"
 // @Param device_id formData string true "디바이스 식별자"
 // @Success 200 {object} map[string]interface{}
+// @Success 202 {object} service.SketchJob
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
 // @Router /sketch/analyze [post]
 func (h *SketchHandler) Analyze(c *fiber.Ctx) error {
 	start := time.Now()
 	
+	req, formErr := h.parseAnalyzeForm(c)
+	if formErr != nil {
+		return c.Status(formErr.Code).JSON(fiber.Map{
+			"success": false,
+			"error":   formErr.Message,
+		})
+	}
+
+	// 비동기 분석: 작업 등록 후 즉시 응답 (결과는 GET /sketch/jobs/:id로 조회)
+	if c.QueryBool("async") {
+		return h.enqueueAnalyze(c, req)
+	}
+
+	h.logger.Debug("Starting sketch analysis",
+		zap.String("device_id", req.DeviceID),
+		zap.Int("image_size", len(req.ImageData)),
+		zap.String("has_text", func() string {
+			if req.InputText != "" {
+				return "yes"
+			}
+			return "no"
+		}()),
+	)
+
+	result, err := h.sketchService.Analyze(c.Context(), req)
+	duration := time.Since(start)
+
+	// Fiber context는 핸들러 종료 후 재사용되므로 goroutine에서 사용할 값들을 미리 캡처
+	clientIP := c.IP()
+	deviceID := req.DeviceID
+
+	if err != nil {
+		// 비동기로 에러 로깅 (goroutine 사용)
+		go func() {
+			h.logger.Error("Sketch analyze failed",
+				zap.Error(err),
+				zap.String("device_id", deviceID),
+				zap.Duration("duration", duration),
+				zap.String("ip", clientIP),
+			)
+		}()
+		
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// 비동기로 성공 로깅 (goroutine 사용)
+	go func() {
+		h.logger.Info("Sketch analyze completed",
+			zap.String("device_id", deviceID),
+			zap.String("sketch_id", result.SketchID.String()),
+			zap.Duration("duration", duration),
+			zap.String("ip", clientIP),
+			zap.Int("recommendation_count", func() int {
+				if result.Recommendation != nil {
+					count := 1 // primary
+					if result.Recommendation.Alternatives != nil {
+						count += len(result.Recommendation.Alternatives)
+					}
+					return count
+				}
+				return 0
+			}()),
+		)
+	}()
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// parseAnalyzeForm 분석 요청 폼(device_id, image, text) 검증 및 변환 (일반/비동기/스트리밍 공통)
+func (h *SketchHandler) parseAnalyzeForm(c *fiber.Ctx) (*service.AnalyzeRequest, *fiber.Error) {
 	// 디바이스 ID 확인
 	deviceID := c.FormValue("device_id")
 	if deviceID == "" {
 		h.logger.Warn("Sketch analyze missing device_id",
 			zap.String("ip", c.IP()),
 		)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   "device_id is required",
-		})
+		return nil, fiber.NewError(fiber.StatusBadRequest, "device_id is required")
 	}
 
 	// 이미지 파일 확인
@@ -66,10 +147,7 @@ func (h *SketchHandler) Analyze(c *fiber.Ctx) error {
 			zap.String("ip", c.IP()),
 			zap.Error(err),
 		)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   "image file is required",
-		})
+		return nil, fiber.NewError(fiber.StatusBadRequest, "image file is required")
 	}
 
 	// 파일 크기 확인 (5MB)
@@ -79,10 +157,7 @@ func (h *SketchHandler) Analyze(c *fiber.Ctx) error {
 			zap.Int64("file_size", file.Size),
 			zap.String("ip", c.IP()),
 		)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   "image file too large (max 5MB)",
-		})
+		return nil, fiber.NewError(fiber.StatusBadRequest, "image file too large (max 5MB)")
 	}
 
 	// 파일 열기
@@ -92,10 +167,7 @@ func (h *SketchHandler) Analyze(c *fiber.Ctx) error {
 			zap.String("device_id", deviceID),
 			zap.Error(err),
 		)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   "failed to read image file",
-		})
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "failed to read image file")
 	}
 	defer f.Close()
 
@@ -106,76 +178,97 @@ func (h *SketchHandler) Analyze(c *fiber.Ctx) error {
 			zap.String("device_id", deviceID),
 			zap.Error(err),
 		)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   "failed to read image data",
-		})
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "failed to read image data")
 	}
 
 	// 분석 요청
-	req := &service.AnalyzeRequest{
+	// 비동기/스트리밍 분석은 핸들러 종료 후에도 값을 사용하므로 Fiber 버퍼를 참조하지 않도록 문자열 복사
+	return &service.AnalyzeRequest{
 		ImageData: imageData,
-		InputText: c.FormValue("text"),
-		DeviceID:  deviceID,
+		InputText: utils.CopyString(c.FormValue("text")),
+		DeviceID:  utils.CopyString(deviceID),
+		UserID:    middleware.GetUserID(c), // 로그인(익명 포함) 사용자면 소유자 기록
+	}, nil
+}
+
+// enqueueAnalyze 비동기 분석 작업 등록 (202 + 작업 상태 반환)
+func (h *SketchHandler) enqueueAnalyze(c *fiber.Ctx, req *service.AnalyzeRequest) error {
+	job, err := h.jobQueue.Enqueue(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrSketchQueueFull) {
+			h.logger.Warn("Sketch job queue full",
+				zap.String("device_id", req.DeviceID),
+				zap.String("ip", c.IP()),
+			)
+			c.Set("Retry-After", "5")
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+		h.logger.Error("Sketch job enqueue failed",
+			zap.Error(err),
+			zap.String("device_id", req.DeviceID),
+		)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
 	}
 
-	h.logger.Debug("Starting sketch analysis",
-		zap.String("device_id", deviceID),
-		zap.Int64("image_size", file.Size),
-		zap.String("has_text", func() string {
-			if req.InputText != "" {
-				return "yes"
-			}
-			return "no"
-		}()),
+	h.logger.Info("Sketch job enqueued",
+		zap.String("job_id", job.ID),
+		zap.String("device_id", req.DeviceID),
 	)
 
-	result, err := h.sketchService.Analyze(c.Context(), req)
-	duration := time.Since(start)
+	c.Location("/ojeomneo/v1/sketch/jobs/" + job.ID)
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data":    job,
+	})
+}
 
-	// Fiber context는 핸들러 종료 후 재사용되므로 goroutine에서 사용할 값들을 미리 캡처
-	clientIP := c.IP()
+// GetJob godoc
+// @Summary 비동기 스케치 분석 작업 조회
+// @Description 작업 상태(queued, analyzing, matching, done, failed)를 조회합니다. done이면 result에 분석 결과, failed이면 error에 실패 사유가 담깁니다 (작업은 1시간 보관)
+// @Tags sketch
+// @Produce json
+// @Param id path string true "작업 ID"
+// @Success 200 {object} service.SketchJob
+// @Failure 404 {object} map[string]interface{}
+// @Router /sketch/jobs/{id} [get]
+func (h *SketchHandler) GetJob(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"error":   "job not found",
+		})
+	}
 
+	job, err := h.jobQueue.Get(c.Context(), id)
 	if err != nil {
-		// 비동기로 에러 로깅 (goroutine 사용)
-		go func() {
-			h.logger.Error("Sketch analyze failed",
-				zap.Error(err),
-				zap.String("device_id", deviceID),
-				zap.Duration("duration", duration),
-				zap.String("ip", clientIP),
-			)
-		}()
-		
+		if errors.Is(err, service.ErrSketchJobNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"error":   "job not found",
+			})
+		}
+		h.logger.Error("Get sketch job failed",
+			zap.Error(err),
+			zap.String("job_id", id),
+		)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
 			"error":   err.Error(),
 		})
 	}
 
-	// 비동기로 성공 로깅 (goroutine 사용)
-	go func() {
-		h.logger.Info("Sketch analyze completed",
-			zap.String("device_id", deviceID),
-			zap.String("sketch_id", result.SketchID.String()),
-			zap.Duration("duration", duration),
-			zap.String("ip", clientIP),
-			zap.Int("recommendation_count", func() int {
-				if result.Recommendation != nil {
-					count := 1 // primary
-					if result.Recommendation.Alternatives != nil {
-						count += len(result.Recommendation.Alternatives)
-					}
-					return count
-				}
-				return 0
-			}()),
-		)
-	}()
-
+	// 진행 상태가 계속 바뀌므로 응답 캐시 금지
+	c.Set(fiber.HeaderCacheControl, "no-store")
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    result,
+		"data":    job,
 	})
 }
 
@@ -187,11 +280,12 @@ type HistoryQuery struct {
 
 // GetHistory godoc
 // @Summary 스케치 히스토리 조회
-// @Description 디바이스별 스케치 분석 히스토리를 조회합니다
+// @Description 디바이스별 스케치 분석 히스토리를 조회합니다 (로그인 시 계정 전체 히스토리)
 // @Tags sketch
 // @Accept json
 // @Produce json
-// @Param device_id query string true "디바이스 식별자"
+// @Security BearerAuth
+// @Param device_id query string false "디바이스 식별자 (비로그인 시 필수)"
 // @Param page query int false "페이지 번호" default(1)
 // @Param limit query int false "페이지당 개수" default(10)
 // @Success 200 {object} map[string]interface{}
@@ -200,8 +294,14 @@ type HistoryQuery struct {
 func (h *SketchHandler) GetHistory(c *fiber.Ctx) error {
 	start := time.Now()
 	
+	// 정회원은 user_id 기준 전체 히스토리, 비로그인/익명 사용자는 device_id 기준 3일 히스토리
+	var userID *uint
+	if claims := middleware.GetAuthClaims(c); claims != nil && !claims.IsGuest {
+		userID = &claims.UserID
+	}
+
 	deviceID := c.Query("device_id")
-	if deviceID == "" {
+	if deviceID == "" && userID == nil {
 		h.logger.Warn("Get history missing device_id",
 			zap.String("ip", c.IP()),
 		)
@@ -221,12 +321,6 @@ func (h *SketchHandler) GetHistory(c *fiber.Ctx) error {
 		limit = 10
 	}
 
-	// TODO: 로그인 기능 구현 후 실제 userID를 헤더나 쿼리에서 가져오기
-	// 현재는 로그인 기능이 없으므로 nil로 설정 (비로그인 사용자)
-	var userID *uint = nil
-	// 예시: userIDStr := c.Get("X-User-ID")
-	// if userIDStr != "" { userID = parseUserID(userIDStr) }
-
 	sketches, total, err := h.sketchService.GetHistory(c.Context(), deviceID, userID, page, limit)
 	duration := time.Since(start)
 	
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/service"
+)
+
+// sketchStreamTimeout 스트리밍 분석 최대 시간 (요청 컨텍스트와 분리되어 있으므로 별도 제한)
+const sketchStreamTimeout = 2 * time.Minute
+
+// AnalyzeStream godoc
+// @Summary 스케치 분석 및 메뉴 추천 (SSE 스트리밍)
+// @Description 분석 결과를 Server-Sent Events로 단계별 전송합니다. analysis(감정 분석) → menus(추천 메뉴) → reason(메뉴별 추천 이유, 생성 중에는 delta 조각, 완성 시 done=true와 reason) → done(전체 결과, /sketch/analyze 응답의 data와 같음) 순서이며, 실패 시 error 이벤트를 보내고 연결을 닫습니다
+// @Tags sketch
+// @Accept multipart/form-data
+// @Produce text/event-stream
+// @Param image formData file true "스케치 이미지 (PNG/JPEG, max 5MB)"
+// @Param text formData string false "추가 텍스트 입력"
+// @Param device_id formData string true "디바이스 식별자"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 400 {object} map[string]interface{}
+// @Router /sketch/analyze/stream [post]
+func (h *SketchHandler) AnalyzeStream(c *fiber.Ctx) error {
+	req, formErr := h.parseAnalyzeForm(c)
+	if formErr != nil {
+		return c.Status(formErr.Code).JSON(fiber.Map{
+			"success": false,
+			"error":   formErr.Message,
+		})
+	}
+
+	// 스트림 작성은 핸들러 반환 후 진행되므로 분석 컨텍스트를 요청과 분리하고,
+	// 클라이언트 연결이 끊기면(flush 실패) 취소한다
+	ctx, cancel := context.WithTimeout(context.Background(), sketchStreamTimeout)
+	events := make(chan service.SketchEvent, 16)
+	req.OnEvent = func(event service.SketchEvent) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	start := time.Now()
+	clientIP := c.IP()
+	deviceID := req.DeviceID
+
+	go func() {
+		defer close(events)
+
+		_, err := h.sketchService.Analyze(ctx, req)
+		if err != nil {
+			h.logger.Error("Sketch analyze stream failed",
+				zap.Error(err),
+				zap.String("device_id", deviceID),
+				zap.Duration("duration", time.Since(start)),
+				zap.String("ip", clientIP),
+			)
+			req.OnEvent(service.SketchEvent{Type: service.SketchEventError, Data: fiber.Map{"error": err.Error()}})
+			return
+		}
+
+		h.logger.Info("Sketch analyze stream completed",
+			zap.String("device_id", deviceID),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("ip", clientIP),
+		)
+	}()
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // nginx 프록시 버퍼링 해제
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		for event := range events {
+			if ctx.Err() != nil {
+				continue // 연결이 끊겼으면 남은 이벤트는 버림
+			}
+			if err := writeSSEEvent(w, string(event.Type), event.Data); err != nil {
+				h.logger.Debug("Sketch analyze stream client disconnected",
+					zap.Error(err),
+					zap.String("device_id", deviceID),
+				)
+				cancel()
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeSSEEvent SSE 이벤트 하나를 쓰고 즉시 전송
+func writeSSEEvent(w *bufio.Writer, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -2,10 +2,12 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/ggorockee/ojeomneo/server/internal/config"
 	"github.com/ggorockee/ojeomneo/server/internal/model"
 	"github.com/ggorockee/ojeomneo/server/internal/service"
 	"github.com/ggorockee/ojeomneo/server/internal/service/llm"
@@ -96,16 +99,22 @@ func setupSketchTestDB(t *testing.T) *gorm.DB {
 // setupSketchApp 스케치 테스트용 Fiber 앱 설정
 func setupSketchApp(t *testing.T) (*fiber.App, *gorm.DB) {
 	db := setupSketchTestDB(t)
+	t.Setenv("UPLOAD_PATH", t.TempDir()) // 업로드 이미지를 작업 트리 대신 임시 디렉터리에 저장
 
 	logger := zap.NewNop()
-	llmClient := llm.NewClient("", "gpt-4o-mini") // Mock 클라이언트
+	llmClient := llm.NewMockProvider() // 외부 API 없는 목업 제공자
 	menuService := service.NewMenuService(db, logger)
 	sketchService := service.NewSketchService(db, llmClient, menuService, logger)
-	sketchHandler := NewSketchHandler(sketchService, logger)
+	jobQueue := service.NewSketchJobQueue(sketchService, nil, &config.Config{SketchJobWorkers: 1}, logger)
+	jobQueue.Start()
+	t.Cleanup(func() { jobQueue.Stop(context.Background()) })
+	sketchHandler := NewSketchHandler(sketchService, jobQueue, logger)
 
 	app := fiber.New()
 	app.Post("/sketch/analyze", sketchHandler.Analyze)
+	app.Post("/sketch/analyze/stream", sketchHandler.AnalyzeStream)
 	app.Get("/sketch/history", sketchHandler.GetHistory)
+	app.Get("/sketch/jobs/:id", sketchHandler.GetJob)
 	app.Get("/sketch/:id", sketchHandler.GetByID)
 
 	return app, db
@@ -200,6 +209,116 @@ func TestSketchHandler_Analyze_Success(t *testing.T) {
 	})
 }
 
+func TestSketchHandler_Analyze_Async(t *testing.T) {
+	app, _ := setupSketchApp(t)
+
+	t.Run("작업 등록 후 완료될 때까지 폴링", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("device_id", "test-device-async")
+		part, _ := writer.CreateFormFile("image", "test.png")
+		part.Write([]byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A})
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/sketch/analyze?async=true", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var result struct {
+			Success bool              `json:"success"`
+			Data    service.SketchJob `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.True(t, result.Success)
+		assert.Equal(t, service.SketchJobQueued, result.Data.Status)
+		assert.Equal(t, "/ojeomneo/v1/sketch/jobs/"+result.Data.ID, resp.Header.Get("Location"))
+
+		// SQLite에서 UUID 지원 문제로 실패할 수 있으므로 완료 또는 실패 상태까지만 확인
+		var job service.SketchJob
+		require.Eventually(t, func() bool {
+			resp, err := app.Test(httptest.NewRequest("GET", "/sketch/jobs/"+result.Data.ID, nil), -1)
+			if err != nil || resp.StatusCode != fiber.StatusOK {
+				return false
+			}
+			var polled struct {
+				Data service.SketchJob `json:"data"`
+			}
+			if json.NewDecoder(resp.Body).Decode(&polled) != nil {
+				return false
+			}
+			job = polled.Data
+			return job.IsFinished()
+		}, 10*time.Second, 50*time.Millisecond)
+
+		if job.Status == service.SketchJobDone {
+			assert.NotNil(t, job.Result)
+		} else {
+			assert.NotEmpty(t, job.Error)
+		}
+	})
+
+	t.Run("없는 작업 조회", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/sketch/jobs/"+uuid.New().String(), nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestSketchHandler_AnalyzeStream(t *testing.T) {
+	app, _ := setupSketchApp(t)
+
+	t.Run("device_id 누락 시 JSON 오류 응답", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/sketch/analyze/stream", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("분석 단계별 SSE 이벤트 전송", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("device_id", "test-device-stream")
+		part, _ := writer.CreateFormFile("image", "test.png")
+		part.Write([]byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A})
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/sketch/analyze/stream", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		var events []string
+		for _, line := range strings.Split(string(bodyBytes), "\n") {
+			if strings.HasPrefix(line, "event: ") {
+				events = append(events, strings.TrimPrefix(line, "event: "))
+			}
+		}
+
+		// SQLite에서는 jsonb 메뉴 검색이 실패하므로 analysis 이후 done 또는 error로 종료
+		require.NotEmpty(t, events)
+		assert.Equal(t, "analysis", events[0])
+		last := events[len(events)-1]
+		assert.True(t, last == "done" || last == "error", last)
+		if last == "done" {
+			assert.Contains(t, events, "menus")
+			assert.Contains(t, events, "reason")
+		}
+	})
+}
+
 func TestSketchHandler_GetHistory(t *testing.T) {
 	app, db := setupSketchApp(t)
 	deviceID := "test-device-456"
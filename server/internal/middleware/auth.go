@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// AuthMode 인증 미들웨어 동작 방식
+type AuthMode int
+
+const (
+	// AuthModeRequired 정회원 토큰 필수 (익명 사용자 거부)
+	AuthModeRequired AuthMode = iota
+	// AuthModeGuestAllowed 토큰 필수, 익명 사용자 토큰도 허용
+	AuthModeGuestAllowed
+	// AuthModeOptional 토큰이 없으면 비로그인으로 통과, 있으면 검증
+	AuthModeOptional
+)
+
+// authClaimsKey fiber.Ctx Locals 저장 키
+const authClaimsKey = "auth_claims"
+
+// AuthClaims 인증된 사용자 정보 (Locals에 저장)
+type AuthClaims struct {
+	UserID    uint
+	IsGuest   bool
+	TokenID   string    // Access Token jti (로그아웃 시 denylist 등록용)
+	SessionID string    // 로그인 세션 (Refresh Token 패밀리) ID
+	ExpiresAt time.Time // Access Token 만료 시각
+}
+
+// AuthConfig 인증 미들웨어 설정
+type AuthConfig struct {
+	// JWT 검증 키
+	Keys *auth.KeySet
+	// 동작 방식 (기본: AuthModeRequired)
+	Mode AuthMode
+	// 토큰 폐기 여부 확인 (nil이면 서명/만료만 검증)
+	RevocationChecker auth.RevocationChecker
+}
+
+// Auth JWT 인증 미들웨어
+// Authorization: Bearer <token> 헤더를 검증하고 AuthClaims를 Locals에 저장
+func Auth(cfg AuthConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if authHeader == "" {
+			if cfg.Mode == AuthModeOptional {
+				return c.Next()
+			}
+			return unauthorized(c, "로그인이 필요합니다")
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return unauthorized(c, "잘못된 인증 형식입니다")
+		}
+
+		var checkers []auth.RevocationChecker
+		if cfg.RevocationChecker != nil {
+			checkers = append(checkers, cfg.RevocationChecker)
+		}
+
+		claims, err := auth.ValidateAccessToken(parts[1], cfg.Keys, checkers...)
+		if err != nil {
+			if errors.Is(err, auth.ErrTokenRevoked) {
+				return unauthorized(c, "로그아웃되었거나 더 이상 유효하지 않은 계정입니다. 다시 로그인해 주세요")
+			}
+			return unauthorized(c, "로그인이 만료되었습니다. 다시 로그인해 주세요")
+		}
+
+		if claims.IsGuest && cfg.Mode == AuthModeRequired {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"error":   "회원 전용 기능입니다. 로그인해 주세요",
+			})
+		}
+
+		authClaims := &AuthClaims{
+			UserID:    claims.UserID,
+			IsGuest:   claims.IsGuest,
+			TokenID:   claims.ID,
+			SessionID: claims.SessionID,
+		}
+		if claims.ExpiresAt != nil {
+			authClaims.ExpiresAt = claims.ExpiresAt.Time
+		}
+		c.Locals(authClaimsKey, authClaims)
+
+		return c.Next()
+	}
+}
+
+// RequireAuth 정회원 인증 필수 미들웨어
+func RequireAuth(keys *auth.KeySet, checker auth.RevocationChecker) fiber.Handler {
+	return Auth(AuthConfig{Keys: keys, Mode: AuthModeRequired, RevocationChecker: checker})
+}
+
+// GuestAllowedAuth 익명 사용자 포함 인증 필수 미들웨어
+func GuestAllowedAuth(keys *auth.KeySet, checker auth.RevocationChecker) fiber.Handler {
+	return Auth(AuthConfig{Keys: keys, Mode: AuthModeGuestAllowed, RevocationChecker: checker})
+}
+
+// OptionalAuth 선택적 인증 미들웨어 (토큰이 있을 때만 사용자 식별)
+func OptionalAuth(keys *auth.KeySet, checker auth.RevocationChecker) fiber.Handler {
+	return Auth(AuthConfig{Keys: keys, Mode: AuthModeOptional, RevocationChecker: checker})
+}
+
+// AdminKeyHeader 관리자(Django admin) 서비스 호출용 공유 키 헤더
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminKeyOrAuth 관리자 공유 키가 일치하면 통과, 아니면 next(사용자 인증)로 검증
+// adminKey가 비어 있으면 공유 키 인증을 사용하지 않는다.
+func AdminKeyOrAuth(adminKey string, next fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if adminKey != "" {
+			if key := c.Get(AdminKeyHeader); key != "" &&
+				subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) == 1 {
+				return c.Next()
+			}
+		}
+		return next(c)
+	}
+}
+
+// GetAuthClaims Locals에서 인증 정보 조회 (비로그인 시 nil)
+func GetAuthClaims(c *fiber.Ctx) *AuthClaims {
+	claims, ok := c.Locals(authClaimsKey).(*AuthClaims)
+	if !ok {
+		return nil
+	}
+	return claims
+}
+
+// GetUserID Locals에서 사용자 ID 조회 (비로그인 시 nil)
+func GetUserID(c *fiber.Ctx) *uint {
+	claims := GetAuthClaims(c)
+	if claims == nil {
+		return nil
+	}
+	userID := claims.UserID
+	return &userID
+}
+
+// unauthorized 401 응답 (기존 auth 핸들러 응답 형식과 동일)
+func unauthorized(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// testKeys 테스트용 HS256 키
+var testKeys = auth.NewHMACKeySet("test-secret-key")
+
+// setupAuthApp 인증 미들웨어 테스트용 Fiber 앱 설정
+func setupAuthApp(mode AuthMode) *fiber.App {
+	app := fiber.New()
+	app.Get("/protected", Auth(AuthConfig{Keys: testKeys, Mode: mode}), func(c *fiber.Ctx) error {
+		claims := GetAuthClaims(c)
+		if claims == nil {
+			return c.JSON(fiber.Map{"user_id": nil})
+		}
+		return c.JSON(fiber.Map{"user_id": claims.UserID, "is_guest": claims.IsGuest})
+	})
+	return app
+}
+
+func doAuthRequest(t *testing.T, app *fiber.App, token string) (int, map[string]interface{}) {
+	req := httptest.NewRequest("GET", "/protected", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func TestAuth_Required(t *testing.T) {
+	app := setupAuthApp(AuthModeRequired)
+
+	t.Run("토큰 없음", func(t *testing.T) {
+		status, _ := doAuthRequest(t, app, "")
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("잘못된 토큰", func(t *testing.T) {
+		status, _ := doAuthRequest(t, app, "invalid-token")
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("정회원 토큰", func(t *testing.T) {
+		token, err := auth.GenerateAccessToken(42, "", testKeys, 15)
+		require.NoError(t, err)
+
+		status, result := doAuthRequest(t, app, token)
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, float64(42), result["user_id"])
+		assert.Equal(t, false, result["is_guest"])
+	})
+
+	t.Run("익명 토큰 거부", func(t *testing.T) {
+		token, err := auth.GenerateGuestToken(7, "", testKeys, 7)
+		require.NoError(t, err)
+
+		status, _ := doAuthRequest(t, app, token)
+		assert.Equal(t, fiber.StatusForbidden, status)
+	})
+
+	t.Run("refresh 토큰 거부", func(t *testing.T) {
+		token, err := auth.GenerateRefreshToken(42, "test-token-id", "", testKeys, 7)
+		require.NoError(t, err)
+
+		status, _ := doAuthRequest(t, app, token)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+}
+
+func TestAuth_GuestAllowed(t *testing.T) {
+	app := setupAuthApp(AuthModeGuestAllowed)
+
+	token, err := auth.GenerateGuestToken(7, "", testKeys, 7)
+	require.NoError(t, err)
+
+	status, result := doAuthRequest(t, app, token)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, float64(7), result["user_id"])
+	assert.Equal(t, true, result["is_guest"])
+}
+
+func TestAuth_Optional(t *testing.T) {
+	app := setupAuthApp(AuthModeOptional)
+
+	t.Run("토큰 없으면 비로그인으로 통과", func(t *testing.T) {
+		status, result := doAuthRequest(t, app, "")
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Nil(t, result["user_id"])
+	})
+
+	t.Run("토큰이 있으면 검증", func(t *testing.T) {
+		status, _ := doAuthRequest(t, app, "invalid-token")
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+}
+
+// revokeAllChecker 모든 토큰을 폐기로 판단하는 테스트용 RevocationChecker
+type revokeAllChecker struct{}
+
+func (revokeAllChecker) IsRevoked(claims *auth.Claims) (bool, error) {
+	return true, nil
+}
+
+func TestAuth_RevokedToken(t *testing.T) {
+	app := fiber.New()
+	app.Get("/protected", Auth(AuthConfig{
+		Keys:              testKeys,
+		Mode:              AuthModeRequired,
+		RevocationChecker: revokeAllChecker{},
+	}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	token, err := auth.GenerateAccessToken(42, "session-id", testKeys, 15)
+	require.NoError(t, err)
+
+	status, result := doAuthRequest(t, app, token)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+	assert.Equal(t, false, result["success"])
+}
+
+func TestAdminKeyOrAuth(t *testing.T) {
+	app := fiber.New()
+	app.Post("/images/upload", AdminKeyOrAuth("admin-key", RequireAuth(testKeys, nil)), func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"success": true})
+	})
+
+	doUpload := func(header, value string) int {
+		req := httptest.NewRequest("POST", "/images/upload", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	t.Run("관리자 공유 키", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, doUpload(AdminKeyHeader, "admin-key"))
+	})
+
+	t.Run("잘못된 공유 키는 사용자 인증으로 검증", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusUnauthorized, doUpload(AdminKeyHeader, "wrong-key"))
+	})
+
+	t.Run("정회원 토큰", func(t *testing.T) {
+		token, err := auth.GenerateAccessToken(42, "", testKeys, 15)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, doUpload("Authorization", "Bearer "+token))
+	})
+
+	t.Run("공유 키 미설정 시 빈 헤더로 통과 불가", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/images/upload", AdminKeyOrAuth("", RequireAuth(testKeys, nil)), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+		req := httptest.NewRequest("POST", "/images/upload", nil)
+		req.Header.Set(AdminKeyHeader, "")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
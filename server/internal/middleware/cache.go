@@ -82,6 +82,8 @@ func DefaultCacheConfig() CacheConfig {
 		SkipPaths: []string{
 			"/ojeomneo/v1/healthcheck",
 			"/ojeomneo/v1/docs",
+			"/ojeomneo/v1/auth",        // 인증 콜백, 다운로드 링크 등 토큰 없이 호출되는 사용자별 응답
+			"/ojeomneo/v1/sketch/jobs", // 비동기 분석 작업 상태 폴링 (요청마다 상태가 바뀜)
 			"/ojeomneo/metrics",
 		},
 		Methods:   []string{"GET"},
@@ -128,6 +130,11 @@ func Cache(config ...CacheConfig) fiber.Handler {
 			return c.Next()
 		}
 
+		// 인증된 요청은 사용자별 응답이므로 캐시하지 않음 (캐시 키에 사용자 정보 없음)
+		if c.Get("Authorization") != "" {
+			return c.Next()
+		}
+
 		// 캐시 키 생성 (경로 + 쿼리스트링)
 		cacheKey := generateCacheKey(cfg.KeyPrefix, path, c.Request().URI().QueryString())
 
@@ -174,6 +181,11 @@ func Cache(config ...CacheConfig) fiber.Handler {
 			return nil
 		}
 
+		// 핸들러가 Cache-Control: no-store로 캐시를 금지한 응답은 저장하지 않음
+		if strings.Contains(string(c.Response().Header.Peek(fiber.HeaderCacheControl)), "no-store") {
+			return nil
+		}
+
 		// 응답 캐시
 		ttl := cfg.DefaultTTL
 		for pathPrefix, pathTTL := range cfg.PathTTL {
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRedis GET/SET만 메모리에서 처리하는 테스트용 Redis 훅 (실제 연결 없음)
+type memoryRedis struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (m *memoryRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (m *memoryRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		args := cmd.Args()
+		switch cmd.Name() {
+		case "get":
+			value, ok := m.data[args[1].(string)]
+			if !ok {
+				cmd.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			cmd.(*redis.StringCmd).SetVal(value)
+		case "set":
+			m.data[args[1].(string)] = string(args[2].([]byte))
+			cmd.(*redis.StatusCmd).SetVal("OK")
+		}
+		return nil
+	}
+}
+
+func (m *memoryRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// setupCacheApp 캐시 미들웨어 테스트용 Fiber 앱 (요청마다 응답 값이 바뀜)
+func setupCacheApp(t *testing.T) *fiber.App {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	client.AddHook(&memoryRedis{data: map[string]string{}})
+	t.Cleanup(func() { client.Close() })
+
+	cfg := DefaultCacheConfig()
+	cfg.RedisClient = client
+
+	var calls int
+	counter := func(c *fiber.Ctx) error {
+		calls++
+		return c.SendString(strconv.Itoa(calls))
+	}
+
+	app := fiber.New()
+	app.Use(Cache(cfg))
+	app.Get("/ojeomneo/v1/menu", counter)
+	app.Get("/ojeomneo/v1/sketch/jobs/:id", counter)
+	app.Get("/ojeomneo/v1/live", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return counter(c)
+	})
+	return app
+}
+
+// getBody GET 요청 후 응답 본문과 X-Cache 헤더 반환
+func getBody(t *testing.T, app *fiber.App, path string) (string, string) {
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body), resp.Header.Get("X-Cache")
+}
+
+func TestCache(t *testing.T) {
+	t.Run("일반 GET 응답은 캐시", func(t *testing.T) {
+		app := setupCacheApp(t)
+
+		first, _ := getBody(t, app, "/ojeomneo/v1/menu")
+		second, cache := getBody(t, app, "/ojeomneo/v1/menu")
+		assert.Equal(t, first, second)
+		assert.Equal(t, "HIT", cache)
+	})
+
+	t.Run("작업 상태 폴링은 매번 새 응답", func(t *testing.T) {
+		app := setupCacheApp(t)
+		path := "/ojeomneo/v1/sketch/jobs/00000000-0000-0000-0000-000000000001"
+
+		first, _ := getBody(t, app, path)
+		second, cache := getBody(t, app, path)
+		assert.NotEqual(t, first, second)
+		assert.Empty(t, cache)
+	})
+
+	t.Run("Cache-Control: no-store 응답은 저장하지 않음", func(t *testing.T) {
+		app := setupCacheApp(t)
+
+		first, _ := getBody(t, app, "/ojeomneo/v1/live")
+		second, cache := getBody(t, app, "/ojeomneo/v1/live")
+		assert.NotEqual(t, first, second)
+		assert.NotEqual(t, "HIT", cache)
+	})
+}
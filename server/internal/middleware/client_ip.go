@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ClientIP 신뢰 프록시(fiber.Config.TrustedProxies)를 거친 요청의 원 클라이언트 IP
+// X-Forwarded-For의 맨 앞 주소는 클라이언트가 임의로 넣을 수 있으므로, 오른쪽(가까운 hop)부터 신뢰 프록시를 건너뛰고
+// 처음 만나는 주소를 사용한다. 연결한 쪽이 신뢰 프록시가 아니면 헤더를 무시하고 연결 IP를 사용한다.
+func ClientIP(c *fiber.Ctx) string {
+	remoteIP := c.Context().RemoteIP().String()
+	cfg := c.App().Config()
+	if !cfg.EnableTrustedProxyCheck || !c.IsProxyTrusted() {
+		return remoteIP
+	}
+
+	hops := strings.Split(c.Get(fiber.HeaderXForwardedFor), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// 형식이 잘못된 hop 너머는 신뢰할 수 없음
+			break
+		}
+		if !isTrustedProxy(ip, cfg.TrustedProxies) {
+			return ip.String()
+		}
+	}
+	return remoteIP
+}
+
+// isTrustedProxy ip가 신뢰 프록시 목록(IP 또는 CIDR)에 포함되는지 확인
+func isTrustedProxy(ip net.IP, trustedProxies []string) bool {
+	for _, proxy := range trustedProxies {
+		if strings.Contains(proxy, "/") {
+			if _, network, err := net.ParseCIDR(proxy); err == nil && network.Contains(ip) {
+				return true
+			}
+		} else if trusted := net.ParseIP(proxy); trusted != nil && trusted.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupClientIPApp ClientIP 테스트용 Fiber 앱 (app.Test 요청의 연결 IP는 0.0.0.0)
+func setupClientIPApp(trustedProxies ...string) *fiber.App {
+	app := fiber.New(fiber.Config{
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
+	})
+	app.Get("/ip", func(c *fiber.Ctx) error {
+		return c.SendString(ClientIP(c))
+	})
+	return app
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		forwardedFor   string
+		want           string
+	}{
+		{"프록시 헤더 없으면 연결 IP", []string{"0.0.0.0"}, "", "0.0.0.0"},
+		{"신뢰 프록시가 덧붙인 클라이언트 IP", []string{"0.0.0.0"}, "198.51.100.1", "198.51.100.1"},
+		{"조작한 맨 앞 주소는 무시", []string{"0.0.0.0", "10.0.0.0/8"}, "6.6.6.6, 198.51.100.1, 10.0.0.2", "198.51.100.1"},
+		{"신뢰하지 않는 연결은 헤더 무시", []string{"10.0.0.0/8"}, "198.51.100.1", "0.0.0.0"},
+		{"형식이 잘못된 hop은 연결 IP", []string{"0.0.0.0"}, "198.51.100.1, not-an-ip", "0.0.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/ip", nil)
+			if tt.forwardedFor != "" {
+				req.Header.Set(fiber.HeaderXForwardedFor, tt.forwardedFor)
+			}
+			resp, err := setupClientIPApp(tt.trustedProxies...).Test(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+
+	t.Run("신뢰 프록시 검사를 끄면 헤더 무시", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/ip", func(c *fiber.Ctx) error {
+			return c.SendString(ClientIP(c))
+		})
+		req := httptest.NewRequest("GET", "/ip", nil)
+		req.Header.Set(fiber.HeaderXForwardedFor, "198.51.100.1")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "0.0.0.0", string(body))
+	})
+}
@@ -86,13 +86,7 @@ func RateLimiter(config ...RateLimitConfig) fiber.Handler {
 		}
 
 		// 클라이언트 IP 추출
-		clientIP := c.IP()
-		if realIP := c.Get("X-Real-IP"); realIP != "" {
-			clientIP = realIP
-		}
-		if forwardedFor := c.Get("X-Forwarded-For"); forwardedFor != "" {
-			clientIP = forwardedFor
-		}
+		clientIP := ClientIP(c)
 
 		// Rate Limit 키
 		key := fmt.Sprintf("ratelimit:api:%s", clientIP)
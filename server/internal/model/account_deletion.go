@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// AccountDeletion 회원 탈퇴 요청 기록
+// 탈퇴 요청 시 사용자는 soft delete되고, PurgeAfter 이전에 다시 로그인하면 복구된다.
+// PurgeAfter가 지나면 정리 작업이 사용자와 스케치/이미지를 완전히 삭제하고 PurgedAt을 기록한다.
+// 완전 삭제 후에는 개인 식별 정보 없이 탈퇴 사유 통계용으로만 남는다.
+type AccountDeletion struct {
+	ID          uint        `gorm:"primaryKey" json:"-"`
+	UserID      uint        `gorm:"not null;uniqueIndex:idx_account_deletion_user" json:"-"`
+	Reason      string      `gorm:"type:text;not null;default:''" json:"reason,omitempty"`
+	LoginMethod LoginMethod `gorm:"size:20;not null;default:''" json:"-"` // 탈퇴 시점 로그인 방식 (통계용)
+	RequestedAt time.Time   `gorm:"not null" json:"requested_at"`
+	PurgeAfter  time.Time   `gorm:"not null;index:idx_account_deletion_purge" json:"purge_after"` // 이 시각 이후 완전 삭제 (복구 가능 기한)
+	PurgedAt    *time.Time  `gorm:"" json:"-"`
+	CreatedAt   time.Time   `gorm:"autoCreateTime;not null" json:"-"`
+}
+
+// TableName GORM 테이블명 지정
+func (AccountDeletion) TableName() string {
+	return "account_deletions"
+}
+
+// IsRestorable 유예 기간 내 복구 가능 여부 확인
+func (d *AccountDeletion) IsRestorable() bool {
+	return d.PurgedAt == nil && time.Now().Before(d.PurgeAfter)
+}
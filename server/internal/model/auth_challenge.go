@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// AuthChallenge 일회용 인증 challenge (Redis 미사용 시 fallback 저장소)
+// Key는 "<purpose>:<challenge>" 형식이며, 사용 즉시 삭제된다.
+type AuthChallenge struct {
+	Key       string    `gorm:"size:255;primaryKey" json:"key"`
+	Payload   string    `gorm:"type:text;not null;default:''" json:"payload"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_auth_challenge_expires" json:"expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName GORM 테이블명 지정
+func (AuthChallenge) TableName() string {
+	return "auth_challenges"
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+)
+
+// DataExportStatus 개인 데이터 내보내기 상태
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "pending"    // 요청 접수
+	DataExportStatusProcessing DataExportStatus = "processing" // ZIP 생성 중
+	DataExportStatusCompleted  DataExportStatus = "completed"  // 다운로드 가능
+	DataExportStatusFailed     DataExportStatus = "failed"     // 생성 실패
+	DataExportStatusExpired    DataExportStatus = "expired"    // 링크 만료 (파일 삭제됨)
+)
+
+// DataExport 개인 데이터 내보내기 요청
+// 요청 시 발급한 다운로드 토큰은 해시만 저장하며, ExpiresAt이 지나면 파일을 삭제하고 expired로 변경한다.
+type DataExport struct {
+	ID          string           `gorm:"size:36;primaryKey" json:"id"`
+	UserID      uint             `gorm:"not null;index:idx_data_export_user" json:"-"`
+	Status      DataExportStatus `gorm:"size:20;not null;default:'pending'" json:"status"`
+	TokenHash   string           `gorm:"size:64;not null" json:"-"`
+	FilePath    string           `gorm:"type:text;not null;default:''" json:"-"` // DATA_EXPORT_PATH 기준 상대 경로
+	FileSize    int64            `gorm:"not null;default:0" json:"file_size,omitempty"`
+	Error       string           `gorm:"type:text;not null;default:''" json:"-"`
+	ExpiresAt   time.Time        `gorm:"not null;index:idx_data_export_expires" json:"expires_at"`
+	CompletedAt *time.Time       `gorm:"" json:"completed_at,omitempty"`
+	CreatedAt   time.Time        `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+// TableName GORM 테이블명 지정
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+// IsInProgress 생성 중(대기 포함) 여부 확인
+func (e *DataExport) IsInProgress() bool {
+	return e.Status == DataExportStatusPending || e.Status == DataExportStatusProcessing
+}
+
+// IsDownloadable 다운로드 가능 여부 확인
+func (e *DataExport) IsDownloadable() bool {
+	return e.Status == DataExportStatusCompleted && time.Now().Before(e.ExpiresAt)
+}
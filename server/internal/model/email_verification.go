@@ -4,19 +4,31 @@ import (
 	"time"
 )
 
+// EmailVerificationPurpose 이메일 인증 용도 (용도별로 코드와 토큰을 분리)
+type EmailVerificationPurpose string
+
+const (
+	EmailPurposeSignup        EmailVerificationPurpose = "signup"         // 회원가입/이메일 로그인 연결
+	EmailPurposePasswordReset EmailVerificationPurpose = "password_reset" // 비밀번호 재설정
+	EmailPurposeMagicLink     EmailVerificationPurpose = "magic_link"     // 비밀번호 없는 로그인
+)
+
 // EmailVerification 이메일 인증 모델
+// 인증코드와 인증 후 발급한 토큰은 HMAC 해시만 저장한다.
+// 코드 확인에 성공하면 코드 해시를 지우고 토큰 해시를 기록하며, ExpiresAt은 토큰 만료 시각으로 바뀐다.
 type EmailVerification struct {
-	ID                uint       `gorm:"primaryKey" json:"id"`
-	Email             string     `gorm:"size:255;not null;index:idx_email_verification_email" json:"email"`
-	Code              string     `gorm:"size:10;not null" json:"-"`
-	VerificationToken *string    `gorm:"size:255;uniqueIndex:idx_email_verification_token" json:"-"`
-	IsVerified        bool       `gorm:"default:false;not null" json:"is_verified"`
-	ExpiresAt         time.Time  `gorm:"not null;index:idx_email_verification_expires" json:"expires_at"`
-	SendCount         int        `gorm:"default:1;not null" json:"send_count"`
-	LastSentAt        *time.Time `gorm:"" json:"last_sent_at,omitempty"`
-	Attempts          int        `gorm:"default:0;not null" json:"attempts"`
-	CreatedAt         time.Time  `gorm:"autoCreateTime;not null" json:"created_at"`
-	UpdatedAt         time.Time  `gorm:"autoUpdateTime;not null" json:"updated_at"`
+	ID                uint                     `gorm:"primaryKey" json:"id"`
+	Email             string                   `gorm:"size:255;not null;index:idx_email_verification_email" json:"email"`
+	Purpose           EmailVerificationPurpose `gorm:"size:20;not null;default:'signup'" json:"purpose"`
+	CodeHash          string                   `gorm:"column:code;size:64;not null" json:"-"`
+	VerificationToken *string                  `gorm:"size:255;uniqueIndex:idx_email_verification_token" json:"-"` // 토큰 HMAC 해시
+	IsVerified        bool                     `gorm:"default:false;not null" json:"is_verified"`
+	ExpiresAt         time.Time                `gorm:"not null;index:idx_email_verification_expires" json:"expires_at"`
+	SendCount         int                      `gorm:"default:1;not null" json:"send_count"`
+	LastSentAt        *time.Time               `gorm:"" json:"last_sent_at,omitempty"`
+	Attempts          int                      `gorm:"default:0;not null" json:"attempts"`
+	CreatedAt         time.Time                `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt         time.Time                `gorm:"autoUpdateTime;not null" json:"updated_at"`
 }
 
 // TableName GORM 테이블명 지정
@@ -29,11 +41,24 @@ func (e *EmailVerification) IsExpired() bool {
 	return time.Now().After(e.ExpiresAt)
 }
 
-// CanResend 재발송 가능 여부 확인 (60초 쿨다운)
-func (e *EmailVerification) CanResend() bool {
+// CanResend 재발송 가능 여부 확인 (마지막 발송 후 cooldown 경과)
+func (e *EmailVerification) CanResend(cooldown time.Duration) bool {
 	if e.LastSentAt == nil {
 		return true
 	}
-	return time.Since(*e.LastSentAt) >= 60*time.Second
+	return time.Since(*e.LastSentAt) >= cooldown
 }
 
+// EmailSendLog 인증 메일 발송 기록 (이메일/IP별 일일 발송 한도 집계용)
+type EmailSendLog struct {
+	ID      uint                     `gorm:"primaryKey" json:"id"`
+	Email   string                   `gorm:"size:255;not null;index:idx_email_send_log_email" json:"email"`
+	IP      string                   `gorm:"size:45;not null;default:'';index:idx_email_send_log_ip" json:"ip"`
+	Purpose EmailVerificationPurpose `gorm:"size:20;not null" json:"purpose"`
+	SentAt  time.Time                `gorm:"not null;index:idx_email_send_log_sent" json:"sent_at"`
+}
+
+// TableName GORM 테이블명 지정
+func (EmailSendLog) TableName() string {
+	return "email_send_logs"
+}
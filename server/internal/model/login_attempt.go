@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+// LoginAttempt 로그인 실패 기록 (Redis 미사용 시 fallback 저장소)
+// Key는 "account:<email>" 또는 "ip:<ip>" 형식
+type LoginAttempt struct {
+	Key           string     `gorm:"size:320;primaryKey" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `gorm:"not null" json:"last_failure_at"`
+	LockedUntil   *time.Time `gorm:"" json:"locked_until,omitempty"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime;index:idx_login_attempt_updated" json:"updated_at"`
+}
+
+// TableName GORM 테이블명 지정
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+// IsLocked 잠금 여부 확인
+func (a *LoginAttempt) IsLocked() bool {
+	return a.LockedUntil != nil && time.Now().Before(*a.LockedUntil)
+}
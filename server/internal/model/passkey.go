@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+)
+
+// PasskeyCredential 사용자 계정에 등록된 passkey (WebAuthn 인증기 공개키)
+// CredentialID는 인증기가 발급한 ID(base64url)로 전체에서 유일하며,
+// SignCount는 복제된 인증기를 탐지하기 위해 로그인마다 갱신한다.
+type PasskeyCredential struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"not null;index:idx_passkey_user" json:"-"`
+	CredentialID string     `gorm:"size:1400;not null;uniqueIndex:idx_passkey_credential" json:"-"`
+	PublicKey    []byte     `gorm:"not null" json:"-"` // COSE_Key (CBOR)
+	Algorithm    int64      `gorm:"not null" json:"algorithm"`
+	SignCount    uint32     `gorm:"not null;default:0" json:"-"`
+	AAGUID       string     `gorm:"size:36;not null;default:''" json:"aaguid"` // 인증기 모델 식별자
+	Name         string     `gorm:"size:100;not null;default:''" json:"name"`
+	LastUsedAt   *time.Time `gorm:"" json:"last_used_at,omitempty"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName GORM 테이블명 지정
+func (PasskeyCredential) TableName() string {
+	return "passkey_credentials"
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+)
+
+// RefreshToken 발급된 Refresh Token 기록 (회전 및 재사용 탐지용)
+// 같은 로그인에서 회전된 토큰들은 하나의 FamilyID를 공유하며,
+// 이미 회전된 토큰이 다시 사용되면 패밀리 전체를 폐기한다.
+type RefreshToken struct {
+	ID         string     `gorm:"size:36;primaryKey" json:"id"`                                     // JWT jti
+	FamilyID   string     `gorm:"size:36;not null;index:idx_refresh_token_family" json:"family_id"` // 최초 로그인 시 생성, 회전 시 승계
+	UserID     uint       `gorm:"not null;index:idx_refresh_token_user" json:"user_id"`
+	ExpiresAt  time.Time  `gorm:"not null;index:idx_refresh_token_expires" json:"expires_at"`
+	RevokedAt  *time.Time `gorm:"" json:"revoked_at,omitempty"`
+	ReplacedBy *string    `gorm:"size:36" json:"replaced_by,omitempty"` // 회전으로 발급된 다음 토큰 ID
+	CreatedAt  time.Time  `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+// TableName GORM 테이블명 지정
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsExpired 만료 여부 확인
+func (r *RefreshToken) IsExpired() bool {
+	return time.Now().After(r.ExpiresAt)
+}
+
+// IsRevoked 폐기(회전 포함) 여부 확인
+func (r *RefreshToken) IsRevoked() bool {
+	return r.RevokedAt != nil
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// Session 로그인 세션 (기기별 로그인 상태)
+// ID는 JWT sid 및 Refresh Token FamilyID와 같으며, 로그인할 때마다 새로 생성되고
+// Refresh Token 회전 시 마지막 접속 정보가 갱신된다.
+// DeviceID는 User.DeviceID, Sketch.DeviceID와 같은 앱 설치 단위 식별자.
+type Session struct {
+	ID          string      `gorm:"size:36;primaryKey" json:"id"`
+	UserID      uint        `gorm:"not null;index:idx_session_user" json:"-"`
+	LoginMethod LoginMethod `gorm:"size:20;not null;default:''" json:"login_method"`
+	DeviceID    *string     `gorm:"size:255;index:idx_session_device" json:"device_id,omitempty"`
+	Platform    string      `gorm:"size:20;not null;default:''" json:"platform"`
+	AppVersion  string      `gorm:"size:20;not null;default:''" json:"app_version"`
+	IP          string      `gorm:"size:45;not null;default:''" json:"ip"`
+	UserAgent   string      `gorm:"size:255;not null;default:''" json:"user_agent"`
+	LastSeenAt  time.Time   `gorm:"not null" json:"last_seen_at"`
+	ExpiresAt   time.Time   `gorm:"not null;index:idx_session_expires" json:"expires_at"`
+	RevokedAt   *time.Time  `gorm:"" json:"-"`
+	CreatedAt   time.Time   `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+// TableName GORM 테이블명 지정
+func (Session) TableName() string {
+	return "user_sessions"
+}
+
+// IsActive 유효한(폐기되지 않고 만료되지 않은) 세션인지 확인
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
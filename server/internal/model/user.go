@@ -10,18 +10,20 @@ import (
 type LoginMethod string
 
 const (
-	LoginMethodEmail  LoginMethod = "email"
-	LoginMethodKakao  LoginMethod = "kakao"
-	LoginMethodGoogle LoginMethod = "google"
-	LoginMethodApple  LoginMethod = "apple"
-	LoginMethodGuest  LoginMethod = "guest" // 익명 사용자
+	LoginMethodEmail   LoginMethod = "email"
+	LoginMethodKakao   LoginMethod = "kakao"
+	LoginMethodGoogle  LoginMethod = "google"
+	LoginMethodApple   LoginMethod = "apple"
+	LoginMethodNaver   LoginMethod = "naver"
+	LoginMethodPasskey LoginMethod = "passkey" // WebAuthn passkey (기존 계정에 등록한 경우에만 사용)
+	LoginMethodGuest   LoginMethod = "guest"   // 익명 사용자
 )
 
 // User 사용자 모델
 // Django AbstractUser와 호환되는 필드 구조
 type User struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
-	Password    string         `gorm:"size:128;not null" json:"-"`
+	Password    string         `gorm:"size:128;not null" json:"-"` // Django 해시 형식 (pbkdf2_sha256$...), Django admin과 공유
 	LastLogin   *time.Time     `gorm:"" json:"last_login,omitempty"`
 	IsSuperuser bool           `gorm:"default:false;not null" json:"is_superuser"`
 	Username    string         `gorm:"size:150;uniqueIndex;not null" json:"username"`
@@ -42,6 +44,19 @@ type User struct {
 	// 익명 세션 지원 필드 (로그인하지 않고 둘러보기)
 	IsGuest  bool    `gorm:"default:false;not null" json:"is_guest"`             // 익명 사용자 여부
 	DeviceID *string `gorm:"size:255;uniqueIndex:idx_device_id" json:"device_id"` // 디바이스 고유 ID (UUID)
+
+	// 토큰 폐기 기준 시각: 이 시각 이전에 발급된 토큰은 모두 무효 (전체 로그아웃/탈퇴)
+	TokensRevokedBefore *time.Time `gorm:"" json:"-"`
+
+	// Apple 비공개 릴레이 이메일 전달 중지 여부 (Apple email-disabled/email-enabled 알림으로 갱신)
+	AppleEmailDisabled bool `gorm:"not null;default:false" json:"-"`
+
+	// 2단계 인증(TOTP): 비밀키는 암호화하여 저장, 복구 코드는 해시 목록(JSON)으로 저장
+	// TOTPSecret만 있고 TOTPEnabledAt이 없으면 등록 진행 중 (코드 확인 전)
+	TOTPSecret        string     `gorm:"column:totp_secret;size:255;not null;default:''" json:"-"`
+	TOTPEnabledAt     *time.Time `gorm:"column:totp_enabled_at" json:"-"`
+	TOTPLastUsedStep  int64      `gorm:"column:totp_last_used_step;not null;default:0" json:"-"` // 코드 재사용 방지
+	TOTPRecoveryCodes string     `gorm:"column:totp_recovery_codes;type:text;not null;default:''" json:"-"`
 }
 
 // TableName GORM 테이블명 지정
@@ -66,3 +81,8 @@ func (u *User) IsSocialUser() bool {
 func (u *User) IsGuestUser() bool {
 	return u.IsGuest
 }
+
+// MFAEnabled 2단계 인증 활성화 여부
+func (u *User) MFAEnabled() bool {
+	return u.TOTPEnabledAt != nil && u.TOTPSecret != ""
+}
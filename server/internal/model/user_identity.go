@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+// UserIdentity 사용자 계정에 연결된 로그인 수단
+// 한 계정에 Google/Apple/Kakao/이메일 로그인을 함께 연결할 수 있으며,
+// (provider, subject) 조합은 전체에서 하나의 계정에만 연결된다.
+type UserIdentity struct {
+	ID       uint        `gorm:"primaryKey" json:"id"`
+	UserID   uint        `gorm:"not null;index:idx_user_identity_user" json:"-"`
+	Provider LoginMethod `gorm:"size:20;not null;uniqueIndex:idx_user_identity_provider_subject" json:"provider"`
+	Subject  string      `gorm:"size:255;not null;uniqueIndex:idx_user_identity_provider_subject" json:"-"` // SNS 고유 ID (이메일 로그인은 정규화된 이메일)
+	Email    string      `gorm:"size:254;not null;default:''" json:"email"`
+	LinkedAt time.Time   `gorm:"not null" json:"linked_at"`
+
+	// 제공자 refresh token (암호화 저장, 탈퇴 시 제공자 측 토큰 폐기용, 현재 Apple만 사용)
+	RefreshToken string `gorm:"type:text;not null;default:''" json:"-"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"-"`
+}
+
+// TableName GORM 테이블명 지정
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -62,11 +62,21 @@ func DatabaseModule() fx.Option {
 						logger.Info("Running database migrations...")
 						models := []interface{}{
 							&model.User{},
+							&model.EmailVerification{},
+							&model.EmailSendLog{},
 							&model.Menu{},
 							&model.MenuImage{},
 							&model.Sketch{},
 							&model.Recommendation{},
 							&model.AppVersion{},
+							&model.RefreshToken{},
+							&model.UserIdentity{},
+							&model.LoginAttempt{},
+							&model.Session{},
+							&model.PasskeyCredential{},
+							&model.AuthChallenge{},
+							&model.AccountDeletion{},
+							&model.DataExport{},
 						}
 
 						if err := db.AutoMigrate(models...); err != nil {
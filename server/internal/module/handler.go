@@ -5,6 +5,7 @@ import (
 	"github.com/ggorockee/ojeomneo/server/internal/handler"
 	"github.com/ggorockee/ojeomneo/server/internal/service"
 	"github.com/ggorockee/ojeomneo/server/internal/service/cloudflare"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -22,8 +23,8 @@ func HandlerModule() fx.Option {
 			func(menuService *service.MenuService, logger *zap.Logger) *handler.MenuHandler {
 				return handler.NewMenuHandler(menuService, logger)
 			},
-			func(sketchService *service.SketchService, logger *zap.Logger) *handler.SketchHandler {
-				return handler.NewSketchHandler(sketchService, logger)
+			func(sketchService *service.SketchService, jobQueue *service.SketchJobQueue, logger *zap.Logger) *handler.SketchHandler {
+				return handler.NewSketchHandler(sketchService, jobQueue, logger)
 			},
 			func(db *gorm.DB, logger *zap.Logger) *handler.AppVersionHandler {
 				return handler.NewAppVersionHandler(db, logger)
@@ -34,6 +35,12 @@ func HandlerModule() fx.Option {
 			func(authService *service.AuthService, cfg *config.Config, logger *zap.Logger) *handler.AuthHandler {
 				return handler.NewAuthHandler(authService, cfg, logger)
 			},
+			func(exportService *service.DataExportService, logger *zap.Logger) *handler.DataExportHandler {
+				return handler.NewDataExportHandler(exportService, logger)
+			},
+			func(keys *auth.KeySet) *handler.JWKSHandler {
+				return handler.NewJWKSHandler(keys)
+			},
 		),
 	)
 }
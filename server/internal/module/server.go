@@ -14,7 +14,9 @@ import (
 	"github.com/ggorockee/ojeomneo/server/internal/config"
 	"github.com/ggorockee/ojeomneo/server/internal/handler"
 	"github.com/ggorockee/ojeomneo/server/internal/middleware"
+	"github.com/ggorockee/ojeomneo/server/internal/service"
 	"github.com/ggorockee/ojeomneo/server/internal/telemetry"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
@@ -34,6 +36,10 @@ type ServerParams struct {
 	ImageHandler    *handler.ImageHandler
 	AuthHandler     *handler.AuthHandler
 	RedisConfig     RedisConfig
+	TokenRevoker    *service.TokenRevoker
+	JWTKeys         *auth.KeySet
+	JWKSHandler     *handler.JWKSHandler
+	DataExportHandler *handler.DataExportHandler
 }
 
 // ServerModule 서버 모듈
@@ -50,6 +56,9 @@ func ServerModule() fx.Option {
 					ServerHeader: "Ojeomneo",
 					ErrorHandler: handler.CustomErrorHandler,
 					BodyLimit:    10 * 1024 * 1024, // 10MB
+					// 신뢰 프록시에서 온 요청만 X-Forwarded-For를 반영 (middleware.ClientIP)
+					EnableTrustedProxyCheck: true,
+					TrustedProxies:          params.Config.TrustedProxies,
 				})
 
 				// 전역 미들웨어 설정
@@ -98,7 +107,7 @@ func ServerModule() fx.Option {
 				app.Use(cors.New(cors.Config{
 					AllowOrigins: "*",
 					AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS",
-					AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+					AllowHeaders: "Origin,Content-Type,Accept,Authorization,X-Device-ID,X-Platform,X-App-Version",
 				}))
 
 				// /ojeomneo 그룹
@@ -107,6 +116,10 @@ func ServerModule() fx.Option {
 				// Prometheus metrics 엔드포인트 (SigNoz scrape용)
 				ojeomneo.Get("/metrics", middleware.PrometheusHandler())
 
+				// JWKS (토큰 검증용 공개키) - 루트와 /ojeomneo 양쪽에 공개
+				app.Get("/.well-known/jwks.json", params.JWKSHandler.GetJWKS)
+				ojeomneo.Get("/.well-known/jwks.json", params.JWKSHandler.GetJWKS)
+
 				// API v1 라우터
 				v1 := ojeomneo.Group("/v1")
 
@@ -142,25 +155,64 @@ func ServerModule() fx.Option {
 				v1.Get("/healthcheck/live", params.HealthHandler.LivenessCheck)
 				v1.Get("/healthcheck/ready", params.HealthHandler.ReadinessCheck)
 
+				// 인증 미들웨어 (로그아웃/탈퇴로 폐기된 토큰 거부)
+				requireAuth := middleware.RequireAuth(params.JWTKeys, params.TokenRevoker)
+				optionalAuth := middleware.OptionalAuth(params.JWTKeys, params.TokenRevoker)
+				guestAllowedAuth := middleware.GuestAllowedAuth(params.JWTKeys, params.TokenRevoker)
+
 				// Auth 엔드포인트
 				// 이메일 인증
 				v1.Post("/auth/email/send-code", params.AuthHandler.SendEmailCode)
 				v1.Post("/auth/email/verify-code", params.AuthHandler.VerifyEmailCode)
+				// 비밀번호 없는 로그인 (로그인 링크)
+				v1.Post("/auth/email/magic-link", params.AuthHandler.RequestMagicLink)
+				v1.Post("/auth/email/magic-link/verify", params.AuthHandler.MagicLinkLogin)
 				// 회원가입/로그인
 				v1.Post("/auth/signup", params.AuthHandler.Signup)
 				v1.Post("/auth/login", params.AuthHandler.Login)
 				v1.Post("/auth/refresh", params.AuthHandler.RefreshToken)
+				// 로그아웃
+				v1.Post("/auth/logout", guestAllowedAuth, params.AuthHandler.Logout)
+				v1.Post("/auth/logout-all", requireAuth, params.AuthHandler.LogoutAll)
+				// 로그인된 기기(세션) 관리 (정회원 전용)
+				v1.Get("/auth/sessions", requireAuth, params.AuthHandler.ListSessions)
+				v1.Delete("/auth/sessions/:id", requireAuth, params.AuthHandler.RevokeSession)
+				// 2단계 인증 (TOTP)
+				v1.Post("/auth/mfa/verify", params.AuthHandler.VerifyMFA)
+				v1.Post("/auth/mfa/totp/setup", requireAuth, params.AuthHandler.SetupTOTP)
+				v1.Post("/auth/mfa/totp/enable", requireAuth, params.AuthHandler.EnableTOTP)
+				v1.Post("/auth/mfa/totp/disable", requireAuth, params.AuthHandler.DisableTOTP)
+				// Passkey (WebAuthn)
+				v1.Post("/auth/passkey/register/options", requireAuth, params.AuthHandler.BeginPasskeyRegistration)
+				v1.Post("/auth/passkey/register", requireAuth, params.AuthHandler.FinishPasskeyRegistration)
+				v1.Post("/auth/passkey/login/options", params.AuthHandler.BeginPasskeyLogin)
+				v1.Post("/auth/passkey/login", params.AuthHandler.FinishPasskeyLogin)
+				v1.Get("/auth/passkeys", requireAuth, params.AuthHandler.ListPasskeys)
+				v1.Delete("/auth/passkeys/:id", requireAuth, params.AuthHandler.DeletePasskey)
 				// 비밀번호 재설정
 				v1.Post("/auth/password/reset-request", params.AuthHandler.PasswordResetRequest)
 				v1.Post("/auth/password/reset-verify", params.AuthHandler.PasswordResetVerify)
 				v1.Post("/auth/password/reset-confirm", params.AuthHandler.PasswordResetConfirm)
-				// 사용자 관리
-				v1.Get("/auth/me", params.AuthHandler.GetMe)
-				v1.Delete("/auth/me", params.AuthHandler.DeleteMe)
+				// 사용자 관리 (익명 사용자도 조회 가능, 탈퇴 가능 여부는 핸들러에서 판단)
+				v1.Get("/auth/me", guestAllowedAuth, params.AuthHandler.GetMe)
+				v1.Delete("/auth/me", guestAllowedAuth, params.AuthHandler.DeleteMe)
+				// 개인 데이터 내보내기 (다운로드는 링크의 토큰으로 인증)
+				v1.Post("/auth/me/export", requireAuth, params.DataExportHandler.RequestExport)
+				v1.Get("/auth/me/export/:id", requireAuth, params.DataExportHandler.GetExport)
+				v1.Get("/auth/me/export/:id/download", params.DataExportHandler.Download)
+				// 로그인 수단 연결 (정회원 전용)
+				v1.Get("/auth/identities", requireAuth, params.AuthHandler.ListIdentities)
+				v1.Post("/auth/identities/:provider", requireAuth, params.AuthHandler.LinkIdentity)
+				v1.Delete("/auth/identities/:provider", requireAuth, params.AuthHandler.UnlinkIdentity)
 				// SNS 로그인
 				v1.Post("/auth/google", params.AuthHandler.GoogleLogin)
 				v1.Post("/auth/apple", params.AuthHandler.AppleLogin)
+				v1.Post("/auth/apple/notifications", params.AuthHandler.AppleNotification)
 				v1.Post("/auth/kakao", params.AuthHandler.KakaoLogin)
+				v1.Get("/auth/kakao/unlink", params.AuthHandler.KakaoUnlinkCallback)
+				v1.Post("/auth/kakao/unlink", params.AuthHandler.KakaoUnlinkCallback)
+				v1.Post("/auth/naver", params.AuthHandler.NaverLogin)
+				v1.Post("/auth/sns/:provider", params.AuthHandler.SNSLogin)
 				// 익명 로그인
 				v1.Post("/auth/guest", params.AuthHandler.GuestLogin)
 
@@ -169,18 +221,23 @@ func ServerModule() fx.Option {
 				v1.Get("/menus/categories", params.MenuHandler.GetCategories)
 				v1.Get("/menus/:id", params.MenuHandler.GetByID)
 
-				// Sketch 엔드포인트
-				v1.Post("/sketch/analyze", params.SketchHandler.Analyze)
-				v1.Get("/sketch/history", params.SketchHandler.GetHistory)
-				v1.Get("/sketch/:id", params.SketchHandler.GetByID)
+				// Sketch 엔드포인트 (토큰이 있으면 사용자 식별, 없으면 device_id 기반)
+				sketch := v1.Group("/sketch", optionalAuth)
+				sketch.Post("/analyze", params.SketchHandler.Analyze)
+				sketch.Post("/analyze/stream", params.SketchHandler.AnalyzeStream)
+				sketch.Get("/history", params.SketchHandler.GetHistory)
+				sketch.Get("/jobs/:id", params.SketchHandler.GetJob)
+				sketch.Get("/:id", params.SketchHandler.GetByID)
 
 				// App 엔드포인트
 				v1.Get("/app/version", params.AppVersionHandler.CheckVersion)
 
-				// Image 엔드포인트
-				v1.Post("/images/upload", params.ImageHandler.Upload)
-				v1.Post("/images/upload-url", params.ImageHandler.UploadFromURL)
-				v1.Delete("/images/:id", params.ImageHandler.Delete)
+				// Image 엔드포인트 (정회원 또는 관리자 공유 키)
+				// Django admin의 메뉴 이미지 업로드는 사용자 토큰 없이 X-Admin-Key로 호출한다.
+				images := v1.Group("/images", middleware.AdminKeyOrAuth(params.Config.AdminAPIKey, requireAuth))
+				images.Post("/upload", params.ImageHandler.Upload)
+				images.Post("/upload-url", params.ImageHandler.UploadFromURL)
+				images.Delete("/:id", params.ImageHandler.Delete)
 
 				return app, nil
 			},
@@ -1,11 +1,17 @@
 package module
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/ggorockee/ojeomneo/server/internal/config"
 	"github.com/ggorockee/ojeomneo/server/internal/service"
 	"github.com/ggorockee/ojeomneo/server/internal/service/cloudflare"
 	"github.com/ggorockee/ojeomneo/server/internal/service/llm"
 	"github.com/ggorockee/ojeomneo/server/internal/telemetry"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -16,18 +22,35 @@ import (
 // ServiceModule 서비스 모듈
 func ServiceModule() fx.Option {
 	return fx.Options(
-		// LLM 클라이언트
+		// LLM 제공자 (LLM_PROVIDER로 선택)
+		fx.Provide(newLLMProvider),
+		// JWT 서명/검증 키
 		fx.Provide(
-			func(cfg *config.Config, logger *zap.Logger) *llm.Client {
-				client := llm.NewClient(cfg.GeminiAPIKey, cfg.GeminiModel)
-				if client.IsAvailable() {
-					logger.Info("Gemini client initialized",
-						zap.String("model", cfg.GeminiModel),
+			func(cfg *config.Config, logger *zap.Logger) (*auth.KeySet, error) {
+				configs, err := auth.ParseKeyConfigs(cfg.JWTKeys)
+				if err != nil {
+					return nil, err
+				}
+
+				keys, err := auth.NewKeySet(cfg.JWTSecretKey, cfg.JWTAcceptHS256, cfg.JWTSigningKeyID, configs)
+				if err != nil {
+					return nil, err
+				}
+
+				if keys.SigningKeyID() != "" {
+					// 비대칭 서명 사용 시 JWT secret은 (전환 기간의) HS256 검증에만 쓰고 다른 키를 파생하지 않음
+					if cfg.MFAEncryptionKey == "" || cfg.EmailCodeSecret == "" {
+						return nil, fmt.Errorf("MFA_ENCRYPTION_KEY and EMAIL_CODE_SECRET are required when JWT asymmetric signing is enabled")
+					}
+					logger.Info("JWT asymmetric signing enabled",
+						zap.String("kid", keys.SigningKeyID()),
+						zap.Int("verification_keys", len(configs)),
+						zap.Bool("legacy_hs256_accepted", keys.AcceptsHS256()),
 					)
 				} else {
-					logger.Warn("Gemini API key not configured, using mock responses")
+					logger.Warn("JWT signing with shared secret (HS256), configure JWT_KEYS for asymmetric signing")
 				}
-				return client
+				return keys, nil
 			},
 		),
 		// Cloudflare Images 클라이언트
@@ -51,11 +74,138 @@ func ServiceModule() fx.Option {
 			func(db *gorm.DB, logger *zap.Logger) *service.MenuService {
 				return service.NewMenuService(db, logger)
 			},
-			func(db *gorm.DB, llmClient *llm.Client, menuService *service.MenuService, logger *zap.Logger) *service.SketchService {
+			func(db *gorm.DB, llmClient llm.Provider, menuService *service.MenuService, logger *zap.Logger) *service.SketchService {
 				return service.NewSketchService(db, llmClient, menuService, logger)
 			},
-			func(db *gorm.DB, cfg *config.Config, logger *zap.Logger, metrics *telemetry.AuthMetrics) *service.AuthService {
-				return service.NewAuthService(db, cfg, logger, metrics)
+			func(sketchService *service.SketchService, rdb *redis.Client, cfg *config.Config, logger *zap.Logger) *service.SketchJobQueue {
+				return service.NewSketchJobQueue(sketchService, rdb, cfg, logger)
+			},
+			func(db *gorm.DB, rdb *redis.Client, logger *zap.Logger) *service.TokenRevoker {
+				return service.NewTokenRevoker(db, rdb, logger)
+			},
+			func(db *gorm.DB, rdb *redis.Client, cfg *config.Config, logger *zap.Logger, metrics *telemetry.AuthMetrics) *service.LoginThrottle {
+				return service.NewLoginThrottle(db, rdb, cfg, logger, metrics)
+			},
+			func(db *gorm.DB, rdb *redis.Client, logger *zap.Logger) *service.ChallengeStore {
+				return service.NewChallengeStore(db, rdb, logger)
+			},
+			func(db *gorm.DB, cfg *config.Config, logger *zap.Logger, metrics *telemetry.AuthMetrics, revoker *service.TokenRevoker, keys *auth.KeySet, throttle *service.LoginThrottle, challenges *service.ChallengeStore) (*service.AuthService, error) {
+				return service.NewAuthService(db, cfg, logger, metrics, revoker, keys, throttle, challenges)
+			},
+			func(db *gorm.DB, sketchService *service.SketchService, cfg *config.Config, logger *zap.Logger) *service.DataExportService {
+				return service.NewDataExportService(db, sketchService, cfg, logger)
+			},
+			func(db *gorm.DB, sketchService *service.SketchService, exportService *service.DataExportService, throttle *service.LoginThrottle, logger *zap.Logger) *service.AccountPurger {
+				return service.NewAccountPurger(db, sketchService, exportService, throttle, logger)
+			},
+			func(db *gorm.DB, cfg *config.Config, logger *zap.Logger) *service.EmailVerificationCleaner {
+				return service.NewEmailVerificationCleaner(db, cfg, logger)
+			},
+			func(db *gorm.DB, logger *zap.Logger) *service.TokenCleaner {
+				return service.NewTokenCleaner(db, logger)
+			},
+		),
+		// 비동기 스케치 분석 작업자 (종료 시 처리 중인 작업 완료 대기)
+		fx.Invoke(
+			func(lc fx.Lifecycle, queue *service.SketchJobQueue) {
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						queue.Start()
+						return nil
+					},
+					OnStop: func(ctx context.Context) error {
+						return queue.Stop(ctx)
+					},
+				})
+			},
+		),
+		// 탈퇴 계정 정리 작업 (유예 기간이 지난 계정 완전 삭제)
+		fx.Invoke(
+			func(lc fx.Lifecycle, cfg *config.Config, purger *service.AccountPurger, logger *zap.Logger) {
+				if cfg.AccountPurgeIntervalMin <= 0 {
+					logger.Warn("Account purge job disabled")
+					return
+				}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						go purger.Run(ctx, time.Duration(cfg.AccountPurgeIntervalMin)*time.Minute)
+						logger.Info("Account purge job started",
+							zap.Int("interval_minutes", cfg.AccountPurgeIntervalMin),
+							zap.Int("grace_days", cfg.AccountDeletionGraceDays),
+						)
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						cancel()
+						return nil
+					},
+				})
+			},
+			// 만료된 개인 데이터 내보내기 파일 정리 (1시간 주기)
+			func(lc fx.Lifecycle, exportService *service.DataExportService) {
+				ctx, cancel := context.WithCancel(context.Background())
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						go exportService.Run(ctx, time.Hour)
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						cancel()
+						return nil
+					},
+				})
+			},
+			// 만료된 로그인 실패 기록 정리 (Redis 미사용 시 쌓이는 login_attempts, 1시간 주기)
+			func(lc fx.Lifecycle, throttle *service.LoginThrottle) {
+				ctx, cancel := context.WithCancel(context.Background())
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						go throttle.Run(ctx, time.Hour)
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						cancel()
+						return nil
+					},
+				})
+			},
+			// 만료된 Refresh Token, 세션 기록 정리 (1시간 주기)
+			func(lc fx.Lifecycle, cleaner *service.TokenCleaner) {
+				ctx, cancel := context.WithCancel(context.Background())
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						go cleaner.Run(ctx, time.Hour)
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						cancel()
+						return nil
+					},
+				})
+			},
+			// 만료/사용된 이메일 인증코드, 비밀번호 재설정 기록 정리
+			func(lc fx.Lifecycle, cfg *config.Config, cleaner *service.EmailVerificationCleaner, logger *zap.Logger) {
+				if cfg.EmailCleanupIntervalMin <= 0 {
+					logger.Warn("Email verification cleanup job disabled")
+					return
+				}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						go cleaner.Run(ctx, time.Duration(cfg.EmailCleanupIntervalMin)*time.Minute)
+						logger.Info("Email verification cleanup job started",
+							zap.Int("interval_minutes", cfg.EmailCleanupIntervalMin),
+						)
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						cancel()
+						return nil
+					},
+				})
 			},
 		),
 	)
@@ -75,3 +225,94 @@ func RedisServiceModule() fx.Option {
 	)
 }
 
+// newLLMProvider 설정에 따른 LLM 제공자 생성
+// LLM_PROVIDER가 비어 있으면 GEMINI_API_KEY 유무로 gemini/mock을 고른다 (기존 동작 유지).
+// 주 제공자 뒤에 LLM_FALLBACKS 단계를 붙여 재시도/서킷 브레이커가 적용된 Chain으로 감싼다.
+func newLLMProvider(cfg *config.Config, logger *zap.Logger) (llm.Provider, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.LLMProvider))
+	if name == "" {
+		name = "gemini"
+		if cfg.GeminiAPIKey == "" {
+			logger.Warn("Gemini API key not configured, using mock responses")
+			name = "mock"
+		}
+	}
+
+	primary, err := newLLMTier(cfg, name, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid LLM_PROVIDER: %w", err)
+	}
+	tiers := []llm.Tier{primary}
+	seen := map[string]bool{primary.Name: true}
+
+	if fallbacks := strings.TrimSpace(cfg.LLMFallbacks); !strings.EqualFold(fallbacks, "none") {
+		for _, entry := range strings.Split(fallbacks, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			provider, model, _ := strings.Cut(entry, ":")
+			tier, err := newLLMTier(cfg, strings.ToLower(provider), model)
+			if err != nil {
+				return nil, fmt.Errorf("invalid LLM_FALLBACKS entry %q: %w", entry, err)
+			}
+			if seen[tier.Name] {
+				continue
+			}
+			seen[tier.Name] = true
+			tiers = append(tiers, tier)
+		}
+	}
+
+	names := make([]string, 0, len(tiers))
+	for _, tier := range tiers {
+		names = append(names, tier.Name)
+	}
+	logger.Info("LLM provider chain initialized",
+		zap.Strings("tiers", names),
+		zap.Int("max_retries", cfg.LLMMaxRetries),
+		zap.Int("breaker_failures", cfg.LLMBreakerFailures),
+	)
+
+	return llm.NewChain(llm.ChainConfig{
+		MaxRetries:       cfg.LLMMaxRetries,
+		RetryBaseDelay:   time.Duration(cfg.LLMRetryBaseDelayMs) * time.Millisecond,
+		RetryMaxDelay:    time.Duration(cfg.LLMRetryMaxDelayMs) * time.Millisecond,
+		BreakerThreshold: cfg.LLMBreakerFailures,
+		BreakerCooldown:  time.Duration(cfg.LLMBreakerCooldownSeconds) * time.Second,
+	}, logger, tiers...), nil
+}
+
+// newLLMTier 제공자 이름과 모델로 대체 순서의 한 단계 생성 (model이 비어 있으면 설정의 기본 모델)
+func newLLMTier(cfg *config.Config, name, model string) (llm.Tier, error) {
+	model = strings.TrimSpace(model)
+
+	switch name {
+	case "gemini":
+		if cfg.GeminiAPIKey == "" {
+			return llm.Tier{}, fmt.Errorf("gemini requires GEMINI_API_KEY")
+		}
+		if model == "" {
+			model = cfg.GeminiModel
+		}
+		return llm.Tier{
+			Name:     "gemini:" + model,
+			Provider: llm.NewGeminiClient(cfg.GeminiAPIKey, model),
+		}, nil
+	case "openai":
+		if model == "" {
+			model = cfg.OpenAIModel
+		}
+		if cfg.OpenAIBaseURL == "" || model == "" {
+			return llm.Tier{}, fmt.Errorf("openai requires OPENAI_BASE_URL and OPENAI_MODEL")
+		}
+		return llm.Tier{
+			Name:     "openai:" + model,
+			Provider: llm.NewOpenAIClient(cfg.OpenAIBaseURL, cfg.OpenAIAPIKey, model),
+		}, nil
+	case "mock":
+		return llm.Tier{Name: "mock", Provider: llm.NewMockProvider()}, nil
+	default:
+		return llm.Tier{}, fmt.Errorf("unknown LLM provider %q (gemini, openai, mock)", name)
+	}
+}
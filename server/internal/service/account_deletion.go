@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// ErrAccountPendingDeletion 탈퇴 유예 기간 중인 계정 (로그인하면 복구 가능)
+var ErrAccountPendingDeletion = errors.New("탈퇴 처리 중인 계정입니다. 로그인하면 계정을 복구할 수 있습니다")
+
+// requestAccountDeletion 탈퇴 요청 기록 후 사용자 soft delete 및 발급된 토큰 폐기
+// 로그인 수단과 passkey는 유예 기간 동안 복구용으로 유지하고 완전 삭제 시 정리
+func (s *AuthService) requestAccountDeletion(user *model.User, reason string) (*model.AccountDeletion, error) {
+	now := time.Now()
+	deletion := &model.AccountDeletion{
+		UserID:      user.ID,
+		Reason:      truncate(reason, 1000),
+		LoginMethod: user.LoginMethod,
+		RequestedAt: now,
+		PurgeAfter:  now.AddDate(0, 0, s.cfg.AccountDeletionGraceDays),
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// identity 미생성 기존 사용자도 같은 로그인 수단으로 복구할 수 있도록 연결 기록 생성
+		if err := s.ensurePrimaryIdentity(tx, user); err != nil {
+			return err
+		}
+		if err := tx.Create(deletion).Error; err != nil {
+			return fmt.Errorf("failed to create deletion request: %w", err)
+		}
+		// Soft Delete (GORM의 DeletedAt 사용)
+		if err := tx.Delete(user).Error; err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to delete user",
+			zap.Error(err),
+			zap.Uint("user_id", user.ID),
+		)
+		return nil, fmt.Errorf("회원 탈퇴 처리에 실패했습니다: %w", err)
+	}
+
+	// 발급된 모든 토큰 즉시 폐기
+	if err := s.revoker.RevokeAllForUser(user.ID); err != nil {
+		s.logger.Error("Failed to revoke tokens of deleted user",
+			zap.Error(err),
+			zap.Uint("user_id", user.ID),
+		)
+	}
+
+	return deletion, nil
+}
+
+// pendingDeletion 유예 기간 중인 탈퇴 요청 조회 (없거나 기한이 지났으면 nil)
+func (s *AuthService) pendingDeletion(tx *gorm.DB, userID uint) (*model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	if err := tx.Where("user_id = ?", userID).First(&deletion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find deletion request: %w", err)
+	}
+	if !deletion.IsRestorable() {
+		return nil, nil
+	}
+	return &deletion, nil
+}
+
+// findDeletedUserByIdentity 로그인 수단으로 유예 기간 중인 탈퇴 계정 조회 (없으면 nil)
+func (s *AuthService) findDeletedUserByIdentity(tx *gorm.DB, provider model.LoginMethod, subject string) (*model.User, error) {
+	var identity model.UserIdentity
+	if err := tx.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+	return s.findDeletedUser(tx, identity.UserID)
+}
+
+// findDeletedUser 유예 기간 중인 탈퇴 계정 조회 (없으면 nil)
+func (s *AuthService) findDeletedUser(tx *gorm.DB, userID uint) (*model.User, error) {
+	deletion, err := s.pendingDeletion(tx, userID)
+	if err != nil || deletion == nil {
+		return nil, err
+	}
+
+	var user model.User
+	if err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find deleted user: %w", err)
+	}
+	return &user, nil
+}
+
+// restoreAccount 유예 기간 중인 탈퇴 계정 복구 (탈퇴 요청 기록 삭제)
+func (s *AuthService) restoreAccount(tx *gorm.DB, user *model.User) error {
+	if err := tx.Unscoped().Model(user).Update("deleted_at", nil).Error; err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+	if err := tx.Where("user_id = ?", user.ID).Delete(&model.AccountDeletion{}).Error; err != nil {
+		return fmt.Errorf("failed to delete deletion request: %w", err)
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+
+	s.logger.Info("Deleted account restored by login",
+		zap.Uint("user_id", user.ID),
+	)
+	return nil
+}
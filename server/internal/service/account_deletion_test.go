@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+func TestAuthService_DeleteMe_GracePeriod(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "grace@example.com")
+	hashed, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	require.NoError(t, db.Model(user).Update("password", hashed).Error)
+
+	reason := "  자주 사용하지 않아요  "
+	deletion, err := svc.DeleteMe(user.ID, &reason)
+	require.NoError(t, err)
+
+	t.Run("탈퇴 요청 기록 및 soft delete", func(t *testing.T) {
+		assert.Equal(t, "자주 사용하지 않아요", deletion.Reason)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), deletion.PurgeAfter, time.Minute)
+
+		var count int64
+		db.Model(&model.User{}).Where("id = ?", user.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&model.UserIdentity{}).Where("user_id = ?", user.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("유예 기간 중 같은 이메일로 가입 불가", func(t *testing.T) {
+		token := "grace-token"
+		createVerifiedEmail(t, svc, db, "grace@example.com", token, model.EmailPurposeSignup)
+
+		_, err := svc.Signup(&SignupRequest{Email: "grace@example.com", Password: "password123", VerificationToken: &token})
+		assert.ErrorIs(t, err, ErrAccountPendingDeletion)
+	})
+
+	t.Run("유예 기간 중 로그인하면 계정 복구", func(t *testing.T) {
+		response, err := svc.EmailLogin(&LoginRequest{Email: "grace@example.com", Password: "password123"})
+		require.NoError(t, err)
+		assert.True(t, response.AccountRestored)
+		assert.Equal(t, user.ID, response.User.ID)
+
+		var count int64
+		db.Model(&model.User{}).Where("id = ?", user.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+		db.Model(&model.AccountDeletion{}).Where("user_id = ?", user.ID).Count(&count)
+		assert.Zero(t, count)
+	})
+}
+
+func TestAuthService_DeleteMe_RestoreRequiresMFA(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "grace-mfa@example.com")
+	hashed, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	require.NoError(t, db.Model(user).Update("password", hashed).Error)
+
+	setup, err := svc.SetupTOTP(user.ID)
+	require.NoError(t, err)
+	code, err := auth.TOTPCode(setup.Secret, time.Now())
+	require.NoError(t, err)
+	_, err = svc.EnableTOTP(user.ID, code)
+	require.NoError(t, err)
+
+	_, err = svc.DeleteMe(user.ID, nil)
+	require.NoError(t, err)
+
+	assertDeleted := func(t *testing.T) {
+		var count int64
+		db.Model(&model.User{}).Where("id = ?", user.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&model.AccountDeletion{}).Where("user_id = ?", user.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	}
+
+	login := func(t *testing.T) string {
+		response, err := svc.EmailLogin(&LoginRequest{Email: "grace-mfa@example.com", Password: "password123"})
+		require.NoError(t, err)
+		require.True(t, response.MFARequired)
+		assert.False(t, response.AccountRestored)
+		return response.MFAToken
+	}
+
+	t.Run("비밀번호만으로는 복구되지 않음", func(t *testing.T) {
+		login(t)
+		assertDeleted(t)
+	})
+
+	t.Run("로그인 링크만으로는 복구되지 않음", func(t *testing.T) {
+		linkCode, err := svc.issueEmailCode("grace-mfa@example.com", "10.0.0.1", model.EmailPurposeMagicLink, magicLinkTTL)
+		require.NoError(t, err)
+
+		response, err := svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "grace-mfa@example.com", Code: linkCode})
+		require.NoError(t, err)
+		assert.True(t, response.MFARequired)
+		assert.False(t, response.AccountRestored)
+		assertDeleted(t)
+	})
+
+	t.Run("잘못된 코드로는 복구되지 않음", func(t *testing.T) {
+		_, err := svc.VerifyMFA(login(t), "000000", ClientInfo{})
+		assert.ErrorIs(t, err, ErrMFAInvalidCode)
+		assertDeleted(t)
+	})
+
+	t.Run("2단계 인증 완료 후 복구", func(t *testing.T) {
+		next, err := auth.TOTPCode(setup.Secret, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+
+		response, err := svc.VerifyMFA(login(t), next, ClientInfo{})
+		require.NoError(t, err)
+		assert.True(t, response.AccountRestored)
+		assert.NotEmpty(t, response.AccessToken)
+
+		var count int64
+		db.Model(&model.User{}).Where("id = ?", user.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+		db.Model(&model.AccountDeletion{}).Where("user_id = ?", user.ID).Count(&count)
+		assert.Zero(t, count)
+	})
+}
+
+func TestAuthService_SNSLogin_RestoresDeletedAccount(t *testing.T) {
+	svc, _ := setupSNSAuthService(t)
+
+	response, err := svc.SNSLogin(model.LoginMethodNaver, "naver-token", ClientInfo{})
+	require.NoError(t, err)
+	_, err = svc.DeleteMe(response.User.ID, nil)
+	require.NoError(t, err)
+
+	restored, err := svc.SNSLogin(model.LoginMethodNaver, "naver-token", ClientInfo{})
+	require.NoError(t, err)
+	assert.True(t, restored.AccountRestored)
+	assert.Equal(t, response.User.ID, restored.User.ID)
+}
+
+func TestAccountPurger_PurgeExpired(t *testing.T) {
+	svc, db := setupAuthService(t)
+	createSketchTables(t, db)
+
+	uploadPath := t.TempDir()
+	sketches := &SketchService{db: db, uploadPath: uploadPath, logger: setupTestLogger()}
+	exports := &DataExportService{db: db, sketches: sketches, exportPath: t.TempDir(), linkTTL: time.Hour, logger: setupTestLogger()}
+	purger := NewAccountPurger(db, sketches, exports, svc.throttle, setupTestLogger())
+
+	expired := createTestUser(t, db, "expired@example.com")
+	pending := createTestUser(t, db, "pending@example.com")
+
+	sketch := model.Sketch{ID: uuid.New(), DeviceID: "device-purge", UserID: &expired.ID, ImagePath: "purge.png"}
+	require.NoError(t, db.Create(&sketch).Error)
+	require.NoError(t, db.Create(&model.Recommendation{SketchID: sketch.ID, MenuID: 1, Reason: "r", Rank: 1}).Error)
+	require.NoError(t, os.WriteFile(filepath.Join(uploadPath, "purge.png"), []byte("png"), 0o644))
+
+	for _, user := range []*model.User{expired, pending} {
+		_, err := svc.DeleteMe(user.ID, nil)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Model(&model.AccountDeletion{}).
+		Where("user_id = ?", expired.ID).
+		Update("purge_after", time.Now().Add(-time.Hour)).Error)
+
+	purged, err := purger.PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	t.Run("기한이 지난 계정과 스케치, 이미지 완전 삭제", func(t *testing.T) {
+		var count int64
+		db.Unscoped().Model(&model.User{}).Where("id = ?", expired.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&model.UserIdentity{}).Where("user_id = ?", expired.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Unscoped().Model(&model.Sketch{}).Where("user_id = ?", expired.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&model.Recommendation{}).Where("sketch_id = ?", sketch.ID).Count(&count)
+		assert.Zero(t, count)
+
+		_, err := os.Stat(filepath.Join(uploadPath, "purge.png"))
+		assert.True(t, os.IsNotExist(err))
+
+		var deletion model.AccountDeletion
+		require.NoError(t, db.Where("user_id = ?", expired.ID).First(&deletion).Error)
+		assert.NotNil(t, deletion.PurgedAt)
+	})
+
+	t.Run("유예 기간 중인 계정은 유지", func(t *testing.T) {
+		var count int64
+		db.Unscoped().Model(&model.User{}).Where("id = ?", pending.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("이미 정리한 계정은 다시 처리하지 않음", func(t *testing.T) {
+		purged, err := purger.PurgeExpired(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+	})
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// accountPurgeBatchSize 한 번에 정리하는 탈퇴 계정 수
+const accountPurgeBatchSize = 100
+
+// AccountPurger 유예 기간이 지난 탈퇴 계정 완전 삭제 작업
+// 여러 인스턴스에서 동시에 실행되어도 같은 결과가 되도록 삭제는 모두 멱등으로 처리
+type AccountPurger struct {
+	db       *gorm.DB
+	sketches *SketchService
+	exports  *DataExportService
+	throttle *LoginThrottle
+	logger   *zap.Logger
+}
+
+// NewAccountPurger 새 탈퇴 계정 정리 작업 생성
+func NewAccountPurger(db *gorm.DB, sketches *SketchService, exports *DataExportService, throttle *LoginThrottle, logger *zap.Logger) *AccountPurger {
+	return &AccountPurger{
+		db:       db,
+		sketches: sketches,
+		exports:  exports,
+		throttle: throttle,
+		logger:   logger,
+	}
+}
+
+// Run 주기적으로 탈퇴 계정 정리 (ctx 취소 시 종료)
+func (p *AccountPurger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.PurgeExpired(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			p.logger.Error("Account purge failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired 유예 기간이 지난 탈퇴 계정 완전 삭제, 삭제한 계정 수 반환
+func (p *AccountPurger) PurgeExpired(ctx context.Context) (int, error) {
+	var deletions []model.AccountDeletion
+	if err := p.db.WithContext(ctx).
+		Where("purged_at IS NULL AND purge_after <= ?", time.Now()).
+		Order("purge_after ASC").
+		Limit(accountPurgeBatchSize).
+		Find(&deletions).Error; err != nil {
+		return 0, fmt.Errorf("failed to list expired deletions: %w", err)
+	}
+
+	purged := 0
+	for i := range deletions {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		if err := p.purgeAccount(ctx, &deletions[i]); err != nil {
+			p.logger.Error("Failed to purge account",
+				zap.Error(err),
+				zap.Uint("user_id", deletions[i].UserID),
+			)
+			continue
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		p.logger.Info("Expired accounts purged",
+			zap.Int("count", purged),
+		)
+	}
+	return purged, nil
+}
+
+// purgeAccount 탈퇴 계정 완전 삭제
+// 스케치/이미지, 로그인 수단, passkey, 세션, 토큰 기록, 인증 코드, 로그인 실패 기록을 삭제하고 사용자 행을 hard delete
+// 탈퇴 요청 기록은 사유, 로그인 방식, 일시만 남겨 개인 식별 정보 없는 통계로 보관
+func (p *AccountPurger) purgeAccount(ctx context.Context, deletion *model.AccountDeletion) error {
+	userID := deletion.UserID
+
+	var user model.User
+	if err := p.db.WithContext(ctx).Unscoped().First(&user, userID).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	// 유예 기간 중 복구된 계정은 건너뜀 (복구 시 기록이 삭제되지만 동시 실행 대비)
+	if user.ID != 0 && !user.DeletedAt.Valid {
+		return nil
+	}
+
+	sketchCount, err := p.sketches.PurgeUserSketches(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := p.exports.PurgeUserExports(ctx, userID); err != nil {
+		return err
+	}
+
+	err = p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, m := range []interface{}{
+			&model.UserIdentity{},
+			&model.PasskeyCredential{},
+			&model.Session{},
+			&model.RefreshToken{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(m).Error; err != nil {
+				return fmt.Errorf("failed to delete %T: %w", m, err)
+			}
+		}
+
+		if user.Email != "" {
+			if err := tx.Where("email = ?", user.Email).Delete(&model.EmailVerification{}).Error; err != nil {
+				return fmt.Errorf("failed to delete email verifications: %w", err)
+			}
+			if err := tx.Where("email = ?", user.Email).Delete(&model.EmailSendLog{}).Error; err != nil {
+				return fmt.Errorf("failed to delete email send logs: %w", err)
+			}
+		}
+
+		if err := tx.Unscoped().Where("id = ?", userID).Delete(&model.User{}).Error; err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		now := time.Now()
+		return tx.Model(deletion).Update("purged_at", &now).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// 로그인 실패 기록 삭제 (Redis 또는 DB)
+	if user.Email != "" {
+		if err := p.throttle.Forget(user.Email); err != nil {
+			p.logger.Warn("Failed to delete login attempts of purged user",
+				zap.Error(err),
+				zap.Uint("user_id", userID),
+			)
+		}
+	}
+
+	p.logger.Info("Account purged",
+		zap.Uint("user_id", userID),
+		zap.Int("sketches", sketchCount),
+	)
+	return nil
+}
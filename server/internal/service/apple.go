@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/sns"
+)
+
+var (
+	// ErrAppleRevocationDisabled Apple 토큰 폐기 설정(팀 ID, 키 ID, 개인키, 암호화 키) 누락
+	ErrAppleRevocationDisabled = errors.New("Apple 토큰 폐기가 설정되어 있지 않습니다")
+	// ErrAppleNotificationInvalid 서명 검증에 실패한 Apple 서버 알림
+	ErrAppleNotificationInvalid = errors.New("Apple 알림 검증에 실패했습니다")
+)
+
+// appleClientSecret Apple REST API 호출용 client_secret 생성 (APPLE_TEAM_ID, APPLE_KEY_ID, APPLE_PRIVATE_KEY로 서명)
+func (s *AuthService) appleClientSecret() (string, error) {
+	if s.cfg.AppleClientID == "" || s.cfg.AppleTeamID == "" || s.cfg.AppleKeyID == "" || s.cfg.ApplePrivateKey == "" {
+		return "", ErrAppleRevocationDisabled
+	}
+
+	key, err := sns.ParseApplePrivateKey(s.cfg.ApplePrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrAppleRevocationDisabled, err)
+	}
+	return sns.GenerateAppleClientSecret(s.cfg.AppleTeamID, s.cfg.AppleKeyID, s.cfg.AppleClientID, key, time.Now())
+}
+
+// StoreAppleAuthorization Apple 로그인 시 받은 authorization code로 refresh token을 발급받아 저장
+// 저장한 refresh token은 회원 탈퇴 시 Apple 측 토큰 폐기에 사용
+func (s *AuthService) StoreAppleAuthorization(userID uint, code string) error {
+	if s.mfaCipher == nil {
+		return ErrAppleRevocationDisabled
+	}
+	secret, err := s.appleClientSecret()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), snsVerifyTimeout)
+	defer cancel()
+
+	tokens, err := sns.ExchangeAppleAuthorizationCode(ctx, s.cfg.AppleAuthBaseURL, s.cfg.AppleClientID, secret, code)
+	if err != nil {
+		return fmt.Errorf("Apple authorization code 교환에 실패했습니다: %w", err)
+	}
+
+	// Apple에서 직접 받은 id_token이므로 서명 검증 없이 sub만 확인
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(tokens.IDToken, &claims); err != nil || claims.Subject == "" {
+		return errors.New("Apple 토큰 응답에 사용자 정보가 없습니다")
+	}
+
+	var identity model.UserIdentity
+	if err := s.db.Where("user_id = ? AND provider = ? AND subject = ?", userID, model.LoginMethodApple, claims.Subject).
+		First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("Apple 계정이 연결되어 있지 않습니다")
+		}
+		return fmt.Errorf("failed to find apple identity: %w", err)
+	}
+
+	encrypted, err := s.mfaCipher.Encrypt(tokens.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt apple refresh token: %w", err)
+	}
+	if err := s.db.Model(&identity).Update("refresh_token", encrypted).Error; err != nil {
+		return fmt.Errorf("failed to save apple refresh token: %w", err)
+	}
+
+	s.logger.Debug("Apple refresh token stored",
+		zap.Uint("user_id", userID),
+	)
+	return nil
+}
+
+// revokeAppleTokens 사용자의 Apple refresh token을 Apple에 폐기 요청 (저장된 토큰이 없으면 아무것도 하지 않음)
+func (s *AuthService) revokeAppleTokens(userID uint) error {
+	var identities []model.UserIdentity
+	if err := s.db.Where("user_id = ? AND provider = ? AND refresh_token <> ''", userID, model.LoginMethodApple).
+		Find(&identities).Error; err != nil {
+		return fmt.Errorf("failed to list apple identities: %w", err)
+	}
+	if len(identities) == 0 {
+		return nil
+	}
+
+	if s.mfaCipher == nil {
+		return ErrAppleRevocationDisabled
+	}
+	secret, err := s.appleClientSecret()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), snsVerifyTimeout)
+	defer cancel()
+
+	for i := range identities {
+		token, err := s.mfaCipher.Decrypt(identities[i].RefreshToken)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt apple refresh token: %w", err)
+		}
+		if err := sns.RevokeAppleToken(ctx, s.cfg.AppleAuthBaseURL, s.cfg.AppleClientID, secret, token, "refresh_token"); err != nil {
+			return fmt.Errorf("Apple 토큰 폐기에 실패했습니다: %w", err)
+		}
+		if err := s.db.Model(&identities[i]).Update("refresh_token", "").Error; err != nil {
+			return fmt.Errorf("failed to clear apple refresh token: %w", err)
+		}
+	}
+
+	s.logger.Info("Apple tokens revoked",
+		zap.Uint("user_id", userID),
+		zap.Int("count", len(identities)),
+	)
+	return nil
+}
+
+// HandleAppleNotification Apple 서버 알림(Sign in with Apple server-to-server notification) 처리
+// 연결된 사용자가 없는 알림은 무시
+func (s *AuthService) HandleAppleNotification(payload string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), snsVerifyTimeout)
+	defer cancel()
+
+	event, err := sns.VerifyAppleNotification(ctx, s.cfg.AppleKeysURL, payload, s.cfg.AppleClientID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAppleNotificationInvalid, err)
+	}
+
+	user, err := s.findUserByIdentity(s.db, model.LoginMethodApple, event.Sub, "")
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		s.logger.Info("Apple notification for unknown user ignored",
+			zap.String("type", event.Type),
+		)
+		return nil
+	}
+
+	s.logger.Info("Apple notification received",
+		zap.String("type", event.Type),
+		zap.Uint("user_id", user.ID),
+	)
+
+	switch event.Type {
+	case sns.AppleEventEmailDisabled, sns.AppleEventEmailEnabled:
+		disabled := event.Type == sns.AppleEventEmailDisabled
+		if err := s.db.Model(user).Update("apple_email_disabled", disabled).Error; err != nil {
+			return fmt.Errorf("failed to update apple email status: %w", err)
+		}
+	case sns.AppleEventConsentRevoked:
+		// Apple 측 토큰은 이미 폐기되었으므로 저장된 토큰을 지우고 로그아웃 처리
+		if err := s.db.Model(&model.UserIdentity{}).
+			Where("user_id = ? AND provider = ? AND subject = ?", user.ID, model.LoginMethodApple, event.Sub).
+			Update("refresh_token", "").Error; err != nil {
+			return fmt.Errorf("failed to clear apple refresh token: %w", err)
+		}
+		if err := s.revoker.RevokeAllForUser(user.ID); err != nil {
+			return fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+	case sns.AppleEventAccountDelete:
+		return s.handleAppleAccountDelete(user)
+	default:
+		s.logger.Warn("Unknown Apple notification type",
+			zap.String("type", event.Type),
+			zap.Uint("user_id", user.ID),
+		)
+	}
+	return nil
+}
+
+// handleAppleAccountDelete Apple 계정 삭제 알림 처리
+// 다른 로그인 수단이 있으면 Apple 연결만 해제하고, Apple이 유일한 로그인 수단이면 계정 탈퇴 요청 (유예 기간 후 완전 삭제)
+func (s *AuthService) handleAppleAccountDelete(user *model.User) error {
+	var others int64
+	if err := s.db.Model(&model.UserIdentity{}).
+		Where("user_id = ? AND provider <> ?", user.ID, model.LoginMethodApple).
+		Count(&others).Error; err != nil {
+		return fmt.Errorf("failed to count identities: %w", err)
+	}
+
+	if others > 0 {
+		return s.UnlinkIdentity(user.ID, model.LoginMethodApple)
+	}
+	_, err := s.requestAccountDeletion(user, "Apple 계정 삭제")
+	return err
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/sns"
+)
+
+// appleAuthStub Apple /auth/token, /auth/revoke stub (폐기 요청 기록)
+type appleAuthStub struct {
+	mu      sync.Mutex
+	revoked []string
+	secrets []string
+}
+
+func (a *appleAuthStub) register(mux *http.ServeMux, subject string) {
+	mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		idToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": subject}).SignedString([]byte("unused"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "apple-access",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+			"refresh_token": "apple-refresh-token",
+			"id_token":      idToken,
+		})
+	})
+	mux.HandleFunc("/auth/revoke", func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.revoked = append(a.revoked, r.FormValue("token"))
+		a.secrets = append(a.secrets, r.FormValue("client_secret"))
+	})
+}
+
+// setupAppleAuthService Apple 토큰 폐기/알림 처리를 stub 서버로 연결한 인증 서비스
+func setupAppleAuthService(t *testing.T) (*AuthService, *rsa.PrivateKey, *ecdsa.PrivateKey, *appleAuthStub) {
+	svc, appleKey := setupSNSAuthService(t)
+
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(signingKey)
+	require.NoError(t, err)
+
+	stub := &appleAuthStub{}
+	mux := http.NewServeMux()
+	stub.register(mux, "apple-sub-1")
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	svc.cfg.AppleTeamID = "TEAM123456"
+	svc.cfg.AppleKeyID = "KEY1234567"
+	svc.cfg.ApplePrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	svc.cfg.AppleAuthBaseURL = server.URL
+	return svc, appleKey, signingKey, stub
+}
+
+// appleNotificationPayload 테스트용 Apple 서버 알림 JWT 생성
+func appleNotificationPayload(t *testing.T, key *rsa.PrivateKey, eventType, subject string) string {
+	return appleNotificationPayloadFor(t, key, "com.example.ojeomneo", eventType, subject)
+}
+
+// appleNotificationPayloadFor 지정한 앱(aud)으로 보낸 테스트용 Apple 서버 알림 JWT 생성
+func appleNotificationPayloadFor(t *testing.T, key *rsa.PrivateKey, audience, eventType, subject string) string {
+	events, err := json.Marshal(sns.AppleNotificationEvent{Type: eventType, Sub: subject, EventTime: time.Now().UnixMilli()})
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":    "https://appleid.apple.com",
+		"aud":    audience,
+		"iat":    time.Now().Unix(),
+		"jti":    "notification-" + eventType,
+		"events": string(events),
+	})
+	token.Header["kid"] = "test-kid"
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestAuthService_DeleteMe_RevokesAppleToken(t *testing.T) {
+	svc, appleKey, signingKey, stub := setupAppleAuthService(t)
+
+	response, err := svc.SNSLogin(model.LoginMethodApple, appleIdentityToken(t, appleKey, "apple-sub-1", "apple@example.com"), ClientInfo{})
+	require.NoError(t, err)
+	userID := response.User.ID
+
+	t.Run("잘못된 authorization code는 저장하지 않음", func(t *testing.T) {
+		assert.Error(t, svc.StoreAppleAuthorization(userID, "invalid-code"))
+	})
+
+	t.Run("refresh token 암호화 저장", func(t *testing.T) {
+		require.NoError(t, svc.StoreAppleAuthorization(userID, "valid-code"))
+
+		var identity model.UserIdentity
+		require.NoError(t, svc.db.Where("user_id = ? AND provider = ?", userID, model.LoginMethodApple).First(&identity).Error)
+		assert.NotEmpty(t, identity.RefreshToken)
+		assert.NotEqual(t, "apple-refresh-token", identity.RefreshToken)
+	})
+
+	t.Run("탈퇴 시 Apple에 refresh token 폐기 요청", func(t *testing.T) {
+		_, err := svc.DeleteMe(userID, nil)
+		require.NoError(t, err)
+
+		require.Len(t, stub.revoked, 1)
+		assert.Equal(t, "apple-refresh-token", stub.revoked[0])
+
+		// client_secret은 등록한 개인키로 서명되고 팀 ID/Bundle ID를 담아야 함
+		claims := &jwt.RegisteredClaims{}
+		_, err = jwt.ParseWithClaims(stub.secrets[0], claims, func(token *jwt.Token) (interface{}, error) {
+			assert.Equal(t, "KEY1234567", token.Header["kid"])
+			return &signingKey.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithAudience("https://appleid.apple.com"))
+		require.NoError(t, err)
+		assert.Equal(t, "TEAM123456", claims.Issuer)
+		assert.Equal(t, "com.example.ojeomneo", claims.Subject)
+	})
+}
+
+func TestAuthService_HandleAppleNotification(t *testing.T) {
+	svc, appleKey, _, _ := setupAppleAuthService(t)
+
+	response, err := svc.SNSLogin(model.LoginMethodApple, appleIdentityToken(t, appleKey, "apple-sub-1", "relay@privaterelay.appleid.com"), ClientInfo{})
+	require.NoError(t, err)
+	userID := response.User.ID
+
+	t.Run("이메일 전달 중지/재개 반영", func(t *testing.T) {
+		require.NoError(t, svc.HandleAppleNotification(appleNotificationPayload(t, appleKey, sns.AppleEventEmailDisabled, "apple-sub-1")))
+		var user model.User
+		require.NoError(t, svc.db.First(&user, userID).Error)
+		assert.True(t, user.AppleEmailDisabled)
+
+		require.NoError(t, svc.HandleAppleNotification(appleNotificationPayload(t, appleKey, sns.AppleEventEmailEnabled, "apple-sub-1")))
+		require.NoError(t, svc.db.First(&user, userID).Error)
+		assert.False(t, user.AppleEmailDisabled)
+	})
+
+	t.Run("사용 중단 시 발급된 토큰 폐기", func(t *testing.T) {
+		require.NoError(t, svc.HandleAppleNotification(appleNotificationPayload(t, appleKey, sns.AppleEventConsentRevoked, "apple-sub-1")))
+
+		var user model.User
+		require.NoError(t, svc.db.First(&user, userID).Error)
+		assert.NotNil(t, user.TokensRevokedBefore)
+	})
+
+	t.Run("서명이 다른 알림 거부", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		err = svc.HandleAppleNotification(appleNotificationPayload(t, otherKey, sns.AppleEventAccountDelete, "apple-sub-1"))
+		assert.ErrorIs(t, err, ErrAppleNotificationInvalid)
+	})
+
+	t.Run("다른 앱으로 보낸 알림 거부", func(t *testing.T) {
+		err := svc.HandleAppleNotification(appleNotificationPayloadFor(t, appleKey, "com.other.app", sns.AppleEventAccountDelete, "apple-sub-1"))
+		assert.ErrorIs(t, err, ErrAppleNotificationInvalid)
+	})
+
+	t.Run("APPLE_CLIENT_ID 미설정 시 알림 거부", func(t *testing.T) {
+		clientID := svc.cfg.AppleClientID
+		svc.cfg.AppleClientID = ""
+		defer func() { svc.cfg.AppleClientID = clientID }()
+
+		err := svc.HandleAppleNotification(appleNotificationPayload(t, appleKey, sns.AppleEventAccountDelete, "apple-sub-1"))
+		assert.ErrorIs(t, err, ErrAppleNotificationInvalid)
+
+		var count int64
+		svc.db.Model(&model.User{}).Where("id = ?", userID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("연결되지 않은 Apple 계정 알림은 무시", func(t *testing.T) {
+		assert.NoError(t, svc.HandleAppleNotification(appleNotificationPayload(t, appleKey, sns.AppleEventAccountDelete, "unknown-sub")))
+	})
+
+	t.Run("Apple 계정 삭제 시 Apple만 연결된 계정 탈퇴", func(t *testing.T) {
+		require.NoError(t, svc.HandleAppleNotification(appleNotificationPayload(t, appleKey, sns.AppleEventAccountDelete, "apple-sub-1")))
+
+		var count int64
+		svc.db.Model(&model.User{}).Where("id = ?", userID).Count(&count)
+		assert.Zero(t, count)
+
+		// 유예 기간 동안 복구할 수 있도록 탈퇴 요청만 기록
+		var deletion model.AccountDeletion
+		require.NoError(t, svc.db.Where("user_id = ?", userID).First(&deletion).Error)
+		assert.Equal(t, "Apple 계정 삭제", deletion.Reason)
+	})
+
+	t.Run("다른 로그인 수단이 있으면 Apple 연결만 해제", func(t *testing.T) {
+		kakao, err := svc.SNSLogin(model.LoginMethodKakao, "kakao-token", ClientInfo{})
+		require.NoError(t, err)
+		require.NoError(t, svc.createIdentity(svc.db, kakao.User.ID, model.LoginMethodApple, "apple-sub-2", ""))
+
+		require.NoError(t, svc.HandleAppleNotification(appleNotificationPayload(t, appleKey, sns.AppleEventAccountDelete, "apple-sub-2")))
+
+		var user model.User
+		require.NoError(t, svc.db.First(&user, kakao.User.ID).Error)
+		var count int64
+		svc.db.Model(&model.UserIdentity{}).Where("user_id = ? AND provider = ?", kakao.User.ID, model.LoginMethodApple).Count(&count)
+		assert.Zero(t, count)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ggorockee/ojeomneo/server/internal/telemetry"
 	"github.com/ggorockee/ojeomneo/server/pkg/auth"
 	"github.com/ggorockee/ojeomneo/server/pkg/sns"
+	"github.com/ggorockee/ojeomneo/server/pkg/webauthn"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -25,10 +26,23 @@ type AuthService struct {
 	logger       *zap.Logger
 	metrics      *telemetry.AuthMetrics
 	emailService *email.SMTPService
+	revoker      *TokenRevoker
+	keys         *auth.KeySet
+	throttle     *LoginThrottle
+	mfaCipher    *auth.SecretCipher // nil이면 2단계 인증 비활성
+	webauthn     *webauthn.Config   // nil이면 passkey 비활성
+	challenges   *ChallengeStore
+	providers    *sns.Registry // SNS 로그인 제공자
+	emailCodeKey []byte        // 이메일 인증코드/토큰 HMAC 키
 }
 
 // NewAuthService 새 인증 서비스 생성
-func NewAuthService(db *gorm.DB, cfg *config.Config, logger *zap.Logger, metrics *telemetry.AuthMetrics) *AuthService {
+func NewAuthService(db *gorm.DB, cfg *config.Config, logger *zap.Logger, metrics *telemetry.AuthMetrics, revoker *TokenRevoker, keys *auth.KeySet, throttle *LoginThrottle, challenges *ChallengeStore) (*AuthService, error) {
+	emailCodeKey, err := newEmailCodeKey(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	// SMTP 이메일 서비스 초기화
 	var emailService *email.SMTPService
 	if cfg.SMTPUsername != "" && cfg.SMTPPassword != "" {
@@ -59,7 +73,15 @@ func NewAuthService(db *gorm.DB, cfg *config.Config, logger *zap.Logger, metrics
 		logger:       logger,
 		metrics:      metrics,
 		emailService: emailService,
-	}
+		revoker:      revoker,
+		keys:         keys,
+		throttle:     throttle,
+		mfaCipher:    newMFACipher(cfg, logger),
+		webauthn:     newWebAuthnConfig(cfg, logger),
+		challenges:   challenges,
+		providers:    newIdentityProviders(cfg),
+		emailCodeKey: emailCodeKey,
+	}, nil
 }
 
 // AuthResponse 인증 응답
@@ -68,6 +90,16 @@ type AuthResponse struct {
 	RefreshToken string        `json:"refresh_token"`
 	TokenType    string        `json:"token_type"`
 	User         *UserResponse `json:"user"`
+
+	// 익명 계정 전환 결과 (guest_token을 함께 보낸 경우에만)
+	GuestUpgrade *GuestUpgradeResult `json:"guest_upgrade,omitempty"`
+
+	// 탈퇴 유예 기간 중인 계정이 이번 로그인으로 복구된 경우 true
+	AccountRestored bool `json:"account_restored,omitempty"`
+
+	// 2단계 인증 필요 시 토큰 대신 challenge 토큰 반환 (/auth/mfa/verify에서 코드와 교환)
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
 }
 
 // UserResponse 사용자 응답
@@ -82,22 +114,24 @@ type UserResponse struct {
 // SNSLoginRequest SNS 로그인 요청
 type SNSLoginRequest struct {
 	IDToken     string `json:"id_token"`     // Google (Firebase ID Token)
-	AccessToken string `json:"access_token"` // Apple/Kakao
+	AccessToken string `json:"access_token"` // Apple/Kakao/Naver
 }
 
 // SignupRequest 회원가입 요청
 type SignupRequest struct {
-	Email           string  `json:"email"`
-	Password        string  `json:"password"`
-	FirstName       *string `json:"first_name,omitempty"`
-	LastName        *string `json:"last_name,omitempty"`
-	VerificationToken *string `json:"verification_token,omitempty"`
+	Email             string     `json:"email"`
+	Password          string     `json:"password"`
+	FirstName         *string    `json:"first_name,omitempty"`
+	LastName          *string    `json:"last_name,omitempty"`
+	VerificationToken *string    `json:"verification_token,omitempty"`
+	Client            ClientInfo `json:"-"` // 요청 기기 정보 (세션 기록용)
 }
 
 // LoginRequest 이메일 로그인 요청
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string     `json:"email"`
+	Password string     `json:"password"`
+	Client   ClientInfo `json:"-"` // 요청 기기 정보 (세션 기록 및 무차별 대입 방지 집계용)
 }
 
 // GuestLoginRequest 익명 로그인 요청
@@ -105,253 +139,10 @@ type GuestLoginRequest struct {
 	DeviceID string `json:"device_id"` // 디바이스 고유 ID (UUID)
 }
 
-// GoogleLogin Google 로그인 처리 (Firebase ID Token 사용)
-func (s *AuthService) GoogleLogin(idToken string) (*AuthResponse, error) {
-	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	s.logger.Debug("Starting Google login",
-		zap.String("provider", "google"),
-	)
-
-	// Firebase ID Token 검증 (goroutine으로 비동기 처리)
-	type verifyResult struct {
-		user *sns.FirebaseUserInfo
-		err  error
-	}
-	resultCh := make(chan verifyResult, 1)
-
-	go func() {
-		user, err := sns.VerifyFirebaseIDToken(ctx, idToken)
-		resultCh <- verifyResult{user, err}
-	}()
-
-	// 검증 결과 대기
-	result := <-resultCh
-	verifyDuration := time.Since(start)
-
-	if result.err != nil {
-		s.logger.Warn("Google login token verification failed",
-			zap.Error(result.err),
-			zap.String("provider", "google"),
-			zap.Duration("duration", verifyDuration),
-		)
-		// 메트릭 기록: 토큰 검증 실패
-		if s.metrics != nil {
-			s.metrics.RecordSNSLogin(ctx, "google", "token_invalid", float64(time.Since(start).Milliseconds()))
-		}
-		return nil, fmt.Errorf("firebase 토큰 검증에 실패했습니다: %w", result.err)
-	}
-
-	firebaseUser := result.user
-	if firebaseUser.Email == "" {
-		s.logger.Warn("Google login missing email",
-			zap.String("provider", "google"),
-			zap.String("user_id", firebaseUser.ID),
-		)
-		return nil, errors.New("이메일이 필요합니다")
-	}
-
-	s.logger.Debug("Google token verified",
-		zap.String("provider", "google"),
-		zap.String("email", firebaseUser.Email),
-		zap.String("user_id", firebaseUser.ID),
-		zap.Duration("verify_duration", verifyDuration),
-	)
-
-	response, err := s.handleSNSLogin(
-		"google",
-		firebaseUser.ID,
-		firebaseUser.Email,
-		firebaseUser.Name,
-	)
-
-	if err != nil {
-		s.logger.Error("Google login failed",
-			zap.Error(err),
-			zap.String("provider", "google"),
-			zap.String("email", firebaseUser.Email),
-			zap.Duration("total_duration", time.Since(start)),
-		)
-		// 메트릭 기록: 로그인 실패
-		if s.metrics != nil {
-			s.metrics.RecordSNSLogin(ctx, "google", "failed", float64(time.Since(start).Milliseconds()))
-		}
-		return nil, err
-	}
-
-	s.logger.Info("Google login successful",
-		zap.String("provider", "google"),
-		zap.Uint("user_id", response.User.ID),
-		zap.String("email", response.User.Email),
-		zap.Duration("total_duration", time.Since(start)),
-	)
-
-	// 메트릭 기록: 로그인 성공
-	if s.metrics != nil {
-		s.metrics.RecordSNSLogin(ctx, "google", "success", float64(time.Since(start).Milliseconds()))
-		s.metrics.RecordTokenIssued(ctx, "access")
-		s.metrics.RecordTokenIssued(ctx, "refresh")
-	}
-
-	return response, nil
-}
-
-// AppleLogin Apple 로그인 처리
-func (s *AuthService) AppleLogin(identityToken string) (*AuthResponse, error) {
-	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	s.logger.Debug("Starting Apple login",
-		zap.String("provider", "apple"),
-	)
-
-	// Apple Identity Token 검증 (goroutine으로 비동기 처리)
-	type verifyResult struct {
-		user *sns.AppleUserInfo
-		err  error
-	}
-	resultCh := make(chan verifyResult, 1)
-
-	go func() {
-		user, err := sns.VerifyAppleToken(ctx, identityToken, s.cfg.AppleClientID)
-		resultCh <- verifyResult{user, err}
-	}()
-
-	// 검증 결과 대기
-	result := <-resultCh
-	verifyDuration := time.Since(start)
-
-	if result.err != nil {
-		s.logger.Warn("Apple login token verification failed",
-			zap.Error(result.err),
-			zap.String("provider", "apple"),
-			zap.Duration("duration", verifyDuration),
-		)
-		return nil, fmt.Errorf("apple 토큰 검증에 실패했습니다: %w", result.err)
-	}
-
-	appleUser := result.user
-	// Apple 로그인은 첫 로그인 시에만 이메일이 제공되고, 이후 로그인에서는 이메일이 없을 수 있음
-	// social_id(sub)로 사용자를 찾을 수 있으므로 이메일이 없어도 처리 가능
-
-	s.logger.Debug("Apple token verified",
-		zap.String("provider", "apple"),
-		zap.String("email", appleUser.Email),
-		zap.String("user_id", appleUser.ID),
-		zap.Duration("verify_duration", verifyDuration),
-	)
-
-	response, err := s.handleSNSLogin(
-		"apple",
-		appleUser.ID,
-		appleUser.Email, // 빈 문자열일 수 있음
-		appleUser.Name,
-	)
-
-	if err != nil {
-		s.logger.Error("Apple login failed",
-			zap.Error(err),
-			zap.String("provider", "apple"),
-			zap.String("email", appleUser.Email),
-			zap.Duration("total_duration", time.Since(start)),
-		)
-		return nil, err
-	}
-
-	s.logger.Info("Apple login successful",
-		zap.String("provider", "apple"),
-		zap.Uint("user_id", response.User.ID),
-		zap.String("email", response.User.Email),
-		zap.Duration("total_duration", time.Since(start)),
-	)
-
-	return response, nil
-}
-
-// KakaoLogin Kakao 로그인 처리
-func (s *AuthService) KakaoLogin(accessToken string) (*AuthResponse, error) {
-	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	s.logger.Debug("Starting Kakao login",
-		zap.String("provider", "kakao"),
-	)
-
-	// Kakao Access Token 검증 (goroutine으로 비동기 처리)
-	type verifyResult struct {
-		user *sns.KakaoUserInfo
-		err  error
-	}
-	resultCh := make(chan verifyResult, 1)
-
-	go func() {
-		user, err := sns.VerifyKakaoToken(ctx, accessToken)
-		resultCh <- verifyResult{user, err}
-	}()
-
-	// 검증 결과 대기
-	result := <-resultCh
-	verifyDuration := time.Since(start)
-
-	if result.err != nil {
-		s.logger.Warn("Kakao login token verification failed",
-			zap.Error(result.err),
-			zap.String("provider", "kakao"),
-			zap.Duration("duration", verifyDuration),
-		)
-		return nil, fmt.Errorf("kakao 토큰 검증에 실패했습니다: %w", result.err)
-	}
-
-	kakaoUser := result.user
-	if kakaoUser.Email == "" {
-		s.logger.Warn("Kakao login missing email",
-			zap.String("provider", "kakao"),
-			zap.String("user_id", kakaoUser.ID),
-		)
-		return nil, errors.New("이메일 제공 동의가 필요합니다")
-	}
-
-	s.logger.Debug("Kakao token verified",
-		zap.String("provider", "kakao"),
-		zap.String("email", kakaoUser.Email),
-		zap.String("user_id", kakaoUser.ID),
-		zap.Duration("verify_duration", verifyDuration),
-	)
-
-	response, err := s.handleSNSLogin(
-		"kakao",
-		kakaoUser.ID,
-		kakaoUser.Email,
-		kakaoUser.Name,
-	)
-
-	if err != nil {
-		s.logger.Error("Kakao login failed",
-			zap.Error(err),
-			zap.String("provider", "kakao"),
-			zap.String("email", kakaoUser.Email),
-			zap.Duration("total_duration", time.Since(start)),
-		)
-		return nil, err
-	}
-
-	s.logger.Info("Kakao login successful",
-		zap.String("provider", "kakao"),
-		zap.Uint("user_id", response.User.ID),
-		zap.String("email", response.User.Email),
-		zap.Duration("total_duration", time.Since(start)),
-	)
-
-	return response, nil
-}
-
 // handleSNSLogin 공통 SNS 로그인 로직 (goroutine으로 최적화)
 // profileImage는 현재 User 모델에 필드가 없어 사용하지 않음
-func (s *AuthService) handleSNSLogin(provider, socialID, email, name string) (*AuthResponse, error) {
+// emailVerified는 제공자가 이메일 소유를 확인했는지 여부 (이메일 충돌 시 자동 연결 판단에 사용)
+func (s *AuthService) handleSNSLogin(provider, socialID, email string, emailVerified bool, name string, client ClientInfo) (*AuthResponse, error) {
 	start := time.Now()
 
 	// 이메일 정규화
@@ -359,18 +150,45 @@ func (s *AuthService) handleSNSLogin(provider, socialID, email, name string) (*A
 
 	var user *model.User
 	var isNewUser bool
+	var restored bool
 
 	// DB 트랜잭션에서 사용자 생성/조회
 	txErr := s.db.Transaction(func(tx *gorm.DB) error {
-		// 기존 사용자 찾기: login_method + social_id 조합 (social_id가 고유함)
+		// 탈퇴 유예 기간 중인 계정이면 복구 후 로그인
+		deletedUser, err := s.findDeletedUserByIdentity(tx, model.LoginMethod(provider), socialID)
+		if err != nil {
+			return err
+		}
+		if deletedUser != nil {
+			if err := s.restoreAccount(tx, deletedUser); err != nil {
+				return err
+			}
+			restored = true
+		}
+
+		// 기존 사용자 찾기: 연결된 로그인 수단(provider + social_id) 기준
 		// Apple 로그인의 경우 이메일이 없을 수 있으므로 social_id로 먼저 찾음
-		var existingUser model.User
-		err := tx.Where("login_method = ? AND social_id = ?", provider, socialID).
-			First(&existingUser).Error
+		existingUser, err := s.findUserByIdentity(tx, model.LoginMethod(provider), socialID, email)
+		if err != nil {
+			return err
+		}
+
+		// 처음 보는 로그인 수단이면 이메일 충돌 정책 적용 (기존 계정 연결 또는 거부)
+		if existingUser == nil {
+			existingUser, err = s.resolveEmailCollision(tx, model.LoginMethod(provider), socialID, email, emailVerified)
+			if err != nil {
+				return err
+			}
+		}
+
+		if existingUser != nil {
+			// 비활성화된 계정 (Kakao 연결 끊기 등)은 로그인 거부
+			if !existingUser.IsActive {
+				return errors.New("비활성화된 계정입니다")
+			}
 
-		if err == nil {
 			// 기존 사용자 발견
-			user = &existingUser
+			user = existingUser
 			isNewUser = false
 
 			s.logger.Debug("Existing user found for SNS login",
@@ -459,6 +277,10 @@ func (s *AuthService) handleSNSLogin(provider, socialID, email, name string) (*A
 			return fmt.Errorf("failed to create user: %w", err)
 		}
 
+		if err := s.createIdentity(tx, user.ID, model.LoginMethod(provider), socialID, email); err != nil {
+			return err
+		}
+
 		s.logger.Info("New user created for SNS login",
 			zap.String("provider", provider),
 			zap.Uint("user_id", user.ID),
@@ -488,12 +310,7 @@ func (s *AuthService) handleSNSLogin(provider, socialID, email, name string) (*A
 	tokenCh := make(chan tokenResult, 1)
 
 	go func() {
-		accessToken, refreshToken, err := auth.GenerateTokenPair(
-			user.ID,
-			s.cfg.JWTSecretKey,
-			s.cfg.JWTAccessTokenExpireMin,
-			s.cfg.JWTRefreshTokenExpireDays,
-		)
+		accessToken, refreshToken, err := s.issueSessionTokens(user.ID, model.LoginMethod(provider), client)
 		tokenCh <- tokenResult{accessToken, refreshToken, err}
 	}()
 
@@ -527,6 +344,7 @@ func (s *AuthService) handleSNSLogin(provider, socialID, email, name string) (*A
 			DateJoined:  user.DateJoined,
 			LoginMethod: string(user.LoginMethod),
 		},
+		AccountRestored: restored,
 	}, nil
 }
 
@@ -543,29 +361,18 @@ func normalizeEmail(email string) string {
 }
 
 // SendEmailCode 이메일 인증코드 발송
-func (s *AuthService) SendEmailCode(email string) error {
+// 같은 이메일은 EMAIL_CODE_RESEND_COOLDOWN_SECONDS 안에 다시 보낼 수 없고, 이메일/IP별 24시간 발송 한도를 넘으면 *EmailCodeThrottleError 반환
+func (s *AuthService) SendEmailCode(email string, client ClientInfo) error {
 	// 이메일 정규화
 	email = normalizeEmail(email)
 
-	// 6자리 인증코드 생성
-	code := fmt.Sprintf("%06d", rand.Intn(1000000))
-
-	// 기존 인증코드 삭제
-	s.db.Where("email = ?", email).Delete(&model.EmailVerification{})
-
-	// 새 인증 레코드 생성
-	verification := model.EmailVerification{
-		Email:     email,
-		Code:      code,
-		ExpiresAt: time.Now().Add(10 * time.Minute),
-		SendCount: 1,
-		LastSentAt: func() *time.Time {
-			now := time.Now()
-			return &now
-		}(),
-	}
-
-	if err := s.db.Create(&verification).Error; err != nil {
+	// 6자리 인증코드 생성 (같은 용도의 기존 인증코드는 폐기)
+	code, err := s.issueEmailCode(email, client.IP, model.EmailPurposeSignup, 10*time.Minute)
+	if err != nil {
+		var throttleErr *EmailCodeThrottleError
+		if errors.As(err, &throttleErr) {
+			return err
+		}
 		s.logger.Error("Failed to create email verification",
 			zap.Error(err),
 			zap.String("email", email),
@@ -582,12 +389,10 @@ func (s *AuthService) SendEmailCode(email string) error {
 					zap.Error(err),
 					zap.String("email", email),
 				)
-				fmt.Printf("[이메일 발송 실패] %s -> %s (에러: %v)\n", email, code, err)
 			} else {
 				s.logger.Info("Email verification code sent successfully",
 					zap.String("email", email),
 				)
-				fmt.Printf("[이메일 발송 성공] %s\n", email)
 			}
 		}()
 	} else {
@@ -603,41 +408,20 @@ func (s *AuthService) SendEmailCode(email string) error {
 }
 
 // VerifyEmailCode 이메일 인증코드 확인
+// 성공 시 회원가입/이메일 로그인 연결에 사용할 verification token 발급 (30분 유효, 한 번만 사용 가능)
 func (s *AuthService) VerifyEmailCode(email, code string) (bool, string, error) {
 	// 이메일 정규화
 	email = normalizeEmail(email)
 
-	var verification model.EmailVerification
-	err := s.db.Where("email = ? AND code = ? AND expires_at > ?", email, code, time.Now()).
-		First(&verification).Error
-
+	token, err := s.redeemEmailCode(email, code, model.EmailPurposeSignup)
 	if err != nil {
-		// 실패 횟수 증가
-		var existingVerification model.EmailVerification
-		if err := s.db.Where("email = ?", email).First(&existingVerification).Error; err == nil {
-			s.db.Model(&existingVerification).Update("attempts", gorm.Expr("attempts + 1"))
-		}
-
 		s.logger.Warn("Email verification code invalid or expired",
 			zap.String("email", email),
 			zap.Error(err),
 		)
-		return false, "", errors.New("인증코드가 유효하지 않거나 만료되었습니다")
-	}
-
-	// 인증 성공 처리
-	verification.IsVerified = true
-	verification.Attempts = 0
-
-	// VerificationToken 생성 (회원가입 시 사용)
-	token := fmt.Sprintf("%s_%d_%d", email, time.Now().Unix(), rand.Intn(10000))
-	verification.VerificationToken = &token
-
-	if err := s.db.Save(&verification).Error; err != nil {
-		s.logger.Error("Failed to update email verification",
-			zap.Error(err),
-			zap.String("email", email),
-		)
+		if errors.Is(err, ErrEmailCodeInvalid) || errors.Is(err, ErrEmailCodeAttemptsExceeded) {
+			return false, "", err
+		}
 		return false, "", fmt.Errorf("인증 처리에 실패했습니다: %w", err)
 	}
 
@@ -654,20 +438,24 @@ func (s *AuthService) Signup(req *SignupRequest) (*AuthResponse, error) {
 	email := normalizeEmail(req.Email)
 
 	// 이메일 인증 확인
-	var verification model.EmailVerification
+	var verification *model.EmailVerification
 	var verificationErr error
 
 	if req.VerificationToken != nil && *req.VerificationToken != "" {
 		// VerificationToken으로 확인
-		verificationErr = s.db.Where("email = ? AND verification_token = ? AND is_verified = ?",
-			email, *req.VerificationToken, true).First(&verification).Error
+		verification, verificationErr = s.findVerifiedEmail(s.db, email, *req.VerificationToken, model.EmailPurposeSignup)
 	} else {
-		// IsVerified로 확인 (하위 호환성)
-		verificationErr = s.db.Where("email = ? AND is_verified = ?",
-			email, true).First(&verification).Error
+		// IsVerified로 확인 (하위 호환성, 토큰을 보내지 않는 이전 앱 버전)
+		var legacy model.EmailVerification
+		if err := s.db.Where("email = ? AND purpose = ? AND is_verified = ? AND expires_at > ?",
+			email, model.EmailPurposeSignup, true, time.Now()).First(&legacy).Error; err == nil {
+			verification = &legacy
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			verificationErr = err
+		}
 	}
 
-	if verificationErr != nil {
+	if verificationErr != nil || verification == nil {
 		s.logger.Warn("Signup failed: email not verified",
 			zap.String("email", email),
 			zap.Error(verificationErr),
@@ -675,10 +463,12 @@ func (s *AuthService) Signup(req *SignupRequest) (*AuthResponse, error) {
 		return nil, errors.New("이메일 인증이 완료되지 않았습니다")
 	}
 
-	// 기존 사용자 확인
-	var existingUser model.User
-	if err := s.db.Where("email = ? AND login_method = ?", email, "email").
-		First(&existingUser).Error; err == nil {
+	// 기존 사용자 확인 (이메일 로그인 수단이 연결된 계정)
+	existingUser, err := s.findUserByIdentity(s.db, model.LoginMethodEmail, email, email)
+	if err != nil {
+		return nil, fmt.Errorf("회원가입에 실패했습니다: %w", err)
+	}
+	if existingUser != nil {
 		s.logger.Warn("Signup failed: user already exists",
 			zap.String("email", email),
 			zap.Uint("user_id", existingUser.ID),
@@ -686,6 +476,15 @@ func (s *AuthService) Signup(req *SignupRequest) (*AuthResponse, error) {
 		return nil, errors.New("이미 가입된 이메일입니다")
 	}
 
+	// 탈퇴 유예 기간 중인 계정은 재가입 대신 로그인으로 복구
+	deletedUser, err := s.findDeletedUserByIdentity(s.db, model.LoginMethodEmail, email)
+	if err != nil {
+		return nil, fmt.Errorf("회원가입에 실패했습니다: %w", err)
+	}
+	if deletedUser != nil {
+		return nil, ErrAccountPendingDeletion
+	}
+
 	// 비밀번호 해싱
 	hashedPassword, err := auth.HashPassword(req.Password)
 	if err != nil {
@@ -720,21 +519,43 @@ func (s *AuthService) Signup(req *SignupRequest) (*AuthResponse, error) {
 		IsActive:    true,
 	}
 
-	if err := s.db.Create(&user).Error; err != nil {
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		// 다른 로그인 수단으로 가입된 이메일이면 충돌 정책 적용
+		linkedUser, err := s.resolveEmailCollision(tx, model.LoginMethodEmail, email, email, true)
+		if err != nil {
+			return err
+		}
+		if linkedUser != nil {
+			// 기존 계정에 이메일 로그인 연결 (인증코드로 이메일 소유 확인됨)
+			if err := tx.Model(linkedUser).Update("password", hashedPassword).Error; err != nil {
+				return fmt.Errorf("failed to set password: %w", err)
+			}
+			user = *linkedUser
+			return tx.Delete(verification).Error
+		}
+
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		if err := s.createIdentity(tx, user.ID, model.LoginMethodEmail, email, email); err != nil {
+			return err
+		}
+		// 인증 기록은 한 번만 사용 가능
+		return tx.Delete(verification).Error
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrEmailCollision) {
+			return nil, txErr
+		}
 		s.logger.Error("Failed to create user",
-			zap.Error(err),
+			zap.Error(txErr),
 			zap.String("email", email),
 		)
-		return nil, fmt.Errorf("회원가입에 실패했습니다: %w", err)
+		return nil, fmt.Errorf("회원가입에 실패했습니다: %w", txErr)
 	}
 
 	// JWT 토큰 발급
-	accessToken, refreshToken, err := auth.GenerateTokenPair(
-		user.ID,
-		s.cfg.JWTSecretKey,
-		s.cfg.JWTAccessTokenExpireMin,
-		s.cfg.JWTRefreshTokenExpireDays,
-	)
+	accessToken, refreshToken, err := s.issueSessionTokens(user.ID, model.LoginMethodEmail, req.Client)
 	if err != nil {
 		s.logger.Error("Failed to generate tokens",
 			zap.Error(err),
@@ -773,25 +594,47 @@ func (s *AuthService) EmailLogin(req *LoginRequest) (*AuthResponse, error) {
 		zap.String("email", email),
 	)
 
-	// 사용자 조회
-	var user model.User
-	if err := s.db.Where("email = ? AND login_method = ?", email, "email").
-		First(&user).Error; err != nil {
+	// 무차별 대입 방지: 잠금 또는 점진적 지연 확인
+	if err := s.throttle.Check(email, req.Client.IP); err != nil {
+		s.logger.Warn("Email login throttled",
+			zap.String("email", email),
+			zap.String("ip", req.Client.IP),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	// 사용자 조회 (이메일 로그인 수단이 연결된 계정, 없으면 탈퇴 유예 기간 중인 계정)
+	found, err := s.findUserByIdentity(s.db, model.LoginMethodEmail, email, email)
+	restoring := false
+	if err == nil && found == nil {
+		found, err = s.findDeletedUserByIdentity(s.db, model.LoginMethodEmail, email)
+		restoring = found != nil
+	}
+	if err != nil || found == nil {
 		s.logger.Warn("Email login failed: user not found",
 			zap.String("email", email),
 			zap.Error(err),
 		)
+		s.throttle.RecordFailure(email, req.Client.IP)
 		return nil, errors.New("이메일 또는 비밀번호가 올바르지 않습니다")
 	}
+	user := *found
 
-	// 비밀번호 확인
-	if !auth.CheckPassword(req.Password, user.Password) {
+	// 비밀번호 확인 (Django admin에서 만든 해시 포함)
+	passwordOK, needsRehash := auth.VerifyPassword(req.Password, user.Password)
+	if !passwordOK {
 		s.logger.Warn("Email login failed: invalid password",
 			zap.String("email", email),
 			zap.Uint("user_id", user.ID),
 		)
+		s.throttle.RecordFailure(email, req.Client.IP)
 		return nil, errors.New("이메일 또는 비밀번호가 올바르지 않습니다")
 	}
+	s.throttle.RecordSuccess(email)
+	if needsRehash {
+		s.upgradePasswordHash(&user, req.Password)
+	}
 
 	// 사용자 활성화 확인
 	if !user.IsActive {
@@ -802,6 +645,20 @@ func (s *AuthService) EmailLogin(req *LoginRequest) (*AuthResponse, error) {
 		return nil, errors.New("비활성화된 계정입니다")
 	}
 
+	// 2단계 인증 사용 계정: 코드 확인 후 토큰 발급 (탈퇴 계정 복구도 코드 확인 후 VerifyMFA에서 처리)
+	if user.MFAEnabled() {
+		return s.issueMFAChallenge(&user)
+	}
+
+	// 탈퇴 유예 기간 중인 계정은 비밀번호 확인 후 복구
+	if restoring {
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			return s.restoreAccount(tx, &user)
+		}); err != nil {
+			return nil, fmt.Errorf("계정 복구에 실패했습니다: %w", err)
+		}
+	}
+
 	// 마지막 로그인 시간 업데이트
 	now := time.Now()
 	user.LastLogin = &now
@@ -814,12 +671,7 @@ func (s *AuthService) EmailLogin(req *LoginRequest) (*AuthResponse, error) {
 	}
 
 	// JWT 토큰 발급
-	accessToken, refreshToken, err := auth.GenerateTokenPair(
-		user.ID,
-		s.cfg.JWTSecretKey,
-		s.cfg.JWTAccessTokenExpireMin,
-		s.cfg.JWTRefreshTokenExpireDays,
-	)
+	accessToken, refreshToken, err := s.issueSessionTokens(user.ID, model.LoginMethodEmail, req.Client)
 	if err != nil {
 		s.logger.Error("Failed to generate tokens",
 			zap.Error(err),
@@ -845,17 +697,42 @@ func (s *AuthService) EmailLogin(req *LoginRequest) (*AuthResponse, error) {
 			DateJoined:  user.DateJoined,
 			LoginMethod: string(user.LoginMethod),
 		},
+		AccountRestored: restoring,
 	}, nil
 }
 
+// upgradePasswordHash 이전 형식(bcrypt 등)으로 저장된 비밀번호를 로그인 시 Django와 공유하는 형식으로 재해싱
+// 실패해도 로그인은 계속 진행하며, 그사이 비밀번호가 바뀌었으면 덮어쓰지 않음
+func (s *AuthService) upgradePasswordHash(user *model.User, password string) {
+	hashed, err := auth.HashPassword(password)
+	if err != nil {
+		s.logger.Warn("Failed to rehash password", zap.Error(err), zap.Uint("user_id", user.ID))
+		return
+	}
+
+	result := s.db.Unscoped().Model(&model.User{}).
+		Where("id = ? AND password = ?", user.ID, user.Password).
+		Update("password", hashed)
+	if result.Error != nil {
+		s.logger.Warn("Failed to upgrade password hash", zap.Error(result.Error), zap.Uint("user_id", user.ID))
+		return
+	}
+	if result.RowsAffected > 0 {
+		user.Password = hashed
+		s.logger.Info("Password hash upgraded", zap.Uint("user_id", user.ID))
+	}
+}
+
 // RefreshToken Refresh Token으로 새 토큰 발급
-func (s *AuthService) RefreshToken(refreshTokenString string) (*AuthResponse, error) {
+func (s *AuthService) RefreshToken(refreshTokenString string, client ClientInfo) (*AuthResponse, error) {
 	// Refresh Token 검증
-	claims, err := auth.ValidateRefreshToken(refreshTokenString, s.cfg.JWTSecretKey)
+	// 전체 로그아웃/탈퇴 이전에 발급된 토큰은 폐기 확인에서 거부됨
+	claims, err := auth.ValidateRefreshToken(refreshTokenString, s.keys, s.revoker)
 	if err != nil {
 		s.logger.Warn("Refresh token validation failed",
 			zap.Error(err),
 		)
+		s.recordRefreshMetric(context.Background(), "invalid")
 		return nil, errors.New("유효하지 않은 토큰입니다")
 	}
 
@@ -877,19 +754,14 @@ func (s *AuthService) RefreshToken(refreshTokenString string) (*AuthResponse, er
 		return nil, errors.New("비활성화된 계정입니다")
 	}
 
-	// 새 토큰 발급
-	accessToken, newRefreshToken, err := auth.GenerateTokenPair(
-		user.ID,
-		s.cfg.JWTSecretKey,
-		s.cfg.JWTAccessTokenExpireMin,
-		s.cfg.JWTRefreshTokenExpireDays,
-	)
+	// 토큰 회전: 기존 Refresh Token 폐기 후 같은 패밀리로 새 토큰 발급
+	accessToken, newRefreshToken, err := s.rotateRefreshToken(refreshTokenString, claims, client)
 	if err != nil {
-		s.logger.Error("Failed to generate new tokens",
+		s.logger.Warn("Refresh token rotation failed",
 			zap.Error(err),
 			zap.Uint("user_id", user.ID),
 		)
-		return nil, fmt.Errorf("토큰 생성에 실패했습니다: %w", err)
+		return nil, err
 	}
 
 	s.logger.Debug("Token refreshed successfully",
@@ -911,39 +783,27 @@ func (s *AuthService) RefreshToken(refreshTokenString string) (*AuthResponse, er
 }
 
 // PasswordResetRequest 비밀번호 재설정 요청 (인증코드 발송)
-func (s *AuthService) PasswordResetRequest(email string) error {
+// 발송 제한은 회원가입 인증코드와 같은 규칙 적용
+func (s *AuthService) PasswordResetRequest(email string, client ClientInfo) error {
 	// 이메일 정규화
 	email = normalizeEmail(email)
 
-	// 이메일 로그인 사용자 확인
-	var user model.User
-	if err := s.db.Where("email = ? AND login_method = ?", email, "email").
-		First(&user).Error; err != nil {
+	// 이메일 로그인 수단이 연결된 사용자 확인 (대표 로그인 수단이 SNS인 계정 포함)
+	if found, err := s.findUserByIdentity(s.db, model.LoginMethodEmail, email, email); err != nil || found == nil {
 		s.logger.Warn("Password reset requested for non-existent email",
 			zap.String("email", email),
+			zap.Error(err),
 		)
 		return fmt.Errorf("등록되지 않은 이메일입니다")
 	}
 
-	// 6자리 인증코드 생성
-	code := fmt.Sprintf("%06d", rand.Intn(1000000))
-
-	// 기존 인증코드 삭제
-	s.db.Where("email = ?", email).Delete(&model.EmailVerification{})
-
-	// 새 인증 레코드 생성 (비밀번호 재설정용)
-	verification := model.EmailVerification{
-		Email:     email,
-		Code:      code,
-		ExpiresAt: time.Now().Add(60 * time.Minute), // 비밀번호 재설정은 60분 유효
-		SendCount: 1,
-		LastSentAt: func() *time.Time {
-			now := time.Now()
-			return &now
-		}(),
-	}
-
-	if err := s.db.Create(&verification).Error; err != nil {
+	// 6자리 인증코드 생성 (비밀번호 재설정은 60분 유효, 같은 용도의 기존 인증코드는 폐기)
+	code, err := s.issueEmailCode(email, client.IP, model.EmailPurposePasswordReset, 60*time.Minute)
+	if err != nil {
+		var throttleErr *EmailCodeThrottleError
+		if errors.As(err, &throttleErr) {
+			return err
+		}
 		s.logger.Error("Failed to create password reset verification",
 			zap.Error(err),
 			zap.String("email", email),
@@ -958,8 +818,6 @@ func (s *AuthService) PasswordResetRequest(email string) error {
 				zap.Error(err),
 				zap.String("email", email),
 			)
-			// 이메일 발송 실패해도 코드는 저장되었으므로 성공 반환 (개발 환경 대응)
-			fmt.Printf("[개발용] 이메일 발송 실패, 비밀번호 재설정 인증코드: %s -> %s\n", email, code)
 		} else {
 			s.logger.Info("Password reset code sent",
 				zap.String("email", email),
@@ -978,48 +836,20 @@ func (s *AuthService) PasswordResetRequest(email string) error {
 }
 
 // PasswordResetVerify 비밀번호 재설정 인증코드 확인
+// 성공 시 재설정 토큰 발급 (30분 유효, 한 번만 사용 가능)
 func (s *AuthService) PasswordResetVerify(email, code string) (string, error) {
 	// 이메일 정규화
 	email = normalizeEmail(email)
 
-	var verification model.EmailVerification
-	err := s.db.Where("email = ? AND code = ? AND expires_at > ?", email, code, time.Now()).
-		First(&verification).Error
-
+	resetToken, err := s.redeemEmailCode(email, code, model.EmailPurposePasswordReset)
 	if err != nil {
-		// 실패 횟수 증가
-		var existingVerification model.EmailVerification
-		if err := s.db.Where("email = ?", email).First(&existingVerification).Error; err == nil {
-			s.db.Model(&existingVerification).Update("attempts", gorm.Expr("attempts + 1"))
-
-			// 5회 이상 실패 시 에러
-			if existingVerification.Attempts >= 5 {
-				s.logger.Warn("Password reset verification attempts exceeded",
-					zap.String("email", email),
-					zap.Int("attempts", existingVerification.Attempts),
-				)
-				return "", errors.New("인증 시도 횟수를 초과했습니다. 다시 요청해주세요")
-			}
-		}
-
 		s.logger.Warn("Password reset verification code invalid or expired",
 			zap.String("email", email),
 			zap.Error(err),
 		)
-		return "", errors.New("인증코드가 유효하지 않거나 만료되었습니다")
-	}
-
-	// Reset Token 생성
-	resetToken := fmt.Sprintf("reset_%s_%d_%d", email, time.Now().Unix(), rand.Intn(10000))
-	verification.VerificationToken = &resetToken
-	verification.IsVerified = true
-	verification.Attempts = 0
-
-	if err := s.db.Save(&verification).Error; err != nil {
-		s.logger.Error("Failed to update password reset verification",
-			zap.Error(err),
-			zap.String("email", email),
-		)
+		if errors.Is(err, ErrEmailCodeInvalid) || errors.Is(err, ErrEmailCodeAttemptsExceeded) {
+			return "", err
+		}
 		return "", fmt.Errorf("인증 처리에 실패했습니다: %w", err)
 	}
 
@@ -1036,9 +866,8 @@ func (s *AuthService) PasswordResetConfirm(email, resetToken, newPassword string
 	email = normalizeEmail(email)
 
 	// Reset Token 확인
-	var verification model.EmailVerification
-	if err := s.db.Where("email = ? AND verification_token = ? AND is_verified = ?",
-		email, resetToken, true).First(&verification).Error; err != nil {
+	verification, err := s.findVerifiedEmail(s.db, email, resetToken, model.EmailPurposePasswordReset)
+	if err != nil || verification == nil {
 		s.logger.Warn("Password reset confirm failed: invalid token",
 			zap.String("email", email),
 			zap.Error(err),
@@ -1046,16 +875,16 @@ func (s *AuthService) PasswordResetConfirm(email, resetToken, newPassword string
 		return errors.New("유효하지 않은 재설정 토큰입니다")
 	}
 
-	// 사용자 조회
-	var user model.User
-	if err := s.db.Where("email = ? AND login_method = ?", email, "email").
-		First(&user).Error; err != nil {
+	// 사용자 조회 (이메일 로그인 수단이 연결된 계정)
+	found, err := s.findUserByIdentity(s.db, model.LoginMethodEmail, email, email)
+	if err != nil || found == nil {
 		s.logger.Warn("Password reset confirm failed: user not found",
 			zap.String("email", email),
 			zap.Error(err),
 		)
 		return errors.New("사용자를 찾을 수 없습니다")
 	}
+	user := *found
 
 	// 새 비밀번호 해싱
 	hashedPassword, err := auth.HashPassword(newPassword)
@@ -1079,7 +908,10 @@ func (s *AuthService) PasswordResetConfirm(email, resetToken, newPassword string
 	}
 
 	// 인증 레코드 삭제 (보안상 재사용 방지)
-	s.db.Delete(&verification)
+	s.db.Delete(verification)
+
+	// 비밀번호를 재설정했으므로 로그인 잠금 해제
+	s.throttle.Unlock(email)
 
 	s.logger.Info("Password reset successful",
 		zap.String("email", email),
@@ -1103,8 +935,10 @@ func (s *AuthService) GetMe(userID uint) (*model.User, error) {
 	return &user, nil
 }
 
-// DeleteMe 회원 탈퇴 (Soft Delete) - 탈퇴 사유 선택 가능
-func (s *AuthService) DeleteMe(userID uint, reason *string) error {
+// DeleteMe 회원 탈퇴 요청 - 탈퇴 사유 선택 가능
+// 계정은 즉시 soft delete되고, 유예 기간(ACCOUNT_DELETION_GRACE_DAYS) 안에 다시 로그인하면 복구되며
+// 유예 기간이 지나면 AccountPurger가 완전 삭제
+func (s *AuthService) DeleteMe(userID uint, reason *string) (*model.AccountDeletion, error) {
 	start := time.Now()
 
 	s.logger.Info("Starting account deletion",
@@ -1119,46 +953,114 @@ func (s *AuthService) DeleteMe(userID uint, reason *string) error {
 				zap.Uint("user_id", userID),
 				zap.Error(err),
 			)
-			return errors.New("사용자를 찾을 수 없습니다")
+			return nil, errors.New("사용자를 찾을 수 없습니다")
 		}
-		return fmt.Errorf("사용자 조회 실패: %w", err)
+		return nil, fmt.Errorf("사용자 조회 실패: %w", err)
 	}
 
 	// 익명 사용자는 탈퇴 불가 (자동 삭제 대상)
 	if user.IsGuest {
-		return errors.New("익명 사용자는 회원 탈퇴를 할 수 없습니다")
+		return nil, errors.New("익명 사용자는 회원 탈퇴를 할 수 없습니다")
+	}
+
+	// 탈퇴 사유 (선택사항, 탈퇴 요청 기록에 저장)
+	reasonText := ""
+	if reason != nil {
+		reasonText = strings.TrimSpace(*reason)
 	}
 
-	// 탈퇴 사유 로깅 (선택사항)
-	if reason != nil && *reason != "" {
-		s.logger.Info("Account deletion reason provided",
+	// Apple 로그인 토큰 폐기 (App Store 심사 지침: 탈퇴 시 Sign in with Apple 토큰 폐기)
+	if err := s.revokeAppleTokens(userID); err != nil {
+		s.logger.Error("Failed to revoke apple tokens of deleted user",
+			zap.Error(err),
 			zap.Uint("user_id", userID),
-			zap.String("reason", *reason),
 		)
 	}
 
-	// Soft Delete (GORM의 DeletedAt 사용)
-	if err := s.db.Delete(&user).Error; err != nil {
-		s.logger.Error("Failed to delete user",
+	// Kakao 앱 연결 끊기 (카카오 계정에 앱 연결이 남지 않도록)
+	if err := s.unlinkKakao(&user); err != nil {
+		s.logger.Error("Failed to unlink kakao of deleted user",
 			zap.Error(err),
 			zap.Uint("user_id", userID),
 		)
-		return fmt.Errorf("회원 탈퇴 처리에 실패했습니다: %w", err)
 	}
 
-	s.logger.Info("User deleted successfully",
+	deletion, err := s.requestAccountDeletion(&user, reasonText)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Account deletion requested",
 		zap.Uint("user_id", userID),
 		zap.String("email", user.Email),
 		zap.String("login_method", string(user.LoginMethod)),
+		zap.Bool("has_reason", reasonText != ""),
+		zap.Time("purge_after", deletion.PurgeAfter),
 		zap.Duration("duration", time.Since(start)),
 	)
 
+	return deletion, nil
+}
+
+// Logout 현재 기기 로그아웃
+// Access Token(jti)을 denylist에 등록하고 같은 세션의 Refresh Token 패밀리를 폐기
+func (s *AuthService) Logout(userID uint, tokenID, sessionID string, expiresAt time.Time) error {
+	if err := s.revoker.RevokeToken(userID, tokenID, expiresAt); err != nil {
+		s.logger.Error("Failed to revoke access token",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+		return fmt.Errorf("로그아웃 처리에 실패했습니다: %w", err)
+	}
+
+	if sessionID != "" {
+		if err := s.endSession(sessionID); err != nil {
+			s.logger.Error("Failed to end session",
+				zap.Error(err),
+				zap.Uint("user_id", userID),
+				zap.String("session_id", sessionID),
+			)
+			return fmt.Errorf("로그아웃 처리에 실패했습니다: %w", err)
+		}
+	} else {
+		// 세션 정보가 없는 기존 토큰은 연결된 Refresh Token을 알 수 없으므로 전체 폐기
+		if err := s.revoker.RevokeAllForUser(userID); err != nil {
+			s.logger.Error("Failed to revoke tokens for legacy session",
+				zap.Error(err),
+				zap.Uint("user_id", userID),
+			)
+			return fmt.Errorf("로그아웃 처리에 실패했습니다: %w", err)
+		}
+	}
+
+	s.logger.Info("User logged out",
+		zap.Uint("user_id", userID),
+		zap.String("session_id", sessionID),
+	)
+
+	return nil
+}
+
+// LogoutAll 모든 기기 로그아웃 (발급된 모든 토큰 폐기)
+func (s *AuthService) LogoutAll(userID uint) error {
+	if err := s.revoker.RevokeAllForUser(userID); err != nil {
+		s.logger.Error("Failed to revoke all tokens",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+		return fmt.Errorf("전체 로그아웃 처리에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("User logged out from all devices",
+		zap.Uint("user_id", userID),
+	)
+
 	return nil
 }
 
 // GuestLogin 익명 로그인 처리 (디바이스 ID 기반)
 // 로그인하지 않고 둘러보기 기능 지원
-func (s *AuthService) GuestLogin(deviceID string) (*AuthResponse, error) {
+func (s *AuthService) GuestLogin(deviceID string, client ClientInfo) (*AuthResponse, error) {
 	start := time.Now()
 	ctx := context.Background()
 
@@ -1166,6 +1068,9 @@ func (s *AuthService) GuestLogin(deviceID string) (*AuthResponse, error) {
 		zap.String("device_id", deviceID),
 	)
 
+	// 익명 세션은 요청 바디의 디바이스 ID로 기록
+	client.DeviceID = deviceID
+
 	// 디바이스 ID로 기존 익명 사용자 조회
 	var existingUser model.User
 	err := s.db.Where("device_id = ? AND is_guest = ?", deviceID, true).First(&existingUser).Error
@@ -1178,7 +1083,7 @@ func (s *AuthService) GuestLogin(deviceID string) (*AuthResponse, error) {
 		)
 
 		// 토큰 생성
-		guestToken, err := auth.GenerateGuestToken(existingUser.ID, s.cfg.JWTSecretKey, 7) // 7일 만료
+		guestToken, err := s.issueGuestSessionToken(existingUser.ID, client)
 		if err != nil {
 			s.logger.Error("Failed to generate guest token",
 				zap.Error(err),
@@ -1262,7 +1167,7 @@ func (s *AuthService) GuestLogin(deviceID string) (*AuthResponse, error) {
 	}
 
 	// 토큰 생성
-	guestToken, err := auth.GenerateGuestToken(newUser.ID, s.cfg.JWTSecretKey, 7) // 7일 만료
+	guestToken, err := s.issueGuestSessionToken(newUser.ID, client)
 	if err != nil {
 		s.logger.Error("Failed to generate guest token",
 			zap.Error(err),
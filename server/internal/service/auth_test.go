@@ -0,0 +1,372 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// setupAuthTestDB 인증 테스트용 SQLite DB 생성
+func setupAuthTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&model.User{}, &model.RefreshToken{}, &model.UserIdentity{}, &model.EmailVerification{}, &model.LoginAttempt{}, &model.Session{}, &model.PasskeyCredential{}, &model.AuthChallenge{}, &model.AccountDeletion{}, &model.DataExport{}, &model.EmailSendLog{})
+	require.NoError(t, err)
+
+	return db
+}
+
+// setupAuthService 인증 테스트용 서비스 생성
+func setupAuthService(t *testing.T) (*AuthService, *gorm.DB) {
+	db := setupAuthTestDB(t)
+	cfg := &config.Config{
+		JWTSecretKey:              "test-secret-key",
+		JWTAccessTokenExpireMin:   15,
+		JWTRefreshTokenExpireDays: 7,
+
+		LoginMaxFailuresPerAccount: 5,
+		LoginMaxFailuresPerIP:      20,
+		LoginFailureWindowMin:      15,
+		LoginLockoutMin:            15,
+
+		AccountDeletionGraceDays: 30,
+
+		EmailCodeMaxAttempts:        5,
+		EmailCodeResendCooldownSec:  60,
+		EmailCodeDailyLimitPerEmail: 10,
+		EmailCodeDailyLimitPerIP:    50,
+	}
+	revoker := NewTokenRevoker(db, nil, setupTestLogger())
+	throttle := NewLoginThrottle(db, nil, cfg, setupTestLogger(), nil)
+	challenges := NewChallengeStore(db, nil, setupTestLogger())
+	svc, err := NewAuthService(db, cfg, setupTestLogger(), nil, revoker, auth.NewHMACKeySet(cfg.JWTSecretKey), throttle, challenges)
+	require.NoError(t, err)
+	return svc, db
+}
+
+// createVerifiedEmail 코드 확인을 마친 이메일 인증 기록 생성 (토큰은 해시로 저장)
+func createVerifiedEmail(t *testing.T, svc *AuthService, db *gorm.DB, email, token string, purpose model.EmailVerificationPurpose) {
+	hashed := svc.hashEmailSecret(purpose, email, token)
+	require.NoError(t, db.Create(&model.EmailVerification{
+		Email:             email,
+		Purpose:           purpose,
+		VerificationToken: &hashed,
+		IsVerified:        true,
+		ExpiresAt:         time.Now().Add(emailTokenTTL),
+	}).Error)
+}
+
+// createTestUser 테스트용 이메일 사용자 생성
+func createTestUser(t *testing.T, db *gorm.DB, email string) *model.User {
+	user := &model.User{
+		Username:    email + "_email",
+		Email:       email,
+		LoginMethod: model.LoginMethodEmail,
+		IsActive:    true,
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func TestAuthService_RefreshToken_Rotation(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "rotate@example.com")
+
+	_, refreshToken, _, err := svc.issueTokenPair(user.ID, "")
+	require.NoError(t, err)
+
+	t.Run("회전 시 새 토큰 발급 및 기존 토큰 폐기", func(t *testing.T) {
+		response, err := svc.RefreshToken(refreshToken, ClientInfo{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.AccessToken)
+		assert.NotEqual(t, refreshToken, response.RefreshToken)
+
+		oldClaims, err := auth.ValidateRefreshToken(refreshToken, svc.keys)
+		require.NoError(t, err)
+		newClaims, err := auth.ValidateRefreshToken(response.RefreshToken, svc.keys)
+		require.NoError(t, err)
+
+		var oldRecord, newRecord model.RefreshToken
+		require.NoError(t, db.First(&oldRecord, "id = ?", oldClaims.ID).Error)
+		require.NoError(t, db.First(&newRecord, "id = ?", newClaims.ID).Error)
+
+		assert.True(t, oldRecord.IsRevoked())
+		require.NotNil(t, oldRecord.ReplacedBy)
+		assert.Equal(t, newRecord.ID, *oldRecord.ReplacedBy)
+		assert.Equal(t, oldRecord.FamilyID, newRecord.FamilyID)
+		assert.False(t, newRecord.IsRevoked())
+	})
+}
+
+func TestAuthService_RefreshToken_ReuseDetection(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "reuse@example.com")
+
+	_, firstToken, _, err := svc.issueTokenPair(user.ID, "")
+	require.NoError(t, err)
+
+	rotated, err := svc.RefreshToken(firstToken, ClientInfo{})
+	require.NoError(t, err)
+
+	t.Run("회전된 토큰 재사용 시 거부", func(t *testing.T) {
+		_, err := svc.RefreshToken(firstToken, ClientInfo{})
+		assert.Error(t, err)
+	})
+
+	t.Run("재사용 탐지 후 패밀리 전체 폐기", func(t *testing.T) {
+		_, err := svc.RefreshToken(rotated.RefreshToken, ClientInfo{})
+		assert.Error(t, err)
+
+		var active int64
+		db.Model(&model.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", user.ID).Count(&active)
+		assert.Equal(t, int64(0), active)
+	})
+}
+
+func TestAuthService_RefreshToken_Unknown(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "unknown@example.com")
+
+	// 저장소에 없는 jti로 서명된 토큰
+	token, err := auth.GenerateRefreshToken(user.ID, "not-stored", "", svc.keys, 7)
+	require.NoError(t, err)
+
+	_, err = svc.RefreshToken(token, ClientInfo{})
+	assert.Error(t, err)
+}
+
+func TestAuthService_RefreshToken_Legacy(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "legacy-refresh@example.com")
+
+	// 저장소 도입 이전 형식: jti/세션 없는 토큰
+	legacyToken, err := auth.GenerateRefreshToken(user.ID, "", "", svc.keys, 7)
+	require.NoError(t, err)
+
+	t.Run("첫 사용은 새 토큰 패밀리로 전환", func(t *testing.T) {
+		response, err := svc.RefreshToken(legacyToken, ClientInfo{})
+		require.NoError(t, err)
+		claims, err := auth.ValidateRefreshToken(response.RefreshToken, svc.keys)
+		require.NoError(t, err)
+		assert.NotEmpty(t, claims.ID)
+	})
+
+	t.Run("같은 레거시 토큰 재사용 거부", func(t *testing.T) {
+		_, err := svc.RefreshToken(legacyToken, ClientInfo{})
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "logout@example.com")
+
+	accessToken, refreshToken, _, err := svc.issueTokenPair(user.ID, "")
+	require.NoError(t, err)
+	otherAccess, otherRefresh, _, err := svc.issueTokenPair(user.ID, "")
+	require.NoError(t, err)
+
+	claims, err := auth.ValidateAccessToken(accessToken, svc.keys, svc.revoker)
+	require.NoError(t, err)
+
+	// 초 단위 iat 경계를 넘겨 이전 발급 토큰과 구분
+	time.Sleep(time.Second)
+	require.NoError(t, svc.Logout(user.ID, claims.ID, claims.SessionID, claims.ExpiresAt.Time))
+
+	t.Run("로그아웃한 세션의 토큰 거부", func(t *testing.T) {
+		_, err := auth.ValidateAccessToken(accessToken, svc.keys, svc.revoker)
+		assert.ErrorIs(t, err, auth.ErrTokenRevoked)
+
+		_, err = svc.RefreshToken(refreshToken, ClientInfo{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Redis 없으면 다른 세션도 함께 폐기", func(t *testing.T) {
+		_, err := auth.ValidateAccessToken(otherAccess, svc.keys, svc.revoker)
+		assert.ErrorIs(t, err, auth.ErrTokenRevoked)
+
+		_, err = svc.RefreshToken(otherRefresh, ClientInfo{})
+		assert.Error(t, err)
+	})
+
+	t.Run("로그아웃 이후 재로그인 토큰은 유효", func(t *testing.T) {
+		newAccess, _, _, err := svc.issueTokenPair(user.ID, "")
+		require.NoError(t, err)
+
+		_, err = auth.ValidateAccessToken(newAccess, svc.keys, svc.revoker)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAuthService_DeleteMe_RevokesTokens(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "delete@example.com")
+
+	accessToken, refreshToken, _, err := svc.issueTokenPair(user.ID, "")
+	require.NoError(t, err)
+
+	_, err = svc.DeleteMe(user.ID, nil)
+	require.NoError(t, err)
+
+	_, err = auth.ValidateAccessToken(accessToken, svc.keys, svc.revoker)
+	assert.ErrorIs(t, err, auth.ErrTokenRevoked)
+
+	_, err = svc.RefreshToken(refreshToken, ClientInfo{})
+	assert.Error(t, err)
+}
+
+func TestTokenRevoker_InactiveUser(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "inactive@example.com")
+
+	accessToken, _, _, err := svc.issueTokenPair(user.ID, "")
+	require.NoError(t, err)
+
+	require.NoError(t, db.Model(user).Update("is_active", false).Error)
+
+	_, err = auth.ValidateAccessToken(accessToken, svc.keys, svc.revoker)
+	assert.ErrorIs(t, err, auth.ErrTokenRevoked)
+}
+
+// createSketchTables 스케치/추천 테이블 생성 (SQLite에서 UUID 기본값 미지원으로 수동 생성)
+func createSketchTables(t *testing.T, db *gorm.DB) {
+	require.NoError(t, db.Exec(`CREATE TABLE IF NOT EXISTS sketches (
+		id TEXT PRIMARY KEY,
+		device_id TEXT NOT NULL,
+		user_id INTEGER,
+		image_path TEXT NOT NULL,
+		input_text TEXT,
+		created_at DATETIME,
+		deleted_at DATETIME,
+		analysis_result TEXT
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE IF NOT EXISTS recommendations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		sketch_id TEXT NOT NULL,
+		menu_id INTEGER NOT NULL,
+		reason TEXT NOT NULL,
+		rank INTEGER DEFAULT 1,
+		created_at DATETIME
+	)`).Error)
+}
+
+func TestAuthService_UpgradeGuest(t *testing.T) {
+	svc, db := setupAuthService(t)
+	createSketchTables(t, db)
+
+	guestResp, err := svc.GuestLogin("device-upgrade", ClientInfo{})
+	require.NoError(t, err)
+	guestID := guestResp.User.ID
+	member := createTestUser(t, db, "member@example.com")
+
+	// 익명 사용자 소유 스케치, 로그인 없이 같은 기기에서 만든 스케치, 다른 기기 스케치
+	owned := model.Sketch{ID: uuid.New(), DeviceID: "device-upgrade", UserID: &guestID, ImagePath: "a.png"}
+	anonymous := model.Sketch{ID: uuid.New(), DeviceID: "device-upgrade", ImagePath: "b.png"}
+	other := model.Sketch{ID: uuid.New(), DeviceID: "other-device", ImagePath: "c.png"}
+	for _, sketch := range []*model.Sketch{&owned, &anonymous, &other} {
+		require.NoError(t, db.Create(sketch).Error)
+	}
+	require.NoError(t, db.Create(&model.Recommendation{SketchID: owned.ID, MenuID: 1, Reason: "r", Rank: 1}).Error)
+	require.NoError(t, db.Create(&model.Recommendation{SketchID: anonymous.ID, MenuID: 2, Reason: "r", Rank: 1}).Error)
+
+	result, err := svc.UpgradeGuest(guestResp.AccessToken, member.ID, "email")
+	require.NoError(t, err)
+
+	t.Run("스케치와 추천 기록 이관", func(t *testing.T) {
+		assert.Equal(t, guestID, result.GuestUserID)
+		assert.Equal(t, int64(2), result.MergedSketches)
+		assert.Equal(t, int64(2), result.MergedRecommendations)
+
+		var count int64
+		db.Model(&model.Sketch{}).Where("user_id = ?", member.ID).Count(&count)
+		assert.Equal(t, int64(2), count)
+
+		var untouched model.Sketch
+		require.NoError(t, db.First(&untouched, "id = ?", other.ID).Error)
+		assert.Nil(t, untouched.UserID)
+	})
+
+	t.Run("익명 계정 정리 및 토큰 폐기", func(t *testing.T) {
+		var guest model.User
+		require.NoError(t, db.Unscoped().First(&guest, guestID).Error)
+		assert.True(t, guest.DeletedAt.Valid)
+		assert.Nil(t, guest.DeviceID)
+
+		_, err := auth.ValidateAccessToken(guestResp.AccessToken, svc.keys, svc.revoker)
+		assert.ErrorIs(t, err, auth.ErrTokenRevoked)
+	})
+
+	t.Run("같은 기기에서 다시 익명 로그인 가능", func(t *testing.T) {
+		_, err := svc.GuestLogin("device-upgrade", ClientInfo{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("정회원 토큰으로는 전환 불가", func(t *testing.T) {
+		memberToken, _, _, err := svc.issueTokenPair(member.ID, "")
+		require.NoError(t, err)
+
+		_, err = svc.UpgradeGuest(memberToken, member.ID, "email")
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthService_EmailLogin_UpgradesPasswordHash(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	t.Run("이전 bcrypt 해시는 로그인 시 pbkdf2_sha256으로 변경", func(t *testing.T) {
+		user := createTestUser(t, db, "legacy@example.com")
+		legacy, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+		require.NoError(t, err)
+		require.NoError(t, db.Model(user).Update("password", string(legacy)).Error)
+
+		_, err = svc.EmailLogin(&LoginRequest{Email: "legacy@example.com", Password: "password123"})
+		require.NoError(t, err)
+
+		var updated model.User
+		require.NoError(t, db.First(&updated, user.ID).Error)
+		assert.True(t, strings.HasPrefix(updated.Password, "pbkdf2_sha256$"))
+
+		_, err = svc.EmailLogin(&LoginRequest{Email: "legacy@example.com", Password: "password123"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Django admin에서 만든 해시로 로그인", func(t *testing.T) {
+		user := createTestUser(t, db, "staff@example.com")
+		django := "pbkdf2_sha256$1000000$seasalt$r1uLUxoxpP2Ued/qxvmje7UH9PUJBkRrvf9gGPL7Cps="
+		require.NoError(t, db.Model(user).Update("password", django).Error)
+
+		resp, err := svc.EmailLogin(&LoginRequest{Email: "staff@example.com", Password: "lètmein"})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, resp.User.ID)
+
+		var updated model.User
+		require.NoError(t, db.First(&updated, user.ID).Error)
+		assert.Equal(t, django, updated.Password)
+	})
+
+	t.Run("비밀번호가 틀리면 해시 유지", func(t *testing.T) {
+		user := createTestUser(t, db, "wrong@example.com")
+		legacy, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+		require.NoError(t, err)
+		require.NoError(t, db.Model(user).Update("password", string(legacy)).Error)
+
+		_, err = svc.EmailLogin(&LoginRequest{Email: "wrong@example.com", Password: "nope"})
+		assert.Error(t, err)
+
+		var updated model.User
+		require.NoError(t, db.First(&updated, user.ID).Error)
+		assert.Equal(t, string(legacy), updated.Password)
+	})
+}
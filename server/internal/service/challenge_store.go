@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// authChallengeKeyPrefix 일회용 challenge Redis 키 prefix
+const authChallengeKeyPrefix = "auth:challenge:"
+
+// ErrChallengeNotFound 만료되었거나 이미 사용된 challenge
+var ErrChallengeNotFound = errors.New("인증 요청이 만료되었습니다. 다시 시도해 주세요")
+
+// ChallengeStore 일회용 인증 challenge 저장소 (passkey 등록/로그인 등)
+// Redis가 있으면 TTL 키로, 없거나 오류 시 DB(auth_challenges)에 저장한다.
+// Consume은 한 번만 성공하므로 같은 challenge로 두 번 인증할 수 없다.
+type ChallengeStore struct {
+	db     *gorm.DB
+	rdb    *redis.Client
+	logger *zap.Logger
+}
+
+// NewChallengeStore 새 challenge 저장소 생성 (rdb는 nil 가능)
+func NewChallengeStore(db *gorm.DB, rdb *redis.Client, logger *zap.Logger) *ChallengeStore {
+	return &ChallengeStore{
+		db:     db,
+		rdb:    rdb,
+		logger: logger,
+	}
+}
+
+// Save challenge 저장 (payload는 완료 시 확인할 값, 예: 사용자 ID)
+func (s *ChallengeStore) Save(purpose, challenge, payload string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	key := purpose + ":" + challenge
+
+	if s.rdb != nil {
+		err := s.rdb.Set(ctx, authChallengeKeyPrefix+key, payload, ttl).Err()
+		if err == nil {
+			return nil
+		}
+		s.logger.Warn("Challenge save to Redis failed, falling back to database",
+			zap.Error(err),
+			zap.String("purpose", purpose),
+		)
+	}
+
+	now := time.Now()
+
+	// 만료된 challenge 정리
+	if err := s.db.WithContext(ctx).
+		Where("expires_at < ?", now).
+		Delete(&model.AuthChallenge{}).Error; err != nil {
+		s.logger.Warn("Failed to clean up expired challenges",
+			zap.Error(err),
+		)
+	}
+
+	record := model.AuthChallenge{
+		Key:       key,
+		Payload:   payload,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.db.WithContext(ctx).Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to save challenge: %w", err)
+	}
+	return nil
+}
+
+// Consume challenge 확인 후 삭제 (만료되었거나 이미 사용된 경우 ErrChallengeNotFound)
+func (s *ChallengeStore) Consume(purpose, challenge string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	key := purpose + ":" + challenge
+
+	if s.rdb != nil {
+		payload, err := s.rdb.GetDel(ctx, authChallengeKeyPrefix+key).Result()
+		if err == nil {
+			return payload, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			s.logger.Warn("Challenge lookup in Redis failed, falling back to database",
+				zap.Error(err),
+				zap.String("purpose", purpose),
+			)
+		}
+		// Redis 장애 중 DB에 저장된 challenge일 수 있으므로 DB도 확인
+	}
+
+	var record model.AuthChallenge
+	if err := s.db.WithContext(ctx).Where("key = ?", key).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrChallengeNotFound
+		}
+		return "", fmt.Errorf("failed to find challenge: %w", err)
+	}
+
+	// 동시 요청 중 하나만 삭제에 성공
+	result := s.db.WithContext(ctx).Where("key = ?", key).Delete(&model.AuthChallenge{})
+	if result.Error != nil {
+		return "", fmt.Errorf("failed to consume challenge: %w", result.Error)
+	}
+	if result.RowsAffected == 0 || time.Now().After(record.ExpiresAt) {
+		return "", ErrChallengeNotFound
+	}
+
+	return record.Payload, nil
+}
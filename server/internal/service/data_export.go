@@ -0,0 +1,583 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// DataExportSchemaVersion 내보내기 JSON 스키마 버전 (docs/DATA_EXPORT.md, 필드 의미가 바뀌면 증가)
+const DataExportSchemaVersion = 1
+
+const (
+	// dataExportTimeout ZIP 생성 최대 시간 (이 시간이 지나도 끝나지 않은 요청은 실패 처리)
+	dataExportTimeout = 10 * time.Minute
+	// dataExportImageDir ZIP 안의 스케치 이미지 디렉토리
+	dataExportImageDir = "images"
+)
+
+var (
+	// ErrDataExportNotFound 내보내기 요청이 없거나 다운로드 토큰이 일치하지 않음
+	ErrDataExportNotFound = errors.New("내보내기 요청을 찾을 수 없습니다")
+	// ErrDataExportInProgress 이미 생성 중인 내보내기 요청이 있음
+	ErrDataExportInProgress = errors.New("이미 생성 중인 내보내기 요청이 있습니다")
+	// ErrDataExportCooldown 최근 완료된 내보내기가 있어 재요청 대기 중
+	ErrDataExportCooldown = errors.New("최근에 내보낸 데이터가 있습니다. 잠시 후 다시 요청해 주세요")
+	// ErrDataExportNotReady 아직 ZIP 생성이 끝나지 않음 (또는 실패)
+	ErrDataExportNotReady = errors.New("내보내기 파일이 아직 준비되지 않았습니다")
+	// ErrDataExportExpired 다운로드 링크 만료
+	ErrDataExportExpired = errors.New("다운로드 링크가 만료되었습니다")
+)
+
+// DataExportService 개인 데이터 내보내기 서비스
+// 프로필, 스케치(분석 결과 포함), 원본 스케치 이미지, 추천 기록을 ZIP으로 만들어 기한이 있는 링크로 제공
+type DataExportService struct {
+	db         *gorm.DB
+	sketches   *SketchService
+	exportPath string
+	linkTTL    time.Duration
+	cooldown   time.Duration // 완료된 내보내기 이후 재요청 대기 시간 (0이면 제한 없음)
+	logger     *zap.Logger
+}
+
+// NewDataExportService 새 데이터 내보내기 서비스 생성
+func NewDataExportService(db *gorm.DB, sketches *SketchService, cfg *config.Config, logger *zap.Logger) *DataExportService {
+	exportPath := cfg.DataExportPath
+	if exportPath == "" {
+		exportPath = "./exports"
+	}
+
+	// 내보내기 디렉토리 생성 (개인 정보가 담기므로 소유자만 접근)
+	os.MkdirAll(exportPath, 0700)
+
+	linkTTL := time.Duration(cfg.DataExportLinkTTLHours) * time.Hour
+	if linkTTL <= 0 {
+		linkTTL = 24 * time.Hour
+	}
+
+	return &DataExportService{
+		db:         db,
+		sketches:   sketches,
+		exportPath: exportPath,
+		linkTTL:    linkTTL,
+		cooldown:   time.Duration(cfg.DataExportCooldownHours) * time.Hour,
+		logger:     logger,
+	}
+}
+
+// DataExportTicket 내보내기 요청 결과 (다운로드 토큰은 요청 시 한 번만 반환)
+type DataExportTicket struct {
+	Export *model.DataExport
+	Token  string
+}
+
+// ExportManifest 내보내기 ZIP의 manifest.json
+type ExportManifest struct {
+	SchemaVersion       int       `json:"schema_version"`
+	ExportedAt          time.Time `json:"exported_at"`
+	UserID              uint      `json:"user_id"`
+	SketchCount         int       `json:"sketch_count"`
+	RecommendationCount int       `json:"recommendation_count"`
+	ImageCount          int       `json:"image_count"`
+}
+
+// ExportProfile 내보내기 ZIP의 profile.json
+type ExportProfile struct {
+	ID          uint             `json:"id"`
+	Username    string           `json:"username"`
+	Email       string           `json:"email"`
+	FirstName   string           `json:"first_name"`
+	LastName    string           `json:"last_name"`
+	LoginMethod string           `json:"login_method"`
+	DateJoined  time.Time        `json:"date_joined"`
+	LastLogin   *time.Time       `json:"last_login"`
+	MFAEnabled  bool             `json:"mfa_enabled"`
+	Identities  []ExportIdentity `json:"identities"`
+}
+
+// ExportIdentity 계정에 연결된 로그인 수단
+type ExportIdentity struct {
+	Provider string    `json:"provider"`
+	Email    string    `json:"email"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+// ExportSketch 내보내기 ZIP의 sketches.json 항목
+type ExportSketch struct {
+	ID        uuid.UUID       `json:"id"`
+	DeviceID  string          `json:"device_id"`
+	InputText string          `json:"input_text"`
+	Image     string          `json:"image"`    // ZIP 안의 이미지 경로 (원본 파일이 없으면 빈 문자열)
+	Analysis  json.RawMessage `json:"analysis"` // LLM 분석 결과 원본 JSON (없으면 null)
+	CreatedAt time.Time       `json:"created_at"`
+	DeletedAt *time.Time      `json:"deleted_at"` // 히스토리에서 삭제한 스케치
+}
+
+// ExportRecommendation 내보내기 ZIP의 recommendations.json 항목
+type ExportRecommendation struct {
+	SketchID  uuid.UUID `json:"sketch_id"`
+	Rank      int       `json:"rank"`
+	MenuID    uint      `json:"menu_id"`
+	MenuName  string    `json:"menu_name"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RequestExport 내보내기 요청 생성 후 백그라운드에서 ZIP 생성
+// 동시 요청이 모두 확인을 통과하지 않도록 사용자 행을 잠근 트랜잭션 안에서 확인 후 생성한다.
+func (s *DataExportService) RequestExport(userID uint) (*DataExportTicket, error) {
+	token, err := generateDownloadToken()
+	if err != nil {
+		return nil, err
+	}
+
+	export := &model.DataExport{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Status:    model.DataExportStatusPending,
+		TokenHash: hashDownloadToken(token),
+		ExpiresAt: time.Now().Add(s.linkTTL),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error; err != nil {
+			return fmt.Errorf("failed to lock user: %w", err)
+		}
+
+		var inProgress int64
+		if err := tx.Model(&model.DataExport{}).
+			Where("user_id = ? AND status IN ? AND created_at > ?", userID,
+				[]model.DataExportStatus{model.DataExportStatusPending, model.DataExportStatusProcessing},
+				time.Now().Add(-dataExportTimeout)).
+			Count(&inProgress).Error; err != nil {
+			return fmt.Errorf("failed to find export in progress: %w", err)
+		}
+		if inProgress > 0 {
+			return ErrDataExportInProgress
+		}
+
+		if s.cooldown > 0 {
+			var recent int64
+			if err := tx.Model(&model.DataExport{}).
+				Where("user_id = ? AND status = ? AND completed_at > ?", userID,
+					model.DataExportStatusCompleted, time.Now().Add(-s.cooldown)).
+				Count(&recent).Error; err != nil {
+				return fmt.Errorf("failed to find recent export: %w", err)
+			}
+			if recent > 0 {
+				return ErrDataExportCooldown
+			}
+		}
+
+		if err := tx.Create(export).Error; err != nil {
+			return fmt.Errorf("failed to create export: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Data export requested",
+		zap.String("export_id", export.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dataExportTimeout)
+		defer cancel()
+		s.Build(ctx, export)
+	}()
+
+	return &DataExportTicket{Export: export, Token: token}, nil
+}
+
+// GetExport 사용자의 내보내기 요청 상태 조회
+func (s *DataExportService) GetExport(userID uint, exportID string) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := s.db.Where("id = ? AND user_id = ?", exportID, userID).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to find export: %w", err)
+	}
+	return &export, nil
+}
+
+// OpenDownload 다운로드 토큰 확인 후 ZIP 파일 경로 반환
+func (s *DataExportService) OpenDownload(exportID, token string) (*model.DataExport, string, error) {
+	var export model.DataExport
+	if err := s.db.Where("id = ?", exportID).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrDataExportNotFound
+		}
+		return nil, "", fmt.Errorf("failed to find export: %w", err)
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(hashDownloadToken(token)), []byte(export.TokenHash)) != 1 {
+		return nil, "", ErrDataExportNotFound
+	}
+
+	// 탈퇴한 사용자의 링크는 사용할 수 없음
+	var count int64
+	if err := s.db.Model(&model.User{}).Where("id = ?", export.UserID).Count(&count).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to find user: %w", err)
+	}
+	if count == 0 {
+		return nil, "", ErrDataExportNotFound
+	}
+
+	if export.Status == model.DataExportStatusExpired || !time.Now().Before(export.ExpiresAt) {
+		return nil, "", ErrDataExportExpired
+	}
+	if !export.IsDownloadable() {
+		return nil, "", ErrDataExportNotReady
+	}
+	return &export, filepath.Join(s.exportPath, export.FilePath), nil
+}
+
+// Build ZIP 생성 후 상태 갱신 (실패 시 failed로 기록)
+func (s *DataExportService) Build(ctx context.Context, export *model.DataExport) {
+	start := time.Now()
+
+	if err := s.db.WithContext(ctx).Model(export).Update("status", model.DataExportStatusProcessing).Error; err != nil {
+		s.logger.Error("Failed to start data export",
+			zap.Error(err),
+			zap.String("export_id", export.ID),
+		)
+		return
+	}
+
+	filename := export.ID + ".zip"
+	size, err := s.writeArchive(ctx, export.UserID, filepath.Join(s.exportPath, filename))
+	if err != nil {
+		s.logger.Error("Data export failed",
+			zap.Error(err),
+			zap.String("export_id", export.ID),
+			zap.Uint("user_id", export.UserID),
+		)
+		// 요청 컨텍스트가 끝났어도 실패 상태는 기록
+		s.db.Model(export).Updates(map[string]interface{}{
+			"status": model.DataExportStatusFailed,
+			"error":  truncate(err.Error(), 1000),
+		})
+		return
+	}
+
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Model(export).Updates(map[string]interface{}{
+		"status":       model.DataExportStatusCompleted,
+		"file_path":    filename,
+		"file_size":    size,
+		"completed_at": &now,
+	}).Error; err != nil {
+		s.logger.Error("Failed to complete data export",
+			zap.Error(err),
+			zap.String("export_id", export.ID),
+		)
+		return
+	}
+
+	s.logger.Info("Data export completed",
+		zap.String("export_id", export.ID),
+		zap.Uint("user_id", export.UserID),
+		zap.Int64("file_size", size),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+// writeArchive 사용자 데이터 ZIP 파일 작성 (임시 파일에 쓴 뒤 이름 변경), 파일 크기 반환
+func (s *DataExportService) writeArchive(ctx context.Context, userID uint, path string) (int64, error) {
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(tmpPath)
+
+	zw := zip.NewWriter(file)
+	writeErr := s.writeEntries(ctx, zw, userID)
+	if err := zw.Close(); err != nil && writeErr == nil {
+		writeErr = fmt.Errorf("failed to finish zip: %w", err)
+	}
+	if err := file.Close(); err != nil && writeErr == nil {
+		writeErr = fmt.Errorf("failed to close export file: %w", err)
+	}
+	if writeErr != nil {
+		return 0, writeErr
+	}
+
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat export file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, fmt.Errorf("failed to move export file: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// writeEntries manifest.json, profile.json, sketches.json, recommendations.json, images/ 작성
+func (s *DataExportService) writeEntries(ctx context.Context, zw *zip.Writer, userID uint) error {
+	db := s.db.WithContext(ctx)
+
+	var user model.User
+	if err := db.First(&user, userID).Error; err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	var identities []model.UserIdentity
+	if err := db.Where("user_id = ?", userID).Order("linked_at ASC").Find(&identities).Error; err != nil {
+		return fmt.Errorf("failed to list identities: %w", err)
+	}
+
+	profile := ExportProfile{
+		ID:          user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		LoginMethod: string(user.LoginMethod),
+		DateJoined:  user.DateJoined,
+		LastLogin:   user.LastLogin,
+		MFAEnabled:  user.MFAEnabled(),
+		Identities:  make([]ExportIdentity, 0, len(identities)),
+	}
+	for _, identity := range identities {
+		profile.Identities = append(profile.Identities, ExportIdentity{
+			Provider: string(identity.Provider),
+			Email:    identity.Email,
+			LinkedAt: identity.LinkedAt,
+		})
+	}
+
+	// 히스토리에서 삭제한 스케치도 보관 중인 데이터이므로 포함
+	var sketches []model.Sketch
+	if err := db.Unscoped().Where("user_id = ?", userID).Order("created_at ASC").Find(&sketches).Error; err != nil {
+		return fmt.Errorf("failed to list sketches: %w", err)
+	}
+
+	exported := make([]ExportSketch, 0, len(sketches))
+	images := 0
+	for i := range sketches {
+		sketch := &sketches[i]
+		item := ExportSketch{
+			ID:        sketch.ID,
+			DeviceID:  sketch.DeviceID,
+			InputText: sketch.InputText,
+			Analysis:  json.RawMessage("null"),
+			CreatedAt: sketch.CreatedAt,
+		}
+		if len(sketch.AnalysisResult) > 0 {
+			item.Analysis = json.RawMessage(sketch.AnalysisResult)
+		}
+		if sketch.DeletedAt.Valid {
+			deletedAt := sketch.DeletedAt.Time
+			item.DeletedAt = &deletedAt
+		}
+
+		if sketch.ImagePath != "" {
+			name := dataExportImageDir + "/" + sketch.ID.String() + filepath.Ext(sketch.ImagePath)
+			ok, err := s.copyImage(zw, filepath.Join(s.sketches.uploadPath, sketch.ImagePath), name)
+			if err != nil {
+				return err
+			}
+			if ok {
+				item.Image = name
+				images++
+			}
+		}
+		exported = append(exported, item)
+	}
+
+	recommendations := []ExportRecommendation{}
+	if err := db.Table("recommendations").
+		Select("recommendations.sketch_id, recommendations.rank, recommendations.menu_id, COALESCE(menus.name, '') AS menu_name, recommendations.reason, recommendations.created_at").
+		Joins("JOIN sketches ON sketches.id = recommendations.sketch_id").
+		Joins("LEFT JOIN menus ON menus.id = recommendations.menu_id").
+		Where("sketches.user_id = ?", userID).
+		Order("recommendations.created_at ASC, recommendations.rank ASC").
+		Scan(&recommendations).Error; err != nil {
+		return fmt.Errorf("failed to list recommendations: %w", err)
+	}
+
+	manifest := ExportManifest{
+		SchemaVersion:       DataExportSchemaVersion,
+		ExportedAt:          time.Now(),
+		UserID:              userID,
+		SketchCount:         len(exported),
+		RecommendationCount: len(recommendations),
+		ImageCount:          images,
+	}
+
+	for _, entry := range []struct {
+		name string
+		data interface{}
+	}{
+		{"manifest.json", manifest},
+		{"profile.json", profile},
+		{"sketches.json", exported},
+		{"recommendations.json", recommendations},
+	} {
+		if err := writeJSONEntry(zw, entry.name, entry.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyImage 업로드 이미지를 ZIP에 추가 (이미 압축된 형식이므로 무압축 저장), 원본이 없으면 false
+func (s *DataExportService) copyImage(zw *zip.Writer, src, name string) (bool, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.logger.Warn("Sketch image missing for export",
+				zap.String("image_path", src),
+			)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to open sketch image: %w", err)
+	}
+	defer file.Close()
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: time.Now()})
+	if err != nil {
+		return false, fmt.Errorf("failed to add image to zip: %w", err)
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		return false, fmt.Errorf("failed to copy sketch image: %w", err)
+	}
+	return true, nil
+}
+
+// writeJSONEntry JSON 파일을 ZIP에 추가 (사람이 읽을 수 있도록 들여쓰기)
+func writeJSONEntry(zw *zip.Writer, name string, data interface{}) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to zip: %w", name, err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Run 주기적으로 만료된 내보내기 파일 정리 (ctx 취소 시 종료)
+func (s *DataExportService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.CleanupExpired(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error("Data export cleanup failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CleanupExpired 링크가 만료된 ZIP 파일 삭제, 서버 재시작 등으로 멈춘 요청은 실패 처리, 정리한 요청 수 반환
+func (s *DataExportService) CleanupExpired(ctx context.Context) (int, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := db.Model(&model.DataExport{}).
+		Where("status IN ? AND created_at <= ?",
+			[]model.DataExportStatus{model.DataExportStatusPending, model.DataExportStatusProcessing},
+			time.Now().Add(-dataExportTimeout)).
+		Updates(map[string]interface{}{
+			"status": model.DataExportStatusFailed,
+			"error":  "timed out",
+		}).Error; err != nil {
+		return 0, fmt.Errorf("failed to fail stale exports: %w", err)
+	}
+
+	var exports []model.DataExport
+	if err := db.Where("status <> ? AND expires_at <= ?", model.DataExportStatusExpired, time.Now()).
+		Find(&exports).Error; err != nil {
+		return 0, fmt.Errorf("failed to list expired exports: %w", err)
+	}
+
+	for i := range exports {
+		s.removeFile(&exports[i])
+		if err := db.Model(&exports[i]).Updates(map[string]interface{}{
+			"status":    model.DataExportStatusExpired,
+			"file_path": "",
+		}).Error; err != nil {
+			return i, fmt.Errorf("failed to expire export: %w", err)
+		}
+	}
+
+	if len(exports) > 0 {
+		s.logger.Info("Expired data exports cleaned up",
+			zap.Int("count", len(exports)),
+		)
+	}
+	return len(exports), nil
+}
+
+// PurgeUserExports 사용자의 내보내기 파일과 요청 기록 삭제 (탈퇴 계정 정리용)
+func (s *DataExportService) PurgeUserExports(ctx context.Context, userID uint) error {
+	var exports []model.DataExport
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).Find(&exports).Error; err != nil {
+		return fmt.Errorf("failed to list exports: %w", err)
+	}
+	for i := range exports {
+		s.removeFile(&exports[i])
+	}
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.DataExport{}).Error; err != nil {
+		return fmt.Errorf("failed to delete exports: %w", err)
+	}
+	return nil
+}
+
+// removeFile 내보내기 ZIP 파일 삭제 (삭제 실패는 로그만 남김)
+func (s *DataExportService) removeFile(export *model.DataExport) {
+	if export.FilePath == "" {
+		return
+	}
+	if err := os.Remove(filepath.Join(s.exportPath, export.FilePath)); err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("Failed to remove data export file",
+			zap.Error(err),
+			zap.String("export_id", export.ID),
+		)
+	}
+}
+
+// generateDownloadToken 다운로드 링크용 랜덤 토큰 생성
+func generateDownloadToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate download token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashDownloadToken 다운로드 토큰 저장용 해시
+func hashDownloadToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
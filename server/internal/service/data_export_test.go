@@ -0,0 +1,191 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// readZipJSON ZIP 안의 JSON 파일 읽기
+func readZipJSON(t *testing.T, files map[string]*zip.File, name string, v interface{}) {
+	file, ok := files[name]
+	require.True(t, ok, name)
+	r, err := file.Open()
+	require.NoError(t, err)
+	defer r.Close()
+	require.NoError(t, json.NewDecoder(r).Decode(v))
+}
+
+func TestDataExportService_Export(t *testing.T) {
+	_, db := setupAuthService(t)
+	createSketchTables(t, db)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}, &model.MenuImage{}))
+	menus := createTestMenus(t, db)
+
+	uploadPath := t.TempDir()
+	sketches := &SketchService{db: db, uploadPath: uploadPath, logger: setupTestLogger()}
+	exports := &DataExportService{db: db, sketches: sketches, exportPath: t.TempDir(), linkTTL: time.Hour, logger: setupTestLogger()}
+
+	user := createTestUser(t, db, "export@example.com")
+	other := createTestUser(t, db, "other@example.com")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(uploadPath, "sketches"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(uploadPath, "sketches", "a.png"), []byte("png-a"), 0o644))
+	withImage := model.Sketch{
+		ID: uuid.New(), DeviceID: "device-export", UserID: &user.ID, ImagePath: "sketches/a.png", InputText: "배고파",
+		AnalysisResult: datatypes.JSON(`{"emotion":"평온","keywords":["따뜻한"],"mood":"calm"}`),
+	}
+	missingImage := model.Sketch{ID: uuid.New(), DeviceID: "device-export", UserID: &user.ID, ImagePath: "sketches/missing.png"}
+	othersSketch := model.Sketch{ID: uuid.New(), DeviceID: "device-other", UserID: &other.ID, ImagePath: "sketches/a.png"}
+	for _, sketch := range []*model.Sketch{&withImage, &missingImage, &othersSketch} {
+		require.NoError(t, db.Create(sketch).Error)
+	}
+	require.NoError(t, db.Create(&model.Recommendation{SketchID: withImage.ID, MenuID: menus[0].ID, Reason: "따뜻해요", Rank: 1}).Error)
+	require.NoError(t, db.Create(&model.Recommendation{SketchID: othersSketch.ID, MenuID: menus[1].ID, Reason: "r", Rank: 1}).Error)
+
+	ticket, err := exports.RequestExport(user.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, ticket.Token)
+
+	require.Eventually(t, func() bool {
+		export, err := exports.GetExport(user.ID, ticket.Export.ID)
+		return err == nil && !export.IsInProgress()
+	}, 5*time.Second, 20*time.Millisecond)
+
+	t.Run("다른 사용자는 상태 조회 불가", func(t *testing.T) {
+		_, err := exports.GetExport(other.ID, ticket.Export.ID)
+		assert.ErrorIs(t, err, ErrDataExportNotFound)
+	})
+
+	t.Run("잘못된 토큰으로 다운로드 불가", func(t *testing.T) {
+		_, _, err := exports.OpenDownload(ticket.Export.ID, "wrong-token")
+		assert.ErrorIs(t, err, ErrDataExportNotFound)
+		_, _, err = exports.OpenDownload(ticket.Export.ID, "")
+		assert.ErrorIs(t, err, ErrDataExportNotFound)
+	})
+
+	t.Run("ZIP에 프로필, 스케치, 이미지, 추천 기록 포함", func(t *testing.T) {
+		export, path, err := exports.OpenDownload(ticket.Export.ID, ticket.Token)
+		require.NoError(t, err)
+		assert.Equal(t, model.DataExportStatusCompleted, export.Status)
+
+		reader, err := zip.OpenReader(path)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		files := map[string]*zip.File{}
+		for _, file := range reader.File {
+			files[file.Name] = file
+		}
+
+		var manifest ExportManifest
+		readZipJSON(t, files, "manifest.json", &manifest)
+		assert.Equal(t, DataExportSchemaVersion, manifest.SchemaVersion)
+		assert.Equal(t, 2, manifest.SketchCount)
+		assert.Equal(t, 1, manifest.RecommendationCount)
+		assert.Equal(t, 1, manifest.ImageCount)
+
+		var profile ExportProfile
+		readZipJSON(t, files, "profile.json", &profile)
+		assert.Equal(t, "export@example.com", profile.Email)
+
+		var exported []ExportSketch
+		readZipJSON(t, files, "sketches.json", &exported)
+		require.Len(t, exported, 2)
+		byID := map[uuid.UUID]ExportSketch{}
+		for _, sketch := range exported {
+			byID[sketch.ID] = sketch
+		}
+		assert.JSONEq(t, `{"emotion":"평온","keywords":["따뜻한"],"mood":"calm"}`, string(byID[withImage.ID].Analysis))
+		assert.Equal(t, "images/"+withImage.ID.String()+".png", byID[withImage.ID].Image)
+		assert.Empty(t, byID[missingImage.ID].Image)
+
+		var recommendations []ExportRecommendation
+		readZipJSON(t, files, "recommendations.json", &recommendations)
+		require.Len(t, recommendations, 1)
+		assert.Equal(t, menus[0].Name, recommendations[0].MenuName)
+		assert.Equal(t, withImage.ID, recommendations[0].SketchID)
+
+		r, err := files[byID[withImage.ID].Image].Open()
+		require.NoError(t, err)
+		defer r.Close()
+		image, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "png-a", string(image))
+	})
+
+	t.Run("만료된 링크는 정리 후 다운로드 불가", func(t *testing.T) {
+		require.NoError(t, db.Model(&model.DataExport{}).Where("id = ?", ticket.Export.ID).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		cleaned, err := exports.CleanupExpired(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, cleaned)
+
+		_, _, err = exports.OpenDownload(ticket.Export.ID, ticket.Token)
+		assert.ErrorIs(t, err, ErrDataExportExpired)
+
+		files, err := os.ReadDir(exports.exportPath)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
+func TestDataExportService_RequestExport(t *testing.T) {
+	_, db := setupAuthService(t)
+	createSketchTables(t, db)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}, &model.MenuImage{}))
+	sketches := &SketchService{db: db, uploadPath: t.TempDir(), logger: setupTestLogger()}
+	exports := &DataExportService{db: db, sketches: sketches, exportPath: t.TempDir(), linkTTL: time.Hour, cooldown: time.Hour, logger: setupTestLogger()}
+
+	user := createTestUser(t, db, "export-limit@example.com")
+
+	t.Run("생성 중인 요청이 있으면 거부", func(t *testing.T) {
+		pending := model.DataExport{ID: uuid.New().String(), UserID: user.ID, Status: model.DataExportStatusPending, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+		require.NoError(t, db.Create(&pending).Error)
+
+		_, err := exports.RequestExport(user.ID)
+		assert.ErrorIs(t, err, ErrDataExportInProgress)
+
+		var count int64
+		require.NoError(t, db.Model(&model.DataExport{}).Where("user_id = ?", user.ID).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+		require.NoError(t, db.Delete(&pending).Error)
+	})
+
+	t.Run("완료 후 대기 시간 안에는 재요청 거부", func(t *testing.T) {
+		ticket, err := exports.RequestExport(user.ID)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			export, err := exports.GetExport(user.ID, ticket.Export.ID)
+			return err == nil && export.Status == model.DataExportStatusCompleted
+		}, 5*time.Second, 20*time.Millisecond)
+
+		_, err = exports.RequestExport(user.ID)
+		assert.ErrorIs(t, err, ErrDataExportCooldown)
+	})
+
+	t.Run("대기 시간이 지나면 다시 요청 가능", func(t *testing.T) {
+		require.NoError(t, db.Model(&model.DataExport{}).Where("user_id = ?", user.ID).
+			Update("completed_at", time.Now().Add(-2*time.Hour)).Error)
+
+		ticket, err := exports.RequestExport(user.ID)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			export, err := exports.GetExport(user.ID, ticket.Export.ID)
+			return err == nil && !export.IsInProgress()
+		}, 5*time.Second, 20*time.Millisecond)
+	})
+}
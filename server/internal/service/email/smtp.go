@@ -38,7 +38,8 @@ func NewSMTPService(config *SMTPConfig, logger *zap.Logger) *SMTPService {
 // EmailData 이메일 템플릿 데이터
 type EmailData struct {
 	Code    string
-	Purpose string // "verification" 또는 "password_reset"
+	Link    string // 매직 링크 (비어 있으면 인증코드만 표시)
+	Purpose string // "verification", "password_reset" 또는 "magic_link"
 }
 
 // SendVerificationCode 인증코드 발송 (회원가입용)
@@ -81,6 +82,27 @@ func (s *SMTPService) SendPasswordResetCode(to, code string) error {
 	return s.send(to, subject, body)
 }
 
+// SendMagicLink 비밀번호 없는 로그인 링크와 인증코드 발송
+func (s *SMTPService) SendMagicLink(to, code, link string) error {
+	subject := "[오점너] 로그인 링크"
+	data := EmailData{
+		Code:    code,
+		Link:    link,
+		Purpose: "magic_link",
+	}
+
+	body, err := s.renderTemplate(data)
+	if err != nil {
+		s.logger.Error("Failed to render magic link email template",
+			zap.Error(err),
+			zap.String("to", to),
+		)
+		return fmt.Errorf("이메일 템플릿 생성 실패: %w", err)
+	}
+
+	return s.send(to, subject, body)
+}
+
 // send 이메일 발송 (공통)
 func (s *SMTPService) send(to, subject, body string) error {
 	// From 주소는 Gmail 인증 계정과 일치해야 함
@@ -199,6 +221,8 @@ func (s *SMTPService) renderTemplate(data EmailData) (string, error) {
 		tmplContent = verificationEmailTemplate
 	} else if data.Purpose == "password_reset" {
 		tmplContent = passwordResetEmailTemplate
+	} else if data.Purpose == "magic_link" {
+		tmplContent = magicLinkEmailTemplate
 	} else {
 		return "", fmt.Errorf("unknown email purpose: %s", data.Purpose)
 	}
@@ -342,3 +366,79 @@ const passwordResetEmailTemplate = `
 </body>
 </html>
 `
+
+// magicLinkEmailTemplate 비밀번호 없는 로그인 이메일 템플릿
+const magicLinkEmailTemplate = `
+<!DOCTYPE html>
+<html lang="ko">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>오점너 로그인</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Apple SD Gothic Neo', sans-serif; background-color: #f5f5f5;">
+    <table width="100%" cellpadding="0" cellspacing="0" style="background-color: #f5f5f5; padding: 40px 0;">
+        <tr>
+            <td align="center">
+                <table width="600" cellpadding="0" cellspacing="0" style="background-color: #ffffff; border-radius: 12px; box-shadow: 0 2px 8px rgba(0,0,0,0.1);">
+                    <!-- 헤더 -->
+                    <tr>
+                        <td style="padding: 40px 40px 20px 40px; text-align: center;">
+                            <h1 style="margin: 0; color: #1A1C1E; font-size: 28px; font-weight: 700;">오점너</h1>
+                            <p style="margin: 8px 0 0 0; color: #6B7280; font-size: 14px;">AI 기반 메뉴 추천 서비스</p>
+                        </td>
+                    </tr>
+
+                    <!-- 본문 -->
+                    <tr>
+                        <td style="padding: 20px 40px;">
+                            <h2 style="margin: 0 0 16px 0; color: #1A1C1E; font-size: 20px; font-weight: 600;">로그인</h2>
+                            {{if .Link}}
+                            <p style="margin: 0 0 24px 0; color: #4B5563; font-size: 16px; line-height: 1.6;">
+                                아래 버튼을 누르면 비밀번호 없이 오점너에 로그인합니다.
+                            </p>
+
+                            <!-- 로그인 버튼 -->
+                            <div style="text-align: center; margin-bottom: 24px;">
+                                <a href="{{.Link}}" style="display: inline-block; background-color: #1A1C1E; color: #ffffff; font-size: 16px; font-weight: 600; text-decoration: none; padding: 14px 32px; border-radius: 8px;">오점너 로그인</a>
+                            </div>
+
+                            <p style="margin: 0 0 16px 0; color: #4B5563; font-size: 14px; line-height: 1.6;">
+                                버튼이 동작하지 않으면 앱에서 아래 인증코드를 입력해 주세요.
+                            </p>
+                            {{else}}
+                            <p style="margin: 0 0 24px 0; color: #4B5563; font-size: 16px; line-height: 1.6;">
+                                비밀번호 없이 로그인하려면 앱에서 아래 인증코드를 입력해 주세요.
+                            </p>
+                            {{end}}
+
+                            <!-- 인증코드 박스 -->
+                            <div style="background-color: #F3F4F6; border-radius: 8px; padding: 24px; text-align: center; margin-bottom: 24px;">
+                                <p style="margin: 0 0 8px 0; color: #6B7280; font-size: 14px; font-weight: 500;">인증코드</p>
+                                <p style="margin: 0; color: #1A1C1E; font-size: 32px; font-weight: 700; letter-spacing: 4px; font-family: 'Courier New', monospace;">{{.Code}}</p>
+                            </div>
+
+                            <p style="margin: 0 0 8px 0; color: #6B7280; font-size: 14px; line-height: 1.6;">
+                                • 링크와 인증코드는 발송 시점부터 <strong style="color: #1A1C1E;">15분간</strong> 유효하며, 한 번만 사용할 수 있습니다.<br>
+                                • 가입하지 않은 이메일이면 로그인과 함께 새 계정이 만들어집니다.<br>
+                                • 본인이 요청하지 않은 경우, 이 이메일을 무시하셔도 됩니다.
+                            </p>
+                        </td>
+                    </tr>
+
+                    <!-- 푸터 -->
+                    <tr>
+                        <td style="padding: 20px 40px 40px 40px; border-top: 1px solid #E5E7EB;">
+                            <p style="margin: 0; color: #9CA3AF; font-size: 12px; text-align: center;">
+                                © 2025 오점너. All rights reserved.<br>
+                                본 메일은 발신 전용이며, 회신되지 않습니다.
+                            </p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`
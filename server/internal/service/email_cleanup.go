@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// EmailCleanupResult 인증 기록 정리 결과
+type EmailCleanupResult struct {
+	Verifications int64 // 삭제한 인증코드/토큰 기록 수 (회원가입, 비밀번호 재설정)
+	SendLogs      int64 // 삭제한 발송 기록 수
+}
+
+// EmailVerificationCleaner 만료되었거나 더 이상 쓸 수 없는 이메일 인증 기록 정리 작업
+type EmailVerificationCleaner struct {
+	db          *gorm.DB
+	maxAttempts int
+	logger      *zap.Logger
+}
+
+// NewEmailVerificationCleaner 새 이메일 인증 기록 정리 작업 생성
+func NewEmailVerificationCleaner(db *gorm.DB, cfg *config.Config, logger *zap.Logger) *EmailVerificationCleaner {
+	maxAttempts := cfg.EmailCodeMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultEmailCodeMaxAttempts
+	}
+	return &EmailVerificationCleaner{
+		db:          db,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}
+}
+
+// Run 주기적으로 인증 기록 정리 (ctx 취소 시 종료)
+func (c *EmailVerificationCleaner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Cleanup(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			c.logger.Error("Email verification cleanup failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cleanup 만료된 기록, 시도 횟수를 다 쓴 코드, 발송 한도 집계 기간이 지난 발송 기록 삭제
+// 사용한 토큰은 사용 시점에 삭제되고, 확인만 하고 쓰지 않은 토큰은 토큰 만료 후 삭제된다.
+func (c *EmailVerificationCleaner) Cleanup(ctx context.Context) (*EmailCleanupResult, error) {
+	db := c.db.WithContext(ctx)
+	now := time.Now()
+
+	verifications := db.
+		Where("expires_at <= ? OR (is_verified = ? AND attempts >= ?)", now, false, c.maxAttempts).
+		Delete(&model.EmailVerification{})
+	if verifications.Error != nil {
+		return nil, fmt.Errorf("failed to delete email verifications: %w", verifications.Error)
+	}
+
+	sendLogs := db.Where("sent_at <= ?", now.Add(-emailSendQuotaWindow)).Delete(&model.EmailSendLog{})
+	if sendLogs.Error != nil {
+		return nil, fmt.Errorf("failed to delete email send logs: %w", sendLogs.Error)
+	}
+
+	result := &EmailCleanupResult{
+		Verifications: verifications.RowsAffected,
+		SendLogs:      sendLogs.RowsAffected,
+	}
+	if result.Verifications > 0 || result.SendLogs > 0 {
+		c.logger.Info("Email verifications cleaned up",
+			zap.Int64("verifications", result.Verifications),
+			zap.Int64("send_logs", result.SendLogs),
+		)
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+func TestEmailVerificationCleaner_Cleanup(t *testing.T) {
+	svc, db := setupAuthService(t)
+	cleaner := NewEmailVerificationCleaner(db, svc.cfg, setupTestLogger())
+
+	now := time.Now()
+	records := []model.EmailVerification{
+		{Email: "pending@example.com", Purpose: model.EmailPurposeSignup, CodeHash: "a", ExpiresAt: now.Add(10 * time.Minute)},
+		{Email: "expired@example.com", Purpose: model.EmailPurposeSignup, CodeHash: "b", ExpiresAt: now.Add(-time.Minute)},
+		{Email: "locked@example.com", Purpose: model.EmailPurposePasswordReset, CodeHash: "c", Attempts: 5, ExpiresAt: now.Add(time.Hour)},
+		{Email: "reset@example.com", Purpose: model.EmailPurposePasswordReset, IsVerified: true, ExpiresAt: now.Add(-time.Second)},
+	}
+	for i := range records {
+		require.NoError(t, db.Create(&records[i]).Error)
+	}
+	createVerifiedEmail(t, svc, db, "verified@example.com", "token", model.EmailPurposeSignup)
+
+	require.NoError(t, db.Create(&model.EmailSendLog{Email: "old@example.com", SentAt: now.Add(-25 * time.Hour)}).Error)
+	require.NoError(t, db.Create(&model.EmailSendLog{Email: "recent@example.com", SentAt: now.Add(-time.Hour)}).Error)
+
+	t.Run("만료되었거나 시도 횟수를 다 쓴 기록만 삭제", func(t *testing.T) {
+		result, err := cleaner.Cleanup(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), result.Verifications)
+		assert.Equal(t, int64(1), result.SendLogs)
+
+		var emails []string
+		require.NoError(t, db.Model(&model.EmailVerification{}).Order("email").Pluck("email", &emails).Error)
+		assert.Equal(t, []string{"pending@example.com", "verified@example.com"}, emails)
+
+		var count int64
+		db.Model(&model.EmailSendLog{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("정리할 기록이 없으면 0 반환", func(t *testing.T) {
+		result, err := cleaner.Cleanup(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, result.Verifications)
+		assert.Zero(t, result.SendLogs)
+	})
+}
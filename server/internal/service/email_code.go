@@ -0,0 +1,275 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+const (
+	// emailCodeDigits 인증코드 자릿수
+	emailCodeDigits = 6
+	// emailTokenTTL 코드 확인 후 발급한 토큰(회원가입, 비밀번호 재설정)의 유효 시간
+	emailTokenTTL = 30 * time.Minute
+	// emailSendQuotaWindow 발송 한도 집계 기간
+	emailSendQuotaWindow = 24 * time.Hour
+	// defaultEmailCodeMaxAttempts EMAIL_CODE_MAX_ATTEMPTS 미설정 시 코드당 최대 확인 시도 횟수
+	defaultEmailCodeMaxAttempts = 5
+)
+
+var (
+	// ErrEmailCodeInvalid 인증코드가 틀렸거나 만료됨
+	ErrEmailCodeInvalid = errors.New("인증코드가 유효하지 않거나 만료되었습니다")
+	// ErrEmailCodeAttemptsExceeded 코드 확인 시도 횟수 초과 (새 코드 필요)
+	ErrEmailCodeAttemptsExceeded = errors.New("인증 시도 횟수를 초과했습니다. 인증코드를 다시 요청해주세요")
+)
+
+// EmailCodeThrottleError 인증코드 발송 제한 오류 (재발송 대기 또는 일일 한도 초과)
+type EmailCodeThrottleError struct {
+	RetryAfter time.Duration
+	Daily      bool
+}
+
+// Error 사용자 안내 메시지
+func (e *EmailCodeThrottleError) Error() string {
+	if e.Daily {
+		return "인증코드 발송 한도를 초과했습니다. 내일 다시 시도해 주세요"
+	}
+	return fmt.Sprintf("인증코드를 이미 발송했습니다. %d초 후 다시 요청해 주세요", e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds 재시도까지 남은 시간 (초, 올림) - Retry-After 헤더용
+func (e *EmailCodeThrottleError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// newEmailCodeKey 인증코드/토큰 HMAC 키 생성
+// EMAIL_CODE_SECRET이 없으면 JWT secret에서 파생하고 (HS256 서명 사용 시에만, 비대칭 서명 전환 시에는 별도 키 필수),
+// 둘 다 없으면 에러 (파드마다 다른 키를 쓰면 다른 파드에서 발송한 코드가 무효)
+func newEmailCodeKey(cfg *config.Config, logger *zap.Logger) ([]byte, error) {
+	switch {
+	case cfg.EmailCodeSecret != "":
+		return []byte(cfg.EmailCodeSecret), nil
+	case cfg.JWTSecretKey != "":
+		logger.Warn("EMAIL_CODE_SECRET not set, deriving email code key from JWT secret")
+		sum := sha256.Sum256([]byte("email-code:" + cfg.JWTSecretKey))
+		return sum[:], nil
+	}
+	return nil, errors.New("EMAIL_CODE_SECRET (or JWT_SECRET_KEY) is required for email verification codes")
+}
+
+// hashEmailSecret 인증코드/토큰 저장용 HMAC (용도와 이메일을 함께 서명해 다른 흐름에서 재사용 불가)
+func (s *AuthService) hashEmailSecret(purpose model.EmailVerificationPurpose, email, value string) string {
+	mac := hmac.New(sha256.New, s.emailCodeKey)
+	mac.Write([]byte(string(purpose) + "\x00" + email + "\x00" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateEmailCode crypto/rand 기반 6자리 숫자 코드 생성
+func generateEmailCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(math.Pow10(emailCodeDigits))))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate email code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", emailCodeDigits, n.Int64()), nil
+}
+
+// generateEmailToken 코드 확인 후 발급하는 불투명 랜덤 토큰 생성
+func generateEmailToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate email token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// checkEmailSendQuota 재발송 대기 시간과 이메일/IP별 일일 발송 한도 확인
+func (s *AuthService) checkEmailSendQuota(email, ip string, purpose model.EmailVerificationPurpose) error {
+	now := time.Now()
+
+	cooldown := time.Duration(s.cfg.EmailCodeResendCooldownSec) * time.Second
+	var last model.EmailVerification
+	err := s.db.Where("email = ? AND purpose = ?", email, purpose).Order("id DESC").First(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to find email verification: %w", err)
+	}
+	if err == nil && !last.CanResend(cooldown) {
+		return &EmailCodeThrottleError{RetryAfter: last.LastSentAt.Add(cooldown).Sub(now)}
+	}
+
+	since := now.Add(-emailSendQuotaWindow)
+	for _, quota := range []struct {
+		column string
+		value  string
+		limit  int
+	}{
+		{"email", email, s.cfg.EmailCodeDailyLimitPerEmail},
+		{"ip", ip, s.cfg.EmailCodeDailyLimitPerIP},
+	} {
+		if quota.limit <= 0 || quota.value == "" {
+			continue
+		}
+
+		var count int64
+		if err := s.db.Model(&model.EmailSendLog{}).
+			Where(quota.column+" = ? AND sent_at > ?", quota.value, since).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count email sends: %w", err)
+		}
+		if count < int64(quota.limit) {
+			continue
+		}
+
+		// 한도 안에서 가장 오래된 발송 기록이 집계 기간을 벗어나는 시각까지 대기
+		var oldest model.EmailSendLog
+		if err := s.db.Where(quota.column+" = ? AND sent_at > ?", quota.value, since).
+			Order("sent_at DESC").Offset(quota.limit - 1).First(&oldest).Error; err != nil {
+			return fmt.Errorf("failed to find email send log: %w", err)
+		}
+		s.logger.Warn("Email code daily limit exceeded",
+			zap.String("scope", quota.column),
+			zap.String("email", email),
+			zap.String("ip", ip),
+		)
+		return &EmailCodeThrottleError{RetryAfter: oldest.SentAt.Add(emailSendQuotaWindow).Sub(now), Daily: true}
+	}
+
+	return nil
+}
+
+// issueEmailCode 발송 한도 확인 후 새 인증코드 생성 (같은 용도의 이전 코드는 폐기), 평문 코드 반환
+func (s *AuthService) issueEmailCode(email, ip string, purpose model.EmailVerificationPurpose, ttl time.Duration) (string, error) {
+	if err := s.checkEmailSendQuota(email, ip, purpose); err != nil {
+		return "", err
+	}
+
+	code, err := generateEmailCode()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("email = ? AND purpose = ?", email, purpose).Delete(&model.EmailVerification{}).Error; err != nil {
+			return fmt.Errorf("failed to delete previous code: %w", err)
+		}
+		if err := tx.Create(&model.EmailVerification{
+			Email:      email,
+			Purpose:    purpose,
+			CodeHash:   s.hashEmailSecret(purpose, email, code),
+			ExpiresAt:  now.Add(ttl),
+			SendCount:  1,
+			LastSentAt: &now,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to create email verification: %w", err)
+		}
+		return tx.Create(&model.EmailSendLog{
+			Email:   email,
+			IP:      ip,
+			Purpose: purpose,
+			SentAt:  now,
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// redeemEmailCode 인증코드 확인 후 불투명 토큰 발급 (시도 횟수 초과 시 새 코드 필요), 평문 토큰 반환
+func (s *AuthService) redeemEmailCode(email, code string, purpose model.EmailVerificationPurpose) (string, error) {
+	maxAttempts := s.cfg.EmailCodeMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultEmailCodeMaxAttempts
+	}
+
+	var verification model.EmailVerification
+	if err := s.db.Where("email = ? AND purpose = ? AND is_verified = ?", email, purpose, false).
+		Order("id DESC").First(&verification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrEmailCodeInvalid
+		}
+		return "", fmt.Errorf("failed to find email verification: %w", err)
+	}
+	if verification.IsExpired() || verification.CodeHash == "" {
+		return "", ErrEmailCodeInvalid
+	}
+	if verification.Attempts >= maxAttempts {
+		return "", ErrEmailCodeAttemptsExceeded
+	}
+
+	expected := s.hashEmailSecret(purpose, email, strings.TrimSpace(code))
+	if !hmac.Equal([]byte(expected), []byte(verification.CodeHash)) {
+		// 동시 요청으로 한도를 넘지 않도록 조건부 증가
+		result := s.db.Model(&model.EmailVerification{}).
+			Where("id = ? AND attempts < ?", verification.ID, maxAttempts).
+			Update("attempts", gorm.Expr("attempts + 1"))
+		if result.Error != nil {
+			return "", fmt.Errorf("failed to record attempt: %w", result.Error)
+		}
+		if result.RowsAffected == 0 || verification.Attempts+1 >= maxAttempts {
+			s.logger.Warn("Email code attempts exceeded",
+				zap.String("email", email),
+				zap.String("purpose", string(purpose)),
+			)
+			return "", ErrEmailCodeAttemptsExceeded
+		}
+		return "", ErrEmailCodeInvalid
+	}
+
+	token, err := generateEmailToken()
+	if err != nil {
+		return "", err
+	}
+	tokenHash := s.hashEmailSecret(purpose, email, token)
+
+	// 코드는 한 번만 사용 가능 (확인 후 해시 삭제, 동시 요청은 하나만 성공)
+	result := s.db.Model(&model.EmailVerification{}).
+		Where("id = ? AND code = ?", verification.ID, verification.CodeHash).
+		Updates(map[string]interface{}{
+			"code":               "",
+			"verification_token": tokenHash,
+			"is_verified":        true,
+			"attempts":           0,
+			"expires_at":         time.Now().Add(emailTokenTTL),
+		})
+	if result.Error != nil {
+		return "", fmt.Errorf("failed to update email verification: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return "", ErrEmailCodeInvalid
+	}
+	return token, nil
+}
+
+// findVerifiedEmail 코드 확인 후 받은 토큰으로 유효한 인증 기록 조회 (없거나 만료되면 nil)
+func (s *AuthService) findVerifiedEmail(tx *gorm.DB, email, token string, purpose model.EmailVerificationPurpose) (*model.EmailVerification, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	var verification model.EmailVerification
+	if err := tx.Where("email = ? AND purpose = ? AND verification_token = ? AND is_verified = ? AND expires_at > ?",
+		email, purpose, s.hashEmailSecret(purpose, email, token), true, time.Now()).
+		First(&verification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find email verification: %w", err)
+	}
+	return &verification, nil
+}
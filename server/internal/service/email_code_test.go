@@ -0,0 +1,169 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+func TestAuthService_EmailCode(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	t.Run("인증코드와 토큰은 해시로만 저장", func(t *testing.T) {
+		code, err := svc.issueEmailCode("hash@example.com", "10.0.0.1", model.EmailPurposeSignup, 10*time.Minute)
+		require.NoError(t, err)
+		assert.Len(t, code, emailCodeDigits)
+
+		var verification model.EmailVerification
+		require.NoError(t, db.Where("email = ?", "hash@example.com").First(&verification).Error)
+		assert.NotEqual(t, code, verification.CodeHash)
+		assert.Equal(t, svc.hashEmailSecret(model.EmailPurposeSignup, "hash@example.com", code), verification.CodeHash)
+
+		verified, token, err := svc.VerifyEmailCode("hash@example.com", code)
+		require.NoError(t, err)
+		assert.True(t, verified)
+
+		require.NoError(t, db.First(&verification, verification.ID).Error)
+		assert.Empty(t, verification.CodeHash)
+		require.NotNil(t, verification.VerificationToken)
+		assert.NotEqual(t, token, *verification.VerificationToken)
+
+		_, _, err = svc.VerifyEmailCode("hash@example.com", code)
+		assert.ErrorIs(t, err, ErrEmailCodeInvalid)
+	})
+
+	t.Run("시도 횟수 초과 후에는 올바른 코드도 거부", func(t *testing.T) {
+		code, err := svc.issueEmailCode("attempts@example.com", "10.0.0.2", model.EmailPurposeSignup, 10*time.Minute)
+		require.NoError(t, err)
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		for i := 0; i < 4; i++ {
+			_, _, err := svc.VerifyEmailCode("attempts@example.com", wrong)
+			assert.ErrorIs(t, err, ErrEmailCodeInvalid)
+		}
+		_, _, err = svc.VerifyEmailCode("attempts@example.com", wrong)
+		assert.ErrorIs(t, err, ErrEmailCodeAttemptsExceeded)
+
+		_, _, err = svc.VerifyEmailCode("attempts@example.com", code)
+		assert.ErrorIs(t, err, ErrEmailCodeAttemptsExceeded)
+	})
+
+	t.Run("재발송 대기 시간 안에는 발송 불가", func(t *testing.T) {
+		require.NoError(t, svc.SendEmailCode("cooldown@example.com", ClientInfo{IP: "10.0.0.3"}))
+
+		err := svc.SendEmailCode("cooldown@example.com", ClientInfo{IP: "10.0.0.3"})
+		var throttleErr *EmailCodeThrottleError
+		require.ErrorAs(t, err, &throttleErr)
+		assert.False(t, throttleErr.Daily)
+		assert.InDelta(t, 60, throttleErr.RetryAfterSeconds(), 1)
+
+		// 용도가 다르면 대기 시간을 따로 계산
+		createTestUser(t, db, "cooldown@example.com")
+		assert.NoError(t, svc.PasswordResetRequest("cooldown@example.com", ClientInfo{IP: "10.0.0.3"}))
+	})
+
+	t.Run("이메일별 일일 발송 한도", func(t *testing.T) {
+		svc.cfg.EmailCodeResendCooldownSec = 0
+		defer func() { svc.cfg.EmailCodeResendCooldownSec = 60 }()
+
+		for i := 0; i < svc.cfg.EmailCodeDailyLimitPerEmail; i++ {
+			require.NoError(t, svc.SendEmailCode("daily@example.com", ClientInfo{IP: "10.0.1.1"}))
+		}
+		err := svc.SendEmailCode("daily@example.com", ClientInfo{IP: "10.0.1.2"})
+		var throttleErr *EmailCodeThrottleError
+		require.ErrorAs(t, err, &throttleErr)
+		assert.True(t, throttleErr.Daily)
+		assert.Greater(t, throttleErr.RetryAfter, 23*time.Hour)
+
+		// 집계 기간이 지난 기록은 한도에서 제외
+		require.NoError(t, db.Model(&model.EmailSendLog{}).Where("email = ?", "daily@example.com").
+			Update("sent_at", time.Now().Add(-25*time.Hour)).Error)
+		assert.NoError(t, svc.SendEmailCode("daily@example.com", ClientInfo{IP: "10.0.1.2"}))
+	})
+
+	t.Run("IP별 일일 발송 한도", func(t *testing.T) {
+		svc.cfg.EmailCodeDailyLimitPerIP = 3
+		defer func() { svc.cfg.EmailCodeDailyLimitPerIP = 50 }()
+
+		for _, email := range []string{"ip1@example.com", "ip2@example.com", "ip3@example.com"} {
+			require.NoError(t, svc.SendEmailCode(email, ClientInfo{IP: "10.0.2.1"}))
+		}
+		err := svc.SendEmailCode("ip4@example.com", ClientInfo{IP: "10.0.2.1"})
+		var throttleErr *EmailCodeThrottleError
+		require.ErrorAs(t, err, &throttleErr)
+		assert.True(t, throttleErr.Daily)
+
+		assert.NoError(t, svc.SendEmailCode("ip4@example.com", ClientInfo{IP: "10.0.2.2"}))
+	})
+}
+
+func TestAuthService_EmailTokenPurpose(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "purpose@example.com")
+
+	code, err := svc.issueEmailCode("purpose@example.com", "10.0.0.1", model.EmailPurposeSignup, 10*time.Minute)
+	require.NoError(t, err)
+	_, signupToken, err := svc.VerifyEmailCode("purpose@example.com", code)
+	require.NoError(t, err)
+
+	t.Run("회원가입 코드로 비밀번호 재설정 코드 확인 불가", func(t *testing.T) {
+		_, err := svc.PasswordResetVerify("purpose@example.com", code)
+		assert.ErrorIs(t, err, ErrEmailCodeInvalid)
+	})
+
+	t.Run("회원가입 토큰으로 비밀번호 재설정 불가", func(t *testing.T) {
+		assert.Error(t, svc.PasswordResetConfirm("purpose@example.com", signupToken, "newpassword123"))
+	})
+
+	t.Run("비밀번호 재설정 토큰은 한 번만 사용", func(t *testing.T) {
+		resetCode, err := svc.issueEmailCode("purpose@example.com", "10.0.0.1", model.EmailPurposePasswordReset, time.Hour)
+		require.NoError(t, err)
+		resetToken, err := svc.PasswordResetVerify("purpose@example.com", resetCode)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.PasswordResetConfirm("purpose@example.com", resetToken, "newpassword123"))
+		assert.Error(t, svc.PasswordResetConfirm("purpose@example.com", resetToken, "otherpassword123"))
+
+		resp, err := svc.EmailLogin(&LoginRequest{Email: "purpose@example.com", Password: "newpassword123"})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, resp.User.ID)
+	})
+
+	t.Run("만료된 토큰으로 회원가입 불가", func(t *testing.T) {
+		require.NoError(t, db.Model(&model.EmailVerification{}).
+			Where("email = ? AND purpose = ?", "purpose@example.com", model.EmailPurposeSignup).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		_, err := svc.Signup(&SignupRequest{Email: "purpose@example.com", Password: "password123", VerificationToken: &signupToken})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewEmailCodeKey(t *testing.T) {
+	t.Run("EMAIL_CODE_SECRET 우선 사용", func(t *testing.T) {
+		key, err := newEmailCodeKey(&config.Config{EmailCodeSecret: "code-secret", JWTSecretKey: "jwt-secret"}, setupTestLogger())
+		require.NoError(t, err)
+		assert.Equal(t, []byte("code-secret"), key)
+	})
+
+	t.Run("JWT secret에서 파생한 키는 파드 간 동일", func(t *testing.T) {
+		first, err := newEmailCodeKey(&config.Config{JWTSecretKey: "jwt-secret"}, setupTestLogger())
+		require.NoError(t, err)
+		second, err := newEmailCodeKey(&config.Config{JWTSecretKey: "jwt-secret"}, setupTestLogger())
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("키가 없으면 에러 (임시 키 사용 안 함)", func(t *testing.T) {
+		_, err := newEmailCodeKey(&config.Config{}, setupTestLogger())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// GuestUpgradeResult 익명 → 정회원 전환 결과
+type GuestUpgradeResult struct {
+	GuestUserID           uint  `json:"guest_user_id"`
+	MergedSketches        int64 `json:"merged_sketches"`
+	MergedRecommendations int64 `json:"merged_recommendations"`
+}
+
+// UpgradeGuest 익명 사용자의 스케치/추천 기록을 정회원 계정으로 이관하고 익명 계정을 정리
+// guestToken은 GuestLogin에서 발급된 익명 Access Token, method는 전환 경로 (email, google, apple, kakao, naver, passkey)
+func (s *AuthService) UpgradeGuest(guestToken string, memberID uint, method string) (*GuestUpgradeResult, error) {
+	start := time.Now()
+
+	claims, err := auth.ValidateAccessToken(guestToken, s.keys, s.revoker)
+	if err != nil {
+		return nil, fmt.Errorf("유효하지 않은 익명 토큰입니다: %w", err)
+	}
+	if !claims.IsGuest {
+		return nil, errors.New("익명 사용자 토큰이 아닙니다")
+	}
+	if claims.UserID == memberID {
+		return nil, errors.New("같은 계정으로는 전환할 수 없습니다")
+	}
+
+	result := &GuestUpgradeResult{GuestUserID: claims.UserID}
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		var guest model.User
+		if err := tx.Where("id = ? AND is_guest = ?", claims.UserID, true).First(&guest).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("익명 사용자를 찾을 수 없습니다")
+			}
+			return fmt.Errorf("failed to find guest user: %w", err)
+		}
+
+		// 이관 대상: 익명 사용자 소유 스케치 + 같은 디바이스에서 로그인 없이 만든 스케치
+		sketchScope := func(db *gorm.DB) *gorm.DB {
+			if guest.DeviceID != nil && *guest.DeviceID != "" {
+				return db.Where("user_id = ? OR (user_id IS NULL AND device_id = ?)", guest.ID, *guest.DeviceID)
+			}
+			return db.Where("user_id = ?", guest.ID)
+		}
+
+		// 추천은 스케치에 종속되므로 스케치 이관 시 함께 이동 (이관 전 건수 집계)
+		if err := tx.Model(&model.Recommendation{}).
+			Where("sketch_id IN (?)", sketchScope(tx.Model(&model.Sketch{})).Select("id")).
+			Count(&result.MergedRecommendations).Error; err != nil {
+			return fmt.Errorf("failed to count recommendations: %w", err)
+		}
+
+		updated := sketchScope(tx.Model(&model.Sketch{})).Update("user_id", memberID)
+		if updated.Error != nil {
+			return fmt.Errorf("failed to merge sketches: %w", updated.Error)
+		}
+		result.MergedSketches = updated.RowsAffected
+
+		// 익명 계정 정리: 디바이스 ID를 해제해 같은 기기에서 다시 익명 로그인할 수 있도록 하고,
+		// 발급된 익명 토큰은 즉시 폐기
+		if err := tx.Model(&guest).Updates(map[string]interface{}{
+			"device_id":             nil,
+			"is_active":             false,
+			"tokens_revoked_before": time.Now().Truncate(time.Second),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to retire guest user: %w", err)
+		}
+		if err := tx.Model(&model.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", guest.ID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return fmt.Errorf("failed to revoke guest sessions: %w", err)
+		}
+		if err := tx.Delete(&guest).Error; err != nil {
+			return fmt.Errorf("failed to delete guest user: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		s.logger.Warn("Guest upgrade failed",
+			zap.Error(txErr),
+			zap.Uint("guest_user_id", claims.UserID),
+			zap.Uint("user_id", memberID),
+			zap.String("method", method),
+		)
+		return nil, txErr
+	}
+
+	if s.metrics != nil {
+		s.metrics.RecordGuestToUserConversion(context.Background(), method)
+	}
+
+	s.logger.Info("Guest upgraded to member",
+		zap.Uint("guest_user_id", claims.UserID),
+		zap.Uint("user_id", memberID),
+		zap.String("method", method),
+		zap.Int64("merged_sketches", result.MergedSketches),
+		zap.Int64("merged_recommendations", result.MergedRecommendations),
+		zap.Duration("duration", time.Since(start)),
+	)
+
+	return result, nil
+}
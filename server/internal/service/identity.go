@@ -0,0 +1,440 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// EmailCollisionPolicy 신규 로그인/가입 이메일이 기존 계정 이메일과 겹칠 때의 처리 정책
+type EmailCollisionPolicy string
+
+const (
+	// EmailCollisionReject 새 계정을 만들지 않고 거부 (기존 방식으로 로그인 후 계정 연결 유도)
+	EmailCollisionReject EmailCollisionPolicy = "reject"
+	// EmailCollisionLink 이메일 소유가 확인된 경우(제공자의 email_verified, 이메일 가입 인증코드) 기존 계정에 자동 연결, 그 외에는 거부
+	EmailCollisionLink EmailCollisionPolicy = "link"
+	// EmailCollisionSeparate 별도 계정 생성 (기존 동작)
+	EmailCollisionSeparate EmailCollisionPolicy = "separate"
+)
+
+// ErrEmailCollision 다른 로그인 수단으로 가입된 이메일로 신규 로그인/가입을 시도한 경우
+var ErrEmailCollision = errors.New("이미 다른 로그인 방식으로 가입된 이메일입니다. 기존 방식으로 로그인한 뒤 계정 연결을 이용해 주세요")
+
+// ErrIdentityAlreadyLinked 로그인 수단이 이미 다른 계정에 연결된 경우
+var ErrIdentityAlreadyLinked = errors.New("이미 다른 계정에 연결된 로그인 수단입니다")
+
+// placeholderEmailSuffix 이메일 미제공 SNS 사용자용 placeholder 이메일 도메인
+const placeholderEmailSuffix = "@placeholder.local"
+
+// LinkIdentityRequest 로그인 수단 연결 요청
+// SNS는 Token(Google ID Token, Apple Identity Token, Kakao/Naver Access Token),
+// 이메일은 Email/Password/VerificationToken 사용
+type LinkIdentityRequest struct {
+	Token             string
+	Email             string
+	Password          string
+	VerificationToken string
+}
+
+// emailCollisionPolicy 설정된 이메일 충돌 정책 (미설정/알 수 없는 값은 reject)
+func (s *AuthService) emailCollisionPolicy() EmailCollisionPolicy {
+	switch EmailCollisionPolicy(s.cfg.AuthEmailCollisionPolicy) {
+	case EmailCollisionLink:
+		return EmailCollisionLink
+	case EmailCollisionSeparate:
+		return EmailCollisionSeparate
+	default:
+		return EmailCollisionReject
+	}
+}
+
+// findUserByIdentity (provider, subject)로 연결된 사용자 조회
+// 계정 연결 도입 이전 사용자는 users.login_method/social_id로 찾아 identity를 생성
+func (s *AuthService) findUserByIdentity(tx *gorm.DB, provider model.LoginMethod, subject, email string) (*model.User, error) {
+	var identity model.UserIdentity
+	err := tx.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err == nil {
+		var user model.User
+		if err := tx.First(&user, identity.UserID).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to find identity user: %w", err)
+			}
+			// 탈퇴 유예 기간 중인 사용자의 연결은 복구용으로 유지
+			deletion, err := s.pendingDeletion(tx, identity.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if deletion != nil {
+				return nil, nil
+			}
+			// 탈퇴한 사용자에 남은 연결은 정리 후 신규 사용자로 처리
+			if err := tx.Delete(&identity).Error; err != nil {
+				return nil, fmt.Errorf("failed to delete stale identity: %w", err)
+			}
+			return nil, nil
+		}
+		return &user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+
+	// 기존 사용자 찾기 (identity 미생성 사용자)
+	var user model.User
+	query := tx.Where("login_method = ?", provider)
+	if provider == model.LoginMethodEmail {
+		query = query.Where("email = ?", subject)
+	} else {
+		query = query.Where("social_id = ?", subject)
+	}
+	if err := query.First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err := s.createIdentity(tx, user.ID, provider, subject, email); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// findEmailCollision 이메일이 같은 다른 정회원 계정 조회 (placeholder 이메일은 제외)
+func (s *AuthService) findEmailCollision(tx *gorm.DB, email string) (*model.User, error) {
+	if email == "" || strings.HasSuffix(email, placeholderEmailSuffix) {
+		return nil, nil
+	}
+
+	var user model.User
+	err := tx.Where("email = ? AND is_guest = ?", email, false).First(&user).Error
+	if err == nil {
+		return &user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email collision: %w", err)
+	}
+
+	var identity model.UserIdentity
+	err = tx.Where("email = ?", email).First(&identity).Error
+	if err == nil {
+		if err := tx.First(&user, identity.UserID).Error; err == nil {
+			return &user, nil
+		}
+		return nil, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email collision: %w", err)
+	}
+
+	return nil, nil
+}
+
+// resolveEmailCollision 처음 보는 로그인 수단으로 로그인/가입 시 이메일 충돌 정책 적용
+// 기존 계정에 연결해야 하면 해당 사용자를 반환하고, 새 계정을 만들어야 하면 nil 반환
+// emailVerified는 이메일 소유가 확인되었는지 여부 (SNS는 제공자가 확인한 값, 이메일 가입은 인증코드로 확인)
+func (s *AuthService) resolveEmailCollision(tx *gorm.DB, provider model.LoginMethod, subject, email string, emailVerified bool) (*model.User, error) {
+	policy := s.emailCollisionPolicy()
+	if policy == EmailCollisionSeparate {
+		return nil, nil
+	}
+
+	existing, err := s.findEmailCollision(tx, email)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	// 이메일 소유가 확인된 경우에만 자동 연결, 미확인 이메일로 기존 계정에 연결하면 계정 탈취가 가능하므로 거부
+	if policy == EmailCollisionLink && emailVerified {
+		if err := s.createIdentity(tx, existing.ID, provider, subject, email); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Identity auto-linked by email",
+			zap.String("provider", string(provider)),
+			zap.Uint("user_id", existing.ID),
+		)
+		return existing, nil
+	}
+
+	s.logger.Warn("Identity rejected: email already registered",
+		zap.String("provider", string(provider)),
+		zap.Uint("existing_user_id", existing.ID),
+		zap.String("existing_login_method", string(existing.LoginMethod)),
+		zap.Bool("email_verified", emailVerified),
+	)
+	return nil, ErrEmailCollision
+}
+
+// createIdentity 로그인 수단 연결 레코드 생성
+func (s *AuthService) createIdentity(tx *gorm.DB, userID uint, provider model.LoginMethod, subject, email string) error {
+	identity := model.UserIdentity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  subject,
+		Email:    email,
+		LinkedAt: time.Now(),
+	}
+	if err := tx.Create(&identity).Error; err != nil {
+		return fmt.Errorf("failed to create identity: %w", err)
+	}
+	return nil
+}
+
+// ensurePrimaryIdentity 사용자 기본 로그인 수단(users.login_method)의 identity가 없으면 생성
+func (s *AuthService) ensurePrimaryIdentity(tx *gorm.DB, user *model.User) error {
+	if user.IsGuest || user.LoginMethod == model.LoginMethodGuest {
+		return nil
+	}
+
+	subject := user.SocialID
+	if user.LoginMethod == model.LoginMethodEmail {
+		subject = user.Email
+	}
+	if subject == "" {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&model.UserIdentity{}).
+		Where("provider = ? AND subject = ?", user.LoginMethod, subject).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check primary identity: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	email := user.Email
+	if strings.HasSuffix(email, placeholderEmailSuffix) {
+		email = ""
+	}
+	return s.createIdentity(tx, user.ID, user.LoginMethod, subject, email)
+}
+
+// ListIdentities 계정에 연결된 로그인 수단 목록
+func (s *AuthService) ListIdentities(userID uint) ([]model.UserIdentity, error) {
+	var identities []model.UserIdentity
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("사용자를 찾을 수 없습니다")
+			}
+			return fmt.Errorf("사용자 조회 실패: %w", err)
+		}
+
+		if err := s.ensurePrimaryIdentity(tx, &user); err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Order("linked_at ASC").Find(&identities).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+// LinkIdentity 인증된 계정에 로그인 수단 연결 (제공자별 하나)
+func (s *AuthService) LinkIdentity(userID uint, provider model.LoginMethod, req *LinkIdentityRequest) (*model.UserIdentity, error) {
+	var subject, email, hashedPassword string
+	var verification *model.EmailVerification // 이메일 연결 시 사용한 인증 기록 (연결 후 삭제)
+
+	switch provider {
+	case model.LoginMethodEmail:
+		email = normalizeEmail(req.Email)
+		if email == "" || req.Password == "" || req.VerificationToken == "" {
+			return nil, errors.New("이메일, 비밀번호, 인증 토큰이 필요합니다")
+		}
+
+		found, err := s.findVerifiedEmail(s.db, email, req.VerificationToken, model.EmailPurposeSignup)
+		if err != nil || found == nil {
+			return nil, errors.New("이메일 인증이 완료되지 않았습니다")
+		}
+		verification = found
+
+		hashed, err := auth.HashPassword(req.Password)
+		if err != nil {
+			return nil, fmt.Errorf("비밀번호 처리에 실패했습니다: %w", err)
+		}
+		subject = email
+		hashedPassword = hashed
+
+	default:
+		// SNS 로그인 수단 (등록되지 않은 제공자는 ErrUnsupportedProvider)
+		if _, ok := s.providers.Get(string(provider)); !ok {
+			return nil, ErrUnsupportedProvider
+		}
+		if req.Token == "" {
+			return nil, errors.New("토큰이 필요합니다")
+		}
+		info, err := s.verifySNSToken(provider, req.Token)
+		if err != nil {
+			return nil, err
+		}
+		subject = info.Subject
+		email = normalizeEmail(info.Email)
+	}
+
+	var identity *model.UserIdentity
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("사용자를 찾을 수 없습니다")
+			}
+			return fmt.Errorf("사용자 조회 실패: %w", err)
+		}
+		if user.IsGuest {
+			return errors.New("익명 사용자는 로그인 수단을 연결할 수 없습니다")
+		}
+
+		if err := s.ensurePrimaryIdentity(tx, &user); err != nil {
+			return err
+		}
+
+		// 같은 로그인 수단이 다른 계정에 연결되어 있는지 확인 (identity 미생성 사용자 포함)
+		owner, err := s.findUserByIdentity(tx, provider, subject, email)
+		if err != nil {
+			return err
+		}
+		if owner != nil && owner.ID != userID {
+			return ErrIdentityAlreadyLinked
+		}
+
+		var existing model.UserIdentity
+		if err := tx.Where("user_id = ? AND provider = ?", userID, provider).First(&existing).Error; err == nil {
+			if existing.Subject != subject {
+				return errors.New("이미 같은 종류의 로그인 수단이 연결되어 있습니다")
+			}
+			identity = &existing
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to find identity: %w", err)
+		} else {
+			if err := s.createIdentity(tx, userID, provider, subject, email); err != nil {
+				return err
+			}
+			var created model.UserIdentity
+			if err := tx.Where("provider = ? AND subject = ?", provider, subject).First(&created).Error; err != nil {
+				return fmt.Errorf("failed to load identity: %w", err)
+			}
+			identity = &created
+		}
+
+		if provider == model.LoginMethodEmail {
+			if err := tx.Model(&user).Update("password", hashedPassword).Error; err != nil {
+				return fmt.Errorf("비밀번호 설정에 실패했습니다: %w", err)
+			}
+			if err := tx.Delete(verification).Error; err != nil {
+				return fmt.Errorf("failed to consume email verification: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("Identity link failed",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+			zap.String("provider", string(provider)),
+		)
+		return nil, err
+	}
+
+	s.logger.Info("Identity linked",
+		zap.Uint("user_id", userID),
+		zap.String("provider", string(provider)),
+	)
+
+	return identity, nil
+}
+
+// UnlinkIdentity 로그인 수단 연결 해제 (마지막 수단은 해제 불가)
+// 기본 로그인 수단을 해제하면 남은 수단 중 가장 먼저 연결된 것을 기본으로 변경
+func (s *AuthService) UnlinkIdentity(userID uint, provider model.LoginMethod) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("사용자를 찾을 수 없습니다")
+			}
+			return fmt.Errorf("사용자 조회 실패: %w", err)
+		}
+
+		if err := s.ensurePrimaryIdentity(tx, &user); err != nil {
+			return err
+		}
+
+		var identities []model.UserIdentity
+		if err := tx.Where("user_id = ?", userID).Order("linked_at ASC").Find(&identities).Error; err != nil {
+			return fmt.Errorf("failed to list identities: %w", err)
+		}
+
+		var target *model.UserIdentity
+		var remaining []model.UserIdentity
+		for i := range identities {
+			if identities[i].Provider == provider {
+				target = &identities[i]
+			} else {
+				remaining = append(remaining, identities[i])
+			}
+		}
+		if target == nil {
+			return errors.New("연결되지 않은 로그인 수단입니다")
+		}
+		if len(remaining) == 0 {
+			return errors.New("마지막 로그인 수단은 해제할 수 없습니다")
+		}
+
+		if err := tx.Delete(target).Error; err != nil {
+			return fmt.Errorf("failed to delete identity: %w", err)
+		}
+
+		updates := map[string]interface{}{}
+		if provider == model.LoginMethodEmail {
+			updates["password"] = ""
+		}
+		if user.LoginMethod == provider {
+			next := remaining[0]
+			updates["login_method"] = next.Provider
+			if next.Provider == model.LoginMethodEmail {
+				updates["social_id"] = ""
+			} else {
+				updates["social_id"] = next.Subject
+			}
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&user).Updates(updates).Error; err != nil {
+				return fmt.Errorf("failed to update user: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("Identity unlink failed",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+			zap.String("provider", string(provider)),
+		)
+		return err
+	}
+
+	s.logger.Info("Identity unlinked",
+		zap.Uint("user_id", userID),
+		zap.String("provider", string(provider)),
+	)
+
+	return nil
+}
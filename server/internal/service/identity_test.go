@@ -0,0 +1,190 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+func TestAuthService_SNSLogin_EmailCollision(t *testing.T) {
+	t.Run("reject 정책: 기존 이메일 계정이 있으면 거부", func(t *testing.T) {
+		svc, db := setupAuthService(t)
+		createTestUser(t, db, "collide@example.com")
+
+		_, err := svc.handleSNSLogin("google", "google-sub-1", "collide@example.com", false, "", ClientInfo{})
+		assert.ErrorIs(t, err, ErrEmailCollision)
+	})
+
+	t.Run("link 정책: 검증된 이메일이면 기존 계정에 연결", func(t *testing.T) {
+		svc, db := setupAuthService(t)
+		svc.cfg.AuthEmailCollisionPolicy = string(EmailCollisionLink)
+		user := createTestUser(t, db, "link@example.com")
+
+		first, err := svc.handleSNSLogin("google", "google-sub-2", "link@example.com", true, "", ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, first.User.ID)
+
+		// 연결 이후에는 identity로 같은 계정 조회
+		second, err := svc.handleSNSLogin("google", "google-sub-2", "link@example.com", false, "", ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, second.User.ID)
+	})
+
+	t.Run("link 정책: 미검증 Google 이메일은 연결하지 않고 거부", func(t *testing.T) {
+		svc, db := setupAuthService(t)
+		svc.cfg.AuthEmailCollisionPolicy = string(EmailCollisionLink)
+		createTestUser(t, db, "unverified@example.com")
+
+		_, err := svc.handleSNSLogin("google", "google-sub-5", "unverified@example.com", false, "", ClientInfo{})
+		assert.ErrorIs(t, err, ErrEmailCollision)
+	})
+
+	t.Run("link 정책: 검증된 Kakao 이메일은 연결", func(t *testing.T) {
+		svc, db := setupAuthService(t)
+		svc.cfg.AuthEmailCollisionPolicy = string(EmailCollisionLink)
+		user := createTestUser(t, db, "kakao@example.com")
+
+		response, err := svc.handleSNSLogin("kakao", "kakao-sub", "kakao@example.com", true, "", ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, response.User.ID)
+	})
+
+	t.Run("link 정책: 인증 여부를 주지 않는 Naver 이메일은 거부", func(t *testing.T) {
+		svc, db := setupAuthService(t)
+		svc.cfg.AuthEmailCollisionPolicy = string(EmailCollisionLink)
+		createTestUser(t, db, "naver@example.com")
+
+		_, err := svc.handleSNSLogin("naver", "naver-sub", "naver@example.com", false, "", ClientInfo{})
+		assert.ErrorIs(t, err, ErrEmailCollision)
+	})
+
+	t.Run("separate 정책: 별도 계정 생성", func(t *testing.T) {
+		svc, db := setupAuthService(t)
+		svc.cfg.AuthEmailCollisionPolicy = string(EmailCollisionSeparate)
+		user := createTestUser(t, db, "separate@example.com")
+
+		response, err := svc.handleSNSLogin("google", "google-sub-3", "separate@example.com", false, "", ClientInfo{})
+		require.NoError(t, err)
+		assert.NotEqual(t, user.ID, response.User.ID)
+	})
+}
+
+func TestAuthService_SNSLogin_LegacyUser(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	// 계정 연결 도입 이전에 생성된 SNS 사용자 (identity 없음)
+	legacy := &model.User{
+		Username:    "apple_legacy",
+		Email:       "apple_legacy@placeholder.local",
+		LoginMethod: model.LoginMethodApple,
+		SocialID:    "apple-legacy-sub",
+		IsActive:    true,
+	}
+	require.NoError(t, db.Create(legacy).Error)
+
+	response, err := svc.handleSNSLogin("apple", "apple-legacy-sub", "", false, "", ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, legacy.ID, response.User.ID)
+
+	var count int64
+	db.Model(&model.UserIdentity{}).Where("user_id = ? AND provider = ?", legacy.ID, "apple").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestAuthService_LinkAndUnlinkIdentity(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	response, err := svc.handleSNSLogin("google", "google-sub-4", "switch@example.com", false, "", ClientInfo{})
+	require.NoError(t, err)
+	userID := response.User.ID
+
+	token := "verified-token"
+	createVerifiedEmail(t, svc, db, "switch@example.com", token, model.EmailPurposeSignup)
+
+	t.Run("이메일 로그인 연결 후 이메일로 로그인", func(t *testing.T) {
+		_, err := svc.LinkIdentity(userID, model.LoginMethodEmail, &LinkIdentityRequest{
+			Email:             "switch@example.com",
+			Password:          "password123",
+			VerificationToken: token,
+		})
+		require.NoError(t, err)
+
+		login, err := svc.EmailLogin(&LoginRequest{Email: "switch@example.com", Password: "password123"})
+		require.NoError(t, err)
+		assert.Equal(t, userID, login.User.ID)
+
+		identities, err := svc.ListIdentities(userID)
+		require.NoError(t, err)
+		assert.Len(t, identities, 2)
+	})
+
+	t.Run("다른 계정의 로그인 수단은 연결 불가", func(t *testing.T) {
+		createTestUser(t, db, "taken@example.com")
+		takenToken := "taken-token"
+		createVerifiedEmail(t, svc, db, "taken@example.com", takenToken, model.EmailPurposeSignup)
+
+		_, err := svc.LinkIdentity(userID, model.LoginMethodEmail, &LinkIdentityRequest{
+			Email:             "taken@example.com",
+			Password:          "password123",
+			VerificationToken: takenToken,
+		})
+		assert.ErrorIs(t, err, ErrIdentityAlreadyLinked)
+	})
+
+	t.Run("기본 로그인 수단 해제 시 남은 수단으로 변경", func(t *testing.T) {
+		require.NoError(t, svc.UnlinkIdentity(userID, model.LoginMethodGoogle))
+
+		var user model.User
+		require.NoError(t, db.First(&user, userID).Error)
+		assert.Equal(t, model.LoginMethodEmail, user.LoginMethod)
+		assert.Empty(t, user.SocialID)
+	})
+
+	t.Run("마지막 로그인 수단은 해제 불가", func(t *testing.T) {
+		assert.Error(t, svc.UnlinkIdentity(userID, model.LoginMethodEmail))
+	})
+}
+
+func TestAuthService_PasswordReset_LinkedEmailIdentity(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	// 대표 로그인 수단은 SNS, 이메일/비밀번호는 연결된 수단
+	response, err := svc.handleSNSLogin("kakao", "kakao-sub-reset", "linked-reset@example.com", false, "", ClientInfo{})
+	require.NoError(t, err)
+	userID := response.User.ID
+
+	token := "linked-reset-token"
+	createVerifiedEmail(t, svc, db, "linked-reset@example.com", token, model.EmailPurposeSignup)
+	_, err = svc.LinkIdentity(userID, model.LoginMethodEmail, &LinkIdentityRequest{
+		Email:             "linked-reset@example.com",
+		Password:          "password123",
+		VerificationToken: token,
+	})
+	require.NoError(t, err)
+
+	var user model.User
+	require.NoError(t, db.First(&user, userID).Error)
+	require.Equal(t, model.LoginMethodKakao, user.LoginMethod)
+
+	t.Run("재설정 요청 후 새 비밀번호로 로그인", func(t *testing.T) {
+		require.NoError(t, svc.PasswordResetRequest("linked-reset@example.com", ClientInfo{IP: "10.0.0.9"}))
+
+		resetToken := "linked-reset-password-token"
+		createVerifiedEmail(t, svc, db, "linked-reset@example.com", resetToken, model.EmailPurposePasswordReset)
+		require.NoError(t, svc.PasswordResetConfirm("linked-reset@example.com", resetToken, "newpassword123"))
+
+		login, err := svc.EmailLogin(&LoginRequest{Email: "linked-reset@example.com", Password: "newpassword123"})
+		require.NoError(t, err)
+		assert.Equal(t, userID, login.User.ID)
+	})
+
+	t.Run("이메일 로그인 수단이 없는 SNS 계정은 재설정 불가", func(t *testing.T) {
+		_, err := svc.handleSNSLogin("kakao", "kakao-sub-only", "sns-only@example.com", false, "", ClientInfo{})
+		require.NoError(t, err)
+
+		assert.Error(t, svc.PasswordResetRequest("sns-only@example.com", ClientInfo{IP: "10.0.0.9"}))
+	})
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/sns"
+)
+
+var (
+	// ErrKakaoUnlinkDisabled Kakao Admin 키 미설정으로 연결 끊기를 할 수 없음
+	ErrKakaoUnlinkDisabled = errors.New("Kakao 연결 끊기가 설정되어 있지 않습니다")
+	// ErrKakaoCallbackUnauthorized Admin 키가 일치하지 않는 Kakao 콜백 요청
+	ErrKakaoCallbackUnauthorized = errors.New("Kakao 콜백 인증에 실패했습니다")
+)
+
+// kakaoSubjects 사용자에 연결된 Kakao 회원번호 목록 (identity 미생성 기존 사용자는 social_id 사용)
+func (s *AuthService) kakaoSubjects(user *model.User) ([]string, error) {
+	var subjects []string
+	if err := s.db.Model(&model.UserIdentity{}).
+		Where("user_id = ? AND provider = ?", user.ID, model.LoginMethodKakao).
+		Pluck("subject", &subjects).Error; err != nil {
+		return nil, fmt.Errorf("failed to list kakao identities: %w", err)
+	}
+
+	if user.LoginMethod == model.LoginMethodKakao && user.SocialID != "" {
+		found := false
+		for _, subject := range subjects {
+			if subject == user.SocialID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			subjects = append(subjects, user.SocialID)
+		}
+	}
+	return subjects, nil
+}
+
+// unlinkKakao 사용자의 Kakao 앱 연결 끊기 (Admin 키 사용, 연결된 Kakao 계정이 없으면 아무것도 하지 않음)
+func (s *AuthService) unlinkKakao(user *model.User) error {
+	subjects, err := s.kakaoSubjects(user)
+	if err != nil {
+		return err
+	}
+	if len(subjects) == 0 {
+		return nil
+	}
+	if s.cfg.KakaoAdminKey == "" {
+		return ErrKakaoUnlinkDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), snsVerifyTimeout)
+	defer cancel()
+
+	for _, subject := range subjects {
+		if err := sns.UnlinkKakaoUser(ctx, s.cfg.KakaoAPIBaseURL, s.cfg.KakaoAdminKey, subject); err != nil {
+			return fmt.Errorf("Kakao 연결 끊기에 실패했습니다: %w", err)
+		}
+	}
+
+	s.logger.Info("Kakao app connection unlinked",
+		zap.Uint("user_id", user.ID),
+		zap.Int("count", len(subjects)),
+	)
+	return nil
+}
+
+// HandleKakaoUnlink Kakao 연결 끊기 콜백 처리 (사용자가 카카오톡에서 앱 연결을 끊은 경우)
+// 다른 로그인 수단이 있으면 Kakao 연결만 해제하고, Kakao가 유일한 로그인 수단이면 탈퇴 유예 기간 시작
+// (유예 기간 안에 다시 Kakao로 로그인하면 복구)
+// 연결된 사용자가 없는 콜백은 무시
+func (s *AuthService) HandleKakaoUnlink(authorization, kakaoUserID, referrerType string) error {
+	if !sns.VerifyKakaoCallbackAuthorization(authorization, s.cfg.KakaoAdminKey) {
+		return ErrKakaoCallbackUnauthorized
+	}
+
+	user, err := s.findUserByIdentity(s.db, model.LoginMethodKakao, kakaoUserID, "")
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		s.logger.Info("Kakao unlink callback for unknown user ignored",
+			zap.String("referrer_type", referrerType),
+		)
+		return nil
+	}
+
+	s.logger.Info("Kakao unlink callback received",
+		zap.Uint("user_id", user.ID),
+		zap.String("referrer_type", referrerType),
+	)
+
+	var others int64
+	if err := s.db.Model(&model.UserIdentity{}).
+		Where("user_id = ? AND provider <> ?", user.ID, model.LoginMethodKakao).
+		Count(&others).Error; err != nil {
+		return fmt.Errorf("failed to count identities: %w", err)
+	}
+	if others > 0 {
+		return s.UnlinkIdentity(user.ID, model.LoginMethodKakao)
+	}
+
+	_, err = s.requestAccountDeletion(user, "Kakao 연결 끊기")
+	return err
+}
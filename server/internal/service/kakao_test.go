@@ -0,0 +1,110 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// newKakaoUnlinkStub Kakao 연결 끊기 API stub (요청한 회원번호 기록)
+func newKakaoUnlinkStub(t *testing.T, adminKey string) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	unlinked := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/user/unlink" || r.Header.Get("Authorization") != "KakaoAK "+adminKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":-401,"msg":"wrong appKey"}`))
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		unlinked = append(unlinked, r.FormValue("target_id"))
+		_, _ = w.Write([]byte(`{"id":` + r.FormValue("target_id") + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &unlinked
+}
+
+func TestAuthService_DeleteMe_UnlinksKakao(t *testing.T) {
+	svc, _ := setupSNSAuthService(t)
+	svc.cfg.KakaoAdminKey = "admin-key"
+
+	response, err := svc.SNSLogin(model.LoginMethodKakao, "kakao-token", ClientInfo{})
+	require.NoError(t, err)
+
+	// 로그인 이후 연결 끊기 API만 별도 stub으로 연결 (제공자 목록은 로그인용 stub 주소 유지)
+	server, unlinked := newKakaoUnlinkStub(t, "admin-key")
+	svc.cfg.KakaoAPIBaseURL = server.URL
+
+	_, err = svc.DeleteMe(response.User.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1001"}, *unlinked)
+}
+
+func TestAuthService_HandleKakaoUnlink(t *testing.T) {
+	svc, _ := setupSNSAuthService(t)
+	svc.cfg.KakaoAdminKey = "admin-key"
+
+	response, err := svc.SNSLogin(model.LoginMethodKakao, "kakao-token", ClientInfo{})
+	require.NoError(t, err)
+	userID := response.User.ID
+
+	t.Run("Admin 키가 다른 콜백 거부", func(t *testing.T) {
+		err := svc.HandleKakaoUnlink("KakaoAK wrong-key", "1001", "UNLINK_FROM_APPS")
+		assert.ErrorIs(t, err, ErrKakaoCallbackUnauthorized)
+
+		err = svc.HandleKakaoUnlink("", "1001", "UNLINK_FROM_APPS")
+		assert.ErrorIs(t, err, ErrKakaoCallbackUnauthorized)
+	})
+
+	t.Run("연결되지 않은 회원번호는 무시", func(t *testing.T) {
+		assert.NoError(t, svc.HandleKakaoUnlink("KakaoAK admin-key", "9999", "UNLINK_FROM_APPS"))
+	})
+
+	t.Run("Kakao만 연결된 계정은 탈퇴 유예 기간 시작", func(t *testing.T) {
+		require.NoError(t, svc.HandleKakaoUnlink("KakaoAK admin-key", "1001", "UNLINK_FROM_APPS"))
+
+		var count int64
+		svc.db.Model(&model.User{}).Where("id = ?", userID).Count(&count)
+		assert.Zero(t, count)
+
+		var deletion model.AccountDeletion
+		require.NoError(t, svc.db.Where("user_id = ?", userID).First(&deletion).Error)
+		assert.Equal(t, "Kakao 연결 끊기", deletion.Reason)
+	})
+
+	t.Run("연결 끊기 후 다시 Kakao로 로그인하면 복구", func(t *testing.T) {
+		again, err := svc.SNSLogin(model.LoginMethodKakao, "kakao-token", ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, userID, again.User.ID)
+		assert.True(t, again.AccountRestored)
+		assert.True(t, again.User.IsActive)
+
+		var count int64
+		svc.db.Model(&model.AccountDeletion{}).Where("user_id = ?", userID).Count(&count)
+		assert.Zero(t, count)
+	})
+
+	t.Run("다른 로그인 수단이 있으면 Kakao 연결만 해제", func(t *testing.T) {
+		naver, err := svc.SNSLogin(model.LoginMethodNaver, "naver-token", ClientInfo{})
+		require.NoError(t, err)
+		require.NoError(t, svc.createIdentity(svc.db, naver.User.ID, model.LoginMethodKakao, "2002", ""))
+
+		require.NoError(t, svc.HandleKakaoUnlink("KakaoAK admin-key", "2002", "ACCOUNT_DELETE"))
+
+		var user model.User
+		require.NoError(t, svc.db.First(&user, naver.User.ID).Error)
+		assert.True(t, user.IsActive)
+
+		var count int64
+		svc.db.Model(&model.UserIdentity{}).Where("user_id = ? AND provider = ?", naver.User.ID, model.LoginMethodKakao).Count(&count)
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,129 @@
+package llm
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState 서킷 브레이커 상태 (메트릭 값으로도 사용)
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = 0 // 정상: 모든 요청 허용
+	BreakerHalfOpen BreakerState = 1 // 대기 시간 경과: 확인 요청 1개만 허용
+	BreakerOpen     BreakerState = 2 // 차단: 요청하지 않고 다음 단계로 넘김
+)
+
+// String 로그용 상태 이름
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerHalfOpen:
+		return "half_open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker 모델별 서킷 브레이커
+// 연속 실패가 threshold에 이르면 cooldown 동안 요청을 막고, 이후 확인 요청 1개가 성공하면 다시 연다.
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool // half-open 상태에서 확인 요청 진행 중
+}
+
+// NewCircuitBreaker 새 서킷 브레이커 생성 (threshold가 0 이하면 차단하지 않음)
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration) *CircuitBreaker {
+	b := &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+	llmCircuitState.WithLabelValues(name).Set(float64(BreakerClosed))
+	return b
+}
+
+// State 현재 상태 (cooldown이 지난 open은 half-open으로 표시)
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Allow 요청 가능 여부 (half-open이면 확인 요청 1개만 허용)
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.setState(BreakerHalfOpen)
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success 요청 성공 기록 (연속 실패 초기화, half-open이면 닫음)
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+	b.setState(BreakerClosed)
+}
+
+// Failure 요청 실패 기록 (threshold 도달 또는 확인 요청 실패 시 차단)
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.threshold <= 0 {
+		return
+	}
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// Abort 결과를 알 수 없는 요청(호출 측 취소) 종료 처리 (확인 요청 자리만 반납)
+func (b *CircuitBreaker) Abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// setState 상태 변경 및 메트릭 갱신 (b.mu 보유 상태에서 호출)
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	llmCircuitState.WithLabelValues(b.name).Set(float64(state))
+	llmCircuitTransitions.WithLabelValues(b.name, state.String()).Inc()
+}
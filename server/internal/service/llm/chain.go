@@ -0,0 +1,244 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	// 서킷 브레이커 상태 (0: closed, 1: half-open, 2: open)
+	llmCircuitState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ojeomneo_llm_circuit_state",
+			Help: "LLM circuit breaker state per tier (0=closed, 1=half-open, 2=open)",
+		},
+		[]string{"tier"},
+	)
+
+	// 서킷 브레이커 상태 전환 수
+	llmCircuitTransitions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ojeomneo_llm_circuit_transitions_total",
+			Help: "Total number of LLM circuit breaker state transitions",
+		},
+		[]string{"tier", "state"},
+	)
+
+	// 단계별 요청 결과 (success, error, rejected: 브레이커 차단으로 건너뜀)
+	llmRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ojeomneo_llm_requests_total",
+			Help: "Total number of LLM calls per tier and result",
+		},
+		[]string{"tier", "operation", "result"},
+	)
+
+	// 재시도 수
+	llmRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ojeomneo_llm_retries_total",
+			Help: "Total number of LLM call retries per tier",
+		},
+		[]string{"tier", "operation"},
+	)
+)
+
+// ErrCircuitOpen 서킷 브레이커가 열려 요청하지 않음
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Tier 대체 순서의 한 단계 (제공자 + 모델)
+type Tier struct {
+	Name     string // 응답과 메트릭에 표시되는 이름 (예: gemini:gemini-1.5-flash, mock)
+	Provider Provider
+	breaker  *CircuitBreaker
+}
+
+// ChainConfig 재시도, 서킷 브레이커 설정
+type ChainConfig struct {
+	MaxRetries       int           // 단계별 재시도 횟수 (첫 시도 제외)
+	RetryBaseDelay   time.Duration // 첫 재시도 대기 시간 (이후 2배씩, jitter 적용)
+	RetryMaxDelay    time.Duration // 재시도 대기 상한 (Retry-After가 더 길면 다음 단계로 넘김)
+	BreakerThreshold int           // 브레이커를 여는 연속 실패 횟수 (0이면 사용 안 함)
+	BreakerCooldown  time.Duration // 브레이커를 연 뒤 확인 요청까지 대기 시간
+}
+
+// Chain 재시도, 모델별 서킷 브레이커, 대체 순서를 적용한 제공자
+// 앞 단계가 실패하거나 차단되면 다음 단계(보조 모델, 목업 등)로 넘어가며, 분석 결과의 Tier에 처리한 단계를 기록한다.
+type Chain struct {
+	tiers  []*Tier
+	cfg    ChainConfig
+	logger *zap.Logger
+}
+
+// NewChain 새 대체 순서 제공자 생성 (tiers 순서대로 시도)
+func NewChain(cfg ChainConfig, logger *zap.Logger, tiers ...Tier) *Chain {
+	chain := &Chain{cfg: cfg, logger: logger}
+	for i := range tiers {
+		tier := tiers[i]
+		tier.breaker = NewCircuitBreaker(tier.Name, cfg.BreakerThreshold, cfg.BreakerCooldown)
+		chain.tiers = append(chain.tiers, &tier)
+	}
+	return chain
+}
+
+// Name 제공자 이름
+func (c *Chain) Name() string {
+	return "chain"
+}
+
+// BreakerState 단계별 브레이커 상태 (상태 확인용)
+func (c *Chain) BreakerState(tierName string) (BreakerState, bool) {
+	for _, tier := range c.tiers {
+		if tier.Name == tierName {
+			return tier.breaker.State(), true
+		}
+	}
+	return BreakerClosed, false
+}
+
+// AnalyzeSketch 단계 순서대로 스케치 분석 (처리한 단계를 결과의 Tier에 기록)
+func (c *Chain) AnalyzeSketch(ctx context.Context, imageData []byte, inputText string) (*AnalysisResult, error) {
+	var result *AnalysisResult
+	tierName, err := c.call(ctx, "analyze", func(p Provider) error {
+		var err error
+		result, err = p.AnalyzeSketch(ctx, imageData, inputText)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Tier = tierName
+	return result, nil
+}
+
+// GenerateRecommendationReason 단계 순서대로 추천 이유 생성
+func (c *Chain) GenerateRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string) (string, error) {
+	var reason string
+	_, err := c.call(ctx, "reason", func(p Provider) error {
+		var err error
+		reason, err = p.GenerateRecommendationReason(ctx, emotion, keywords, menuName)
+		return err
+	})
+	return reason, err
+}
+
+// StreamRecommendationReason 단계 순서대로 추천 이유를 토큰 단위로 생성
+// 이미 조각을 보낸 뒤 실패하면 이후 시도는 조각 없이 완성본만 생성한다 (클라이언트는 완성본으로 덮어씀).
+func (c *Chain) StreamRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string, onDelta func(delta string)) (string, error) {
+	var reason string
+	streamed := false
+	_, err := c.call(ctx, "reason", func(p Provider) error {
+		var err error
+		if streamer, ok := p.(ReasonStreamer); ok && !streamed {
+			reason, err = streamer.StreamRecommendationReason(ctx, emotion, keywords, menuName, func(delta string) {
+				streamed = true
+				onDelta(delta)
+			})
+		} else {
+			reason, err = p.GenerateRecommendationReason(ctx, emotion, keywords, menuName)
+		}
+		return err
+	})
+	return reason, err
+}
+
+// call 단계 순서대로 fn 실행 후 성공한 단계 이름 반환
+func (c *Chain) call(ctx context.Context, operation string, fn func(p Provider) error) (string, error) {
+	var lastErr error
+	for i, tier := range c.tiers {
+		if !tier.breaker.Allow() {
+			llmRequestsTotal.WithLabelValues(tier.Name, operation, "rejected").Inc()
+			lastErr = fmt.Errorf("%s: %w", tier.Name, ErrCircuitOpen)
+			continue
+		}
+
+		err := c.retry(ctx, tier, operation, fn)
+		if err == nil {
+			tier.breaker.Success()
+			llmRequestsTotal.WithLabelValues(tier.Name, operation, "success").Inc()
+			if i > 0 {
+				c.logger.Warn("LLM served by fallback tier",
+					zap.String("tier", tier.Name),
+					zap.String("operation", operation),
+					zap.NamedError("primary_error", lastErr),
+				)
+			}
+			return tier.Name, nil
+		}
+
+		// 호출 측 취소/시간 초과는 제공자 장애가 아니므로 브레이커에 기록하지 않고 중단
+		if ctx.Err() != nil {
+			tier.breaker.Abort()
+			return "", err
+		}
+
+		tier.breaker.Failure()
+		llmRequestsTotal.WithLabelValues(tier.Name, operation, "error").Inc()
+		c.logger.Warn("LLM tier failed",
+			zap.Error(err),
+			zap.String("tier", tier.Name),
+			zap.String("operation", operation),
+			zap.String("breaker", tier.breaker.State().String()),
+		)
+		lastErr = fmt.Errorf("%s: %w", tier.Name, err)
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no LLM tiers configured")
+	}
+	return "", fmt.Errorf("all LLM tiers failed: %w", lastErr)
+}
+
+// retry 재시도 가능한 오류면 jitter를 적용한 지수 backoff로 다시 시도
+func (c *Chain) retry(ctx context.Context, tier *Tier, operation string, fn func(p Provider) error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn(tier.Provider)
+		if err == nil || attempt >= c.cfg.MaxRetries || !IsRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		delay, ok := c.backoff(attempt, err)
+		if !ok {
+			return err
+		}
+
+		llmRetriesTotal.WithLabelValues(tier.Name, operation).Inc()
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff attempt번째 재시도 대기 시간 (RetryBaseDelay * 2^attempt의 50~100%, Retry-After 우선)
+// Retry-After가 RetryMaxDelay보다 길면 재시도하지 않는다 (false).
+func (c *Chain) backoff(attempt int, err error) (time.Duration, bool) {
+	delay := c.cfg.RetryBaseDelay << attempt
+	if delay <= 0 || delay > c.cfg.RetryMaxDelay {
+		delay = c.cfg.RetryMaxDelay
+	}
+	if delay > 0 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if apiErr.RetryAfter > c.cfg.RetryMaxDelay {
+			return 0, false
+		}
+		if apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+	}
+	return delay, true
+}
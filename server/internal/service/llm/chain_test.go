@@ -0,0 +1,212 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// failingProvider 항상 지정한 오류를 반환하는 테스트용 제공자
+type failingProvider struct {
+	err   error
+	calls atomic.Int32
+}
+
+func (p *failingProvider) Name() string { return "failing" }
+
+func (p *failingProvider) AnalyzeSketch(ctx context.Context, imageData []byte, inputText string) (*AnalysisResult, error) {
+	p.calls.Add(1)
+	return nil, p.err
+}
+
+func (p *failingProvider) GenerateRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string) (string, error) {
+	p.calls.Add(1)
+	return "", p.err
+}
+
+func testChainConfig() ChainConfig {
+	return ChainConfig{
+		MaxRetries:       2,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    10 * time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+	}
+}
+
+func TestChain_AnalyzeSketch(t *testing.T) {
+	t.Run("503 응답은 재시도 후 성공", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				http.Error(w, "overloaded", http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"choices":[{"message":{"content":"{\"emotion\": \"설레는\", \"keywords\": [\"봄\"], \"mood\": \"bright\"}"}}]}`)
+		}))
+		defer server.Close()
+
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "openai:test-retry", Provider: NewOpenAIClient(server.URL, "", "test")},
+			Tier{Name: "mock", Provider: NewMockProvider()},
+		)
+		result, err := chain.AnalyzeSketch(context.Background(), []byte("png"), "")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), attempts.Load())
+		assert.Equal(t, "설레는", result.Emotion)
+		assert.Equal(t, "openai:test-retry", result.Tier)
+	})
+
+	t.Run("재시도 소진 시 다음 단계로 대체", func(t *testing.T) {
+		primary := &failingProvider{err: &APIError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"}}
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "primary:test-fallback", Provider: primary},
+			Tier{Name: "mock", Provider: NewMockProvider()},
+		)
+
+		result, err := chain.AnalyzeSketch(context.Background(), []byte("png"), "")
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), primary.calls.Load(), "첫 시도 + 재시도 2회")
+		assert.Equal(t, "mock", result.Tier)
+	})
+
+	t.Run("재시도 불가 오류는 바로 다음 단계로", func(t *testing.T) {
+		primary := &failingProvider{err: &APIError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}}
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "primary:test-no-retry", Provider: primary},
+			Tier{Name: "mock", Provider: NewMockProvider()},
+		)
+
+		result, err := chain.AnalyzeSketch(context.Background(), []byte("png"), "")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), primary.calls.Load())
+		assert.Equal(t, "mock", result.Tier)
+	})
+
+	t.Run("Retry-After가 상한보다 길면 기다리지 않고 대체", func(t *testing.T) {
+		primary := &failingProvider{err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}}
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "primary:test-retry-after", Provider: primary},
+			Tier{Name: "mock", Provider: NewMockProvider()},
+		)
+
+		result, err := chain.AnalyzeSketch(context.Background(), []byte("png"), "")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), primary.calls.Load())
+		assert.Equal(t, "mock", result.Tier)
+	})
+
+	t.Run("모든 단계 실패", func(t *testing.T) {
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "primary:test-all-fail", Provider: &failingProvider{err: errors.New("boom")}},
+		)
+
+		_, err := chain.AnalyzeSketch(context.Background(), []byte("png"), "")
+		assert.ErrorContains(t, err, "all LLM tiers failed")
+		assert.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestChain_CircuitBreaker(t *testing.T) {
+	t.Run("연속 실패 시 차단되어 호출하지 않음", func(t *testing.T) {
+		primary := &failingProvider{err: errors.New("boom")}
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "primary:test-breaker", Provider: primary},
+			Tier{Name: "mock", Provider: NewMockProvider()},
+		)
+
+		for i := 0; i < 2; i++ {
+			_, err := chain.AnalyzeSketch(context.Background(), []byte("png"), "")
+			require.NoError(t, err)
+		}
+		state, ok := chain.BreakerState("primary:test-breaker")
+		require.True(t, ok)
+		assert.Equal(t, BreakerOpen, state)
+
+		result, err := chain.AnalyzeSketch(context.Background(), []byte("png"), "")
+		require.NoError(t, err)
+		assert.Equal(t, "mock", result.Tier)
+		assert.Equal(t, int32(2), primary.calls.Load(), "차단 중에는 주 제공자를 호출하지 않음")
+	})
+
+	t.Run("호출 측 취소는 실패로 기록하지 않음", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "primary:test-cancel", Provider: &failingProvider{err: context.Canceled}},
+			Tier{Name: "mock", Provider: NewMockProvider()},
+		)
+
+		for i := 0; i < 3; i++ {
+			_, err := chain.AnalyzeSketch(ctx, []byte("png"), "")
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+		state, _ := chain.BreakerState("primary:test-cancel")
+		assert.Equal(t, BreakerClosed, state)
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker("test-half-open", 1, 30*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	breaker.Failure()
+	assert.Equal(t, BreakerOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+
+	t.Run("대기 시간 경과 후 확인 요청 1개만 허용", func(t *testing.T) {
+		now = now.Add(31 * time.Second)
+		assert.True(t, breaker.Allow())
+		assert.False(t, breaker.Allow())
+	})
+
+	t.Run("확인 요청 실패 시 다시 차단", func(t *testing.T) {
+		breaker.Failure()
+		assert.Equal(t, BreakerOpen, breaker.State())
+		assert.False(t, breaker.Allow())
+	})
+
+	t.Run("확인 요청 성공 시 정상화", func(t *testing.T) {
+		now = now.Add(31 * time.Second)
+		require.True(t, breaker.Allow())
+		breaker.Success()
+		assert.Equal(t, BreakerClosed, breaker.State())
+		assert.True(t, breaker.Allow())
+		assert.True(t, breaker.Allow())
+	})
+}
+
+func TestChain_StreamRecommendationReason(t *testing.T) {
+	t.Run("주 제공자 실패 시 대체 단계에서 스트리밍", func(t *testing.T) {
+		chain := NewChain(testChainConfig(), zap.NewNop(),
+			Tier{Name: "primary:test-stream", Provider: &failingProvider{err: errors.New("boom")}},
+			Tier{Name: "mock", Provider: NewMockProvider()},
+		)
+
+		var deltas []string
+		reason, err := chain.StreamRecommendationReason(context.Background(), "피곤한", []string{"따뜻함"}, "칼국수", func(delta string) {
+			deltas = append(deltas, delta)
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, deltas)
+		assert.Contains(t, reason, "면발")
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsRetryable(fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusServiceUnavailable})))
+	assert.False(t, IsRetryable(&APIError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(nil))
+}
@@ -0,0 +1,69 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrContentBlocked 안전 필터 등으로 응답이 차단됨 (같은 입력으로 재시도해도 결과가 같음)
+	ErrContentBlocked = errors.New("response blocked by provider")
+	// ErrResponseTruncated 최대 출력 토큰에 도달해 응답이 잘림
+	ErrResponseTruncated = errors.New("response truncated at max output tokens")
+	// ErrInvalidAnalysis 분석 결과가 형식에 맞지 않아 보정할 수 없음
+	ErrInvalidAnalysis = errors.New("invalid analysis result")
+)
+
+// APIError 제공자 API의 200 이외 응답
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	RetryAfter time.Duration // Retry-After 헤더 (초 단위만 지원, 없으면 0)
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %s - %s", e.Status, e.Body)
+}
+
+// newAPIError 응답 상태와 본문으로 APIError 생성
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
+
+// IsRetryable 같은 제공자에 다시 요청하면 성공할 수 있는 오류인지 여부
+// 요청 한도 초과(429), 일시적인 서버 오류(500, 502, 503, 504), 네트워크 오류/타임아웃이 해당된다.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
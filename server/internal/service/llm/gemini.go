@@ -1,29 +1,32 @@
 package llm
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"regexp"
 	"strings"
 	"time"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
 )
 
-// Client Gemini API 클라이언트
-type Client struct {
+// GeminiClient Gemini API 제공자
+type GeminiClient struct {
 	apiKey     string
 	model      string
 	httpClient *http.Client
 	baseURL    string
 }
 
-// NewClient 새 Gemini 클라이언트 생성
-func NewClient(apiKey, model string) *Client {
-	return &Client{
+// NewGeminiClient 새 Gemini 클라이언트 생성
+func NewGeminiClient(apiKey, model string) *GeminiClient {
+	return &GeminiClient{
 		apiKey: apiKey,
 		model:  model,
 		httpClient: &http.Client{
@@ -33,135 +36,257 @@ func NewClient(apiKey, model string) *Client {
 	}
 }
 
-// AnalysisResult 스케치 분석 결과
-type AnalysisResult struct {
-	Emotion  string   `json:"emotion"`
-	Keywords []string `json:"keywords"`
-	Mood     string   `json:"mood"`
+// Name 제공자 이름
+func (c *GeminiClient) Name() string {
+	return "gemini"
 }
 
-// AnalyzeSketch 스케치 이미지를 분석하여 감정/키워드/분위기 추출
-func (c *Client) AnalyzeSketch(ctx context.Context, imageData []byte, inputText string) (*AnalysisResult, error) {
-	if c.apiKey == "" {
-		return c.mockAnalysis(inputText), nil
-	}
+// geminiRequest generateContent 요청
+type geminiRequest struct {
+	SystemInstruction *geminiContent         `json:"system_instruction,omitempty"`
+	Contents          []geminiContent        `json:"contents"`
+	GenerationConfig  geminiGenerationConfig `json:"generationConfig"`
+}
 
-	base64Image := base64.StdEncoding.EncodeToString(imageData)
+// geminiContent 메시지 (요청/응답 공통)
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
 
-	systemPrompt := `당신은 감성적인 음식 추천가입니다. 사용자가 그린 그림이나 낙서를 보고
-그 순간의 기분, 감정, 분위기를 따뜻하게 읽어주세요.
+// geminiPart 메시지 파트 (텍스트 또는 인라인 이미지)
+type geminiPart struct {
+	Text       string            `json:"text,omitempty"`
+	InlineData *geminiInlineData `json:"inline_data,omitempty"`
+}
 
-정확한 분석보다는 공감과 위로를 담은 해석을 해주세요.`
+// geminiInlineData base64 인코딩된 인라인 데이터
+type geminiInlineData struct {
+	MimeType string `json:"mime_type"`
+	Data     string `json:"data"`
+}
 
-	userPrompt := `이 그림을 보고 다음을 분석해주세요:
+// geminiGenerationConfig 생성 설정 (responseSchema 지정 시 JSON 모드)
+type geminiGenerationConfig struct {
+	Temperature      float64       `json:"temperature"`
+	MaxOutputTokens  int           `json:"maxOutputTokens"`
+	ResponseMimeType string        `json:"responseMimeType,omitempty"`
+	ResponseSchema   *geminiSchema `json:"responseSchema,omitempty"`
+}
+
+// geminiSchema 응답 JSON 스키마 (OpenAPI 스키마의 부분집합)
+type geminiSchema struct {
+	Type       string                   `json:"type"`
+	Enum       []string                 `json:"enum,omitempty"`
+	Items      *geminiSchema            `json:"items,omitempty"`
+	Properties map[string]*geminiSchema `json:"properties,omitempty"`
+	Required   []string                 `json:"required,omitempty"`
+}
 
-1. 그림에서 느껴지는 감정 (한 문장, 예: "피곤하고 위로받고 싶은")
-2. 연상되는 키워드 3개 (음식과 연관지을 수 있는 것들)
-3. 분위기 (bright/calm/dark 중 하나)
+// geminiResponse generateContent 응답 (스트리밍 조각도 같은 형태)
+type geminiResponse struct {
+	Candidates     []geminiCandidate     `json:"candidates"`
+	PromptFeedback *geminiPromptFeedback `json:"promptFeedback,omitempty"`
+}
 
-반드시 아래 JSON 형식으로만 응답해주세요:
-{"emotion": "...", "keywords": ["...", "...", "..."], "mood": "..."}`
+// geminiCandidate 응답 후보
+type geminiCandidate struct {
+	Content      *geminiContent `json:"content,omitempty"`
+	FinishReason string         `json:"finishReason,omitempty"`
+}
 
-	if inputText != "" {
-		userPrompt = fmt.Sprintf(`사용자가 그림과 함께 다음 메시지를 남겼습니다: "%s"
+// geminiPromptFeedback 프롬프트 자체가 차단된 경우의 사유
+type geminiPromptFeedback struct {
+	BlockReason string `json:"blockReason,omitempty"`
+}
 
-%s`, inputText, userPrompt)
+// analysisSchema 스케치 분석 응답 스키마 (분위기는 model.AnalysisMood 값으로 제한)
+var analysisSchema = &geminiSchema{
+	Type: "OBJECT",
+	Properties: map[string]*geminiSchema{
+		"emotion":  {Type: "STRING"},
+		"keywords": {Type: "ARRAY", Items: &geminiSchema{Type: "STRING"}},
+		"mood": {Type: "STRING", Enum: []string{
+			string(model.MoodBright), string(model.MoodCalm), string(model.MoodDark),
+		}},
+	},
+	Required: []string{"emotion", "keywords", "mood"},
+}
+
+// text 첫 번째 후보의 텍스트 (차단/길이 초과는 ErrContentBlocked/ErrResponseTruncated)
+// 길이 초과여도 받은 만큼의 텍스트는 함께 반환한다.
+func (r *geminiResponse) text() (string, error) {
+	if r.PromptFeedback != nil && r.PromptFeedback.BlockReason != "" {
+		return "", fmt.Errorf("%w: prompt blocked (%s)", ErrContentBlocked, r.PromptFeedback.BlockReason)
+	}
+	if len(r.Candidates) == 0 {
+		return "", fmt.Errorf("no candidates in response")
 	}
 
-	reqBody := map[string]interface{}{
-		"system_instruction": map[string]interface{}{
-			"parts": []map[string]string{
-				{"text": systemPrompt},
-			},
+	candidate := r.Candidates[0]
+	var text strings.Builder
+	if candidate.Content != nil {
+		for _, part := range candidate.Content.Parts {
+			text.WriteString(part.Text)
+		}
+	}
+
+	switch candidate.FinishReason {
+	case "", "STOP":
+	case "MAX_TOKENS":
+		return text.String(), ErrResponseTruncated
+	case "SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT", "SPII", "IMAGE_SAFETY":
+		return "", fmt.Errorf("%w: finish reason %s", ErrContentBlocked, candidate.FinishReason)
+	default:
+		if text.Len() == 0 {
+			return "", fmt.Errorf("no text in candidate (finish reason %s)", candidate.FinishReason)
+		}
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no text in candidate")
+	}
+	return text.String(), nil
+}
+
+// AnalyzeSketch 스케치 이미지를 분석하여 감정/키워드/분위기 추출
+// responseSchema로 JSON 응답을 강제하고, 파싱한 결과를 검증/보정한다.
+func (c *GeminiClient) AnalyzeSketch(ctx context.Context, imageData []byte, inputText string) (*AnalysisResult, error) {
+	reqBody := geminiRequest{
+		SystemInstruction: &geminiContent{
+			Parts: []geminiPart{{Text: sketchSystemPrompt}},
 		},
-		"contents": []map[string]interface{}{
+		Contents: []geminiContent{
 			{
-				"parts": []map[string]interface{}{
-					{"text": userPrompt},
-					{
-						"inline_data": map[string]string{
-							"mime_type": "image/png",
-							"data":      base64Image,
-						},
-					},
+				Parts: []geminiPart{
+					{Text: sketchUserPrompt(inputText)},
+					{InlineData: &geminiInlineData{
+						MimeType: "image/png",
+						Data:     base64.StdEncoding.EncodeToString(imageData),
+					}},
 				},
 			},
 		},
-		"generationConfig": map[string]interface{}{
-			"temperature":     0.7,
-			"maxOutputTokens": 500,
+		GenerationConfig: geminiGenerationConfig{
+			Temperature:      0.7,
+			MaxOutputTokens:  500,
+			ResponseMimeType: "application/json",
+			ResponseSchema:   analysisSchema,
 		},
 	}
 
-	respBody, err := c.doRequest(ctx, reqBody)
+	resp, err := c.doRequest(ctx, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze sketch: %w", err)
 	}
 
-	content, err := c.extractContent(respBody)
+	// 잘린 JSON은 파싱할 수 없으므로 길이 초과도 실패로 처리
+	content, err := resp.text()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to analyze sketch: %w", err)
 	}
 
-	// Gemini 응답에서 JSON 추출 (마크다운 코드 블록 처리)
-	jsonContent := extractJSON(content)
+	return parseAnalysis(content)
+}
 
-	var result AnalysisResult
-	if err := json.Unmarshal([]byte(jsonContent), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse analysis result: %w (raw: %s)", err, content)
+// GenerateRecommendationReason 메뉴 추천 이유 생성
+func (c *GeminiClient) GenerateRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string) (string, error) {
+	resp, err := c.doRequest(ctx, reasonRequestBody(emotion, keywords, menuName))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate reason: %w", err)
 	}
 
-	return &result, nil
+	// 길이 초과로 끊긴 추천 이유는 받은 만큼 사용
+	content, err := resp.text()
+	if err != nil && !(errors.Is(err, ErrResponseTruncated) && content != "") {
+		return "", fmt.Errorf("failed to generate reason: %w", err)
+	}
+
+	return strings.TrimSpace(content), nil
 }
 
-// GenerateRecommendationReason 메뉴 추천 이유 생성
-func (c *Client) GenerateRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string) (string, error) {
-	if c.apiKey == "" {
-		return c.mockReason(emotion, menuName), nil
+// StreamRecommendationReason 메뉴 추천 이유를 토큰 단위로 생성 (streamGenerateContent)
+// 조각이 도착할 때마다 onDelta를 호출하고, 완성된 추천 이유를 반환한다.
+func (c *GeminiClient) StreamRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string, onDelta func(delta string)) (string, error) {
+	resp, err := c.post(ctx, "streamGenerateContent", "alt=sse&", reasonRequestBody(emotion, keywords, menuName))
+	if err != nil {
+		return "", fmt.Errorf("failed to stream reason: %w", err)
 	}
+	defer resp.Body.Close()
 
-	prompt := fmt.Sprintf(`감정: %s
-키워드: %v
+	// alt=sse 응답: 조각마다 "data: {GenerateContentResponse}" 한 줄
+	var reason strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
 
-위 상태의 사람에게 어울리는 음식으로 "%s"을 추천합니다.
-왜 이 음식이 어울리는지 2문장 이내로 따뜻하고 공감가는 문체로 설명해주세요.
-설명만 출력하고 다른 텍스트는 포함하지 마세요.`, emotion, keywords, menuName)
+		var chunk geminiResponse
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &chunk); err != nil {
+			return "", fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
 
-	reqBody := map[string]interface{}{
-		"contents": []map[string]interface{}{
-			{
-				"parts": []map[string]string{
-					{"text": prompt},
-				},
-			},
+		// 마지막 조각은 text 없이 finishReason만 올 수 있음, 차단되면 중단
+		text, err := chunk.text()
+		if errors.Is(err, ErrContentBlocked) {
+			return "", fmt.Errorf("failed to stream reason: %w", err)
+		}
+		if text == "" {
+			continue
+		}
+		reason.WriteString(text)
+		onDelta(text)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	if reason.Len() == 0 {
+		return "", fmt.Errorf("no text in stream response")
+	}
+	return strings.TrimSpace(reason.String()), nil
+}
+
+// reasonRequestBody 추천 이유 생성 요청 본문 (일반/스트리밍 공통)
+func reasonRequestBody(emotion string, keywords []string, menuName string) geminiRequest {
+	return geminiRequest{
+		Contents: []geminiContent{
+			{Parts: []geminiPart{{Text: reasonPrompt(emotion, keywords, menuName)}}},
 		},
-		"generationConfig": map[string]interface{}{
-			"temperature":     0.8,
-			"maxOutputTokens": 200,
+		GenerationConfig: geminiGenerationConfig{
+			Temperature:     0.8,
+			MaxOutputTokens: 200,
 		},
 	}
+}
 
-	respBody, err := c.doRequest(ctx, reqBody)
+// doRequest generateContent 요청 후 응답 파싱
+func (c *GeminiClient) doRequest(ctx context.Context, body geminiRequest) (*geminiResponse, error) {
+	resp, err := c.post(ctx, "generateContent", "", body)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate reason: %w", err)
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	content, err := c.extractContent(respBody)
-	if err != nil {
-		return "", err
+	var result geminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return content, nil
+	return &result, nil
 }
 
-// doRequest HTTP 요청 실행
-func (c *Client) doRequest(ctx context.Context, body map[string]interface{}) (map[string]interface{}, error) {
+// post models/{model}:{method} 요청 (200이 아니면 응답 본문을 담은 에러 반환)
+func (c *GeminiClient) post(ctx context.Context, method, query string, body geminiRequest) (*http.Response, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/models/%s:generateContent?key=%s", c.baseURL, c.model, c.apiKey)
+	url := fmt.Sprintf("%s/models/%s:%s?%skey=%s", c.baseURL, c.model, method, query, c.apiKey)
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, err
@@ -173,107 +298,17 @@ func (c *Client) doRequest(ctx context.Context, body map[string]interface{}) (ma
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	respData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s - %s", resp.Status, string(respData))
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(respData, &result); err != nil {
-		return nil, err
-	}
-
-	return result, nil
-}
-
-// extractContent Gemini 응답에서 content 추출
-func (c *Client) extractContent(resp map[string]interface{}) (string, error) {
-	candidates, ok := resp["candidates"].([]interface{})
-	if !ok || len(candidates) == 0 {
-		return "", fmt.Errorf("no candidates in response")
+		defer resp.Body.Close()
+		respData, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, respData)
 	}
 
-	candidate := candidates[0].(map[string]interface{})
-	content, ok := candidate["content"].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("no content in candidate")
-	}
-
-	parts, ok := content["parts"].([]interface{})
-	if !ok || len(parts) == 0 {
-		return "", fmt.Errorf("no parts in content")
-	}
-
-	part := parts[0].(map[string]interface{})
-	text, ok := part["text"].(string)
-	if !ok {
-		return "", fmt.Errorf("no text in part")
-	}
-
-	return text, nil
-}
-
-// mockAnalysis API 키가 없을 때 사용하는 목업 응답
-func (c *Client) mockAnalysis(inputText string) *AnalysisResult {
-	if inputText != "" {
-		return &AnalysisResult{
-			Emotion:  "뭔가 특별한 것을 원하는",
-			Keywords: []string{"기대감", "설렘", "새로움"},
-			Mood:     "bright",
-		}
-	}
-
-	return &AnalysisResult{
-		Emotion:  "피곤하고 위로받고 싶은",
-		Keywords: []string{"따뜻함", "포근함", "집밥"},
-		Mood:     "calm",
-	}
-}
-
-// mockReason API 키가 없을 때 사용하는 목업 추천 이유
-func (c *Client) mockReason(emotion, menuName string) string {
-	reasons := map[string]string{
-		"된장찌개":  "지친 하루 끝에 따뜻한 국물 한 숟갈은 마음까지 녹여줄 거예요. 엄마가 끓여주신 것 같은 그 맛이 오늘 당신에게 필요한 위로예요.",
-		"칼국수":   "따끈한 면발이 속을 편하게 해줄 거예요. 한 그릇 비우고 나면 마음도 한결 가벼워질 거예요.",
-		"김치찌개":  "칼칼한 국물이 정신을 번쩍 들게 해줄 거예요. 밥 한 공기 뚝딱 비우고 나면 활력이 생길 거예요.",
-		"삼겹살":   "고기 한 점의 행복이 오늘 하루의 피로를 날려줄 거예요. 스스로에게 주는 작은 선물이에요.",
-		"냉면":    "시원한 육수가 복잡한 머리를 말끔하게 정리해줄 거예요. 청량한 한 그릇이 당신의 기분을 상쾌하게 바꿔줄 거예요.",
-		"default": fmt.Sprintf("%s 한 그릇이 오늘 당신에게 딱 맞는 선택이에요. 맛있게 드시고 힘내세요!", menuName),
-	}
-
-	if reason, ok := reasons[menuName]; ok {
-		return reason
-	}
-	return reasons["default"]
+	return resp, nil
 }
 
 // IsAvailable API 키가 설정되어 있는지 확인
-func (c *Client) IsAvailable() bool {
+func (c *GeminiClient) IsAvailable() bool {
 	return c.apiKey != ""
 }
-
-// extractJSON Gemini 응답에서 JSON 부분만 추출
-// 마크다운 코드 블록(```json ... ```) 또는 일반 JSON 모두 처리
-func extractJSON(content string) string {
-	content = strings.TrimSpace(content)
-
-	// 마크다운 코드 블록에서 JSON 추출 (```json ... ``` 또는 ``` ... ```)
-	codeBlockPattern := regexp.MustCompile("(?s)```(?:json)?\\s*(.+?)```")
-	if matches := codeBlockPattern.FindStringSubmatch(content); len(matches) > 1 {
-		return strings.TrimSpace(matches[1])
-	}
-
-	// { ... } 형태의 JSON 객체 추출
-	jsonPattern := regexp.MustCompile(`(?s)\{.+\}`)
-	if match := jsonPattern.FindString(content); match != "" {
-		return match
-	}
-
-	return content
-}
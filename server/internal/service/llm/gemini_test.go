@@ -2,87 +2,41 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestClient_NewClient(t *testing.T) {
+func TestGeminiClient_NewGeminiClient(t *testing.T) {
 	t.Run("API 키 있는 클라이언트 생성", func(t *testing.T) {
-		client := NewClient("test-api-key", "gemini-1.5-flash")
+		client := NewGeminiClient("test-api-key", "gemini-1.5-flash")
 		assert.True(t, client.IsAvailable())
 		assert.Equal(t, "gemini-1.5-flash", client.model)
 	})
 
-	t.Run("API 키 없는 클라이언트 생성", func(t *testing.T) {
-		client := NewClient("", "gemini-1.5-flash")
-		assert.False(t, client.IsAvailable())
+	t.Run("제공자 이름", func(t *testing.T) {
+		client := NewGeminiClient("test-api-key", "gemini-1.5-flash")
+		assert.Equal(t, "gemini", client.Name())
 	})
 }
 
-func TestClient_IsAvailable(t *testing.T) {
+func TestGeminiClient_IsAvailable(t *testing.T) {
 	t.Run("API 키 있으면 true", func(t *testing.T) {
-		client := NewClient("AIzaSy-test-key", "gemini-1.5-flash")
+		client := NewGeminiClient("AIzaSy-test-key", "gemini-1.5-flash")
 		assert.True(t, client.IsAvailable())
 	})
 
 	t.Run("API 키 없으면 false", func(t *testing.T) {
-		client := NewClient("", "gemini-1.5-flash")
+		client := NewGeminiClient("", "gemini-1.5-flash")
 		assert.False(t, client.IsAvailable())
 	})
 }
 
-func TestClient_MockAnalysis(t *testing.T) {
-	client := NewClient("", "gemini-1.5-flash")
-	ctx := context.Background()
-
-	t.Run("입력 텍스트 없는 경우 기본 응답", func(t *testing.T) {
-		result, err := client.AnalyzeSketch(ctx, []byte{}, "")
-		require.NoError(t, err)
-		assert.Equal(t, "피곤하고 위로받고 싶은", result.Emotion)
-		assert.Contains(t, result.Keywords, "따뜻함")
-		assert.Contains(t, result.Keywords, "포근함")
-		assert.Contains(t, result.Keywords, "집밥")
-		assert.Equal(t, "calm", result.Mood)
-	})
-
-	t.Run("입력 텍스트 있는 경우", func(t *testing.T) {
-		result, err := client.AnalyzeSketch(ctx, []byte{}, "오늘 기분이 좋아요")
-		require.NoError(t, err)
-		assert.Equal(t, "뭔가 특별한 것을 원하는", result.Emotion)
-		assert.Contains(t, result.Keywords, "기대감")
-		assert.Contains(t, result.Keywords, "설렘")
-		assert.Contains(t, result.Keywords, "새로움")
-		assert.Equal(t, "bright", result.Mood)
-	})
-}
-
-func TestClient_MockReason(t *testing.T) {
-	client := NewClient("", "gemini-1.5-flash")
-	ctx := context.Background()
-
-	t.Run("된장찌개 추천 이유", func(t *testing.T) {
-		reason, err := client.GenerateRecommendationReason(ctx, "피곤한", []string{"위로"}, "된장찌개")
-		require.NoError(t, err)
-		assert.Contains(t, reason, "따뜻한 국물")
-		assert.Contains(t, reason, "위로")
-	})
-
-	t.Run("칼국수 추천 이유", func(t *testing.T) {
-		reason, err := client.GenerateRecommendationReason(ctx, "피곤한", []string{"위로"}, "칼국수")
-		require.NoError(t, err)
-		assert.Contains(t, reason, "면발")
-	})
-
-	t.Run("알 수 없는 메뉴는 기본 추천 이유", func(t *testing.T) {
-		reason, err := client.GenerateRecommendationReason(ctx, "피곤한", []string{"위로"}, "알수없는메뉴")
-		require.NoError(t, err)
-		assert.Contains(t, reason, "알수없는메뉴")
-		assert.Contains(t, reason, "딱 맞는 선택")
-	})
-}
-
 func TestAnalysisResult_Structure(t *testing.T) {
 	result := &AnalysisResult{
 		Emotion:  "피곤하고 위로받고 싶은",
@@ -132,3 +86,171 @@ func TestExtractJSON(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestGeminiClient_StreamRecommendationReason(t *testing.T) {
+	t.Run("streamGenerateContent 조각을 순서대로 전달", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/models/gemini-1.5-flash:streamGenerateContent", r.URL.Path)
+			assert.Equal(t, "sse", r.URL.Query().Get("alt"))
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, chunk := range []string{
+				`{"candidates":[{"content":{"parts":[{"text":"따뜻한 국물이 "}],"role":"model"}}]}`,
+				`{"candidates":[{"content":{"parts":[{"text":"위로가 될 거예요."}],"role":"model"}}]}`,
+				`{"candidates":[{"finishReason":"STOP"}]}`,
+			} {
+				fmt.Fprintf(w, "data: %s\r\n\r\n", chunk)
+			}
+		}))
+		defer server.Close()
+
+		client := NewGeminiClient("test-api-key", "gemini-1.5-flash")
+		client.baseURL = server.URL
+
+		var deltas []string
+		reason, err := client.StreamRecommendationReason(context.Background(), "피곤한", []string{"위로"}, "된장찌개", func(delta string) {
+			deltas = append(deltas, delta)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"따뜻한 국물이 ", "위로가 될 거예요."}, deltas)
+		assert.Equal(t, "따뜻한 국물이 위로가 될 거예요.", reason)
+	})
+
+	t.Run("API 오류", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":{"code":429}}`, http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := NewGeminiClient("test-api-key", "gemini-1.5-flash")
+		client.baseURL = server.URL
+
+		_, err := client.StreamRecommendationReason(context.Background(), "피곤한", []string{"위로"}, "된장찌개", func(string) {})
+		assert.ErrorContains(t, err, "429")
+	})
+}
+
+// newTestGeminiServer 고정된 generateContent 응답을 반환하는 테스트 서버
+func newTestGeminiServer(t *testing.T, response string) (*GeminiClient, func()) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, response)
+	}))
+
+	client := NewGeminiClient("test-api-key", "gemini-1.5-flash")
+	client.baseURL = server.URL
+	return client, server.Close
+}
+
+func TestGeminiClient_AnalyzeSketch(t *testing.T) {
+	t.Run("responseSchema로 JSON 모드 요청", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/models/gemini-1.5-flash:generateContent", r.URL.Path)
+
+			var req geminiRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "application/json", req.GenerationConfig.ResponseMimeType)
+			require.NotNil(t, req.GenerationConfig.ResponseSchema)
+			assert.Equal(t, []string{"bright", "calm", "dark"}, req.GenerationConfig.ResponseSchema.Properties["mood"].Enum)
+			require.Len(t, req.Contents, 1)
+			require.Len(t, req.Contents[0].Parts, 2)
+			assert.Equal(t, "image/png", req.Contents[0].Parts[1].InlineData.MimeType)
+
+			fmt.Fprint(w, `{"candidates":[{"content":{"parts":[{"text":"{\"emotion\": \"설레는\", \"keywords\": [\"봄\", \"소풍\", \"김밥\"], \"mood\": \"bright\"}"}]},"finishReason":"STOP"}]}`)
+		}))
+		defer server.Close()
+
+		client := NewGeminiClient("test-api-key", "gemini-1.5-flash")
+		client.baseURL = server.URL
+
+		result, err := client.AnalyzeSketch(context.Background(), []byte("png"), "")
+		require.NoError(t, err)
+		assert.Equal(t, "설레는", result.Emotion)
+		assert.Equal(t, []string{"봄", "소풍", "김밥"}, result.Keywords)
+		assert.Equal(t, "bright", result.Mood)
+	})
+
+	t.Run("안전 필터로 차단된 후보", func(t *testing.T) {
+		client, closeServer := newTestGeminiServer(t, `{"candidates":[{"finishReason":"SAFETY","safetyRatings":[{"category":"HARM_CATEGORY_HARASSMENT","probability":"HIGH"}]}]}`)
+		defer closeServer()
+
+		_, err := client.AnalyzeSketch(context.Background(), []byte("png"), "")
+		assert.ErrorIs(t, err, ErrContentBlocked)
+		assert.False(t, IsRetryable(err))
+	})
+
+	t.Run("프롬프트 차단 (후보 없음)", func(t *testing.T) {
+		client, closeServer := newTestGeminiServer(t, `{"promptFeedback":{"blockReason":"SAFETY"}}`)
+		defer closeServer()
+
+		_, err := client.AnalyzeSketch(context.Background(), []byte("png"), "")
+		assert.ErrorIs(t, err, ErrContentBlocked)
+	})
+
+	t.Run("최대 토큰 도달로 잘린 JSON", func(t *testing.T) {
+		client, closeServer := newTestGeminiServer(t, `{"candidates":[{"content":{"parts":[{"text":"{\"emotion\": \"설레"}]},"finishReason":"MAX_TOKENS"}]}`)
+		defer closeServer()
+
+		_, err := client.AnalyzeSketch(context.Background(), []byte("png"), "")
+		assert.ErrorIs(t, err, ErrResponseTruncated)
+	})
+
+	t.Run("예상과 다른 응답 형태도 panic 없이 에러", func(t *testing.T) {
+		client, closeServer := newTestGeminiServer(t, `{"candidates":[{"content":{"parts":[]}}]}`)
+		defer closeServer()
+
+		_, err := client.AnalyzeSketch(context.Background(), []byte("png"), "")
+		assert.Error(t, err)
+	})
+}
+
+func TestGeminiClient_GenerateRecommendationReason(t *testing.T) {
+	t.Run("길이 초과로 끊긴 추천 이유는 받은 만큼 사용", func(t *testing.T) {
+		client, closeServer := newTestGeminiServer(t, `{"candidates":[{"content":{"parts":[{"text":"따뜻한 국물이 "}]},"finishReason":"MAX_TOKENS"}]}`)
+		defer closeServer()
+
+		reason, err := client.GenerateRecommendationReason(context.Background(), "피곤한", []string{"위로"}, "된장찌개")
+		require.NoError(t, err)
+		assert.Equal(t, "따뜻한 국물이", reason)
+	})
+
+	t.Run("차단된 응답", func(t *testing.T) {
+		client, closeServer := newTestGeminiServer(t, `{"candidates":[{"finishReason":"RECITATION"}]}`)
+		defer closeServer()
+
+		_, err := client.GenerateRecommendationReason(context.Background(), "피곤한", []string{"위로"}, "된장찌개")
+		assert.ErrorIs(t, err, ErrContentBlocked)
+	})
+}
+
+func TestParseAnalysis(t *testing.T) {
+	t.Run("키워드 공백/중복 제거 후 3개까지 사용", func(t *testing.T) {
+		result, err := parseAnalysis(`{"emotion": " 피곤한 ", "keywords": ["따뜻함", " ", "따뜻함", "포근함", "집밥", "국물"], "mood": "calm"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "피곤한", result.Emotion)
+		assert.Equal(t, []string{"따뜻함", "포근함", "집밥"}, result.Keywords)
+	})
+
+	t.Run("분위기 대소문자 정규화", func(t *testing.T) {
+		result, err := parseAnalysis(`{"emotion": "행복한", "keywords": ["기쁨"], "mood": " Bright "}`)
+		require.NoError(t, err)
+		assert.Equal(t, "bright", result.Mood)
+	})
+
+	t.Run("알 수 없는 분위기는 calm으로 보정", func(t *testing.T) {
+		result, err := parseAnalysis(`{"emotion": "행복한", "keywords": ["기쁨"], "mood": "밝음"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "calm", result.Mood)
+	})
+
+	t.Run("키워드가 없으면 에러", func(t *testing.T) {
+		_, err := parseAnalysis(`{"emotion": "행복한", "keywords": ["", " "], "mood": "bright"}`)
+		assert.ErrorIs(t, err, ErrInvalidAnalysis)
+	})
+
+	t.Run("감정이 없으면 에러", func(t *testing.T) {
+		_, err := parseAnalysis(`{"keywords": ["기쁨"], "mood": "bright"}`)
+		assert.ErrorIs(t, err, ErrInvalidAnalysis)
+	})
+}
@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MockProvider 외부 API 없이 고정된 결과를 반환하는 목업 제공자 (개발, CI용)
+type MockProvider struct{}
+
+// NewMockProvider 새 목업 제공자 생성
+func NewMockProvider() *MockProvider {
+	return &MockProvider{}
+}
+
+// Name 제공자 이름
+func (m *MockProvider) Name() string {
+	return "mock"
+}
+
+// AnalyzeSketch 입력 텍스트 유무에 따라 고정된 분석 결과 반환
+func (m *MockProvider) AnalyzeSketch(ctx context.Context, imageData []byte, inputText string) (*AnalysisResult, error) {
+	if inputText != "" {
+		return &AnalysisResult{
+			Emotion:  "뭔가 특별한 것을 원하는",
+			Keywords: []string{"기대감", "설렘", "새로움"},
+			Mood:     "bright",
+		}, nil
+	}
+
+	return &AnalysisResult{
+		Emotion:  "피곤하고 위로받고 싶은",
+		Keywords: []string{"따뜻함", "포근함", "집밥"},
+		Mood:     "calm",
+	}, nil
+}
+
+// GenerateRecommendationReason 메뉴별 고정된 추천 이유 반환
+func (m *MockProvider) GenerateRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string) (string, error) {
+	reasons := map[string]string{
+		"된장찌개":    "지친 하루 끝에 따뜻한 국물 한 숟갈은 마음까지 녹여줄 거예요. 엄마가 끓여주신 것 같은 그 맛이 오늘 당신에게 필요한 위로예요.",
+		"칼국수":     "따끈한 면발이 속을 편하게 해줄 거예요. 한 그릇 비우고 나면 마음도 한결 가벼워질 거예요.",
+		"김치찌개":    "칼칼한 국물이 정신을 번쩍 들게 해줄 거예요. 밥 한 공기 뚝딱 비우고 나면 활력이 생길 거예요.",
+		"삼겹살":     "고기 한 점의 행복이 오늘 하루의 피로를 날려줄 거예요. 스스로에게 주는 작은 선물이에요.",
+		"냉면":      "시원한 육수가 복잡한 머리를 말끔하게 정리해줄 거예요. 청량한 한 그릇이 당신의 기분을 상쾌하게 바꿔줄 거예요.",
+		"default": fmt.Sprintf("%s 한 그릇이 오늘 당신에게 딱 맞는 선택이에요. 맛있게 드시고 힘내세요!", menuName),
+	}
+
+	if reason, ok := reasons[menuName]; ok {
+		return reason, nil
+	}
+	return reasons["default"], nil
+}
+
+// StreamRecommendationReason 고정된 추천 이유를 어절 단위로 나눠 전달 (스트리밍 흐름 재현)
+func (m *MockProvider) StreamRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string, onDelta func(delta string)) (string, error) {
+	reason, err := m.GenerateRecommendationReason(ctx, emotion, keywords, menuName)
+	if err != nil {
+		return "", err
+	}
+	for _, word := range strings.SplitAfter(reason, " ") {
+		onDelta(word)
+	}
+	return reason, nil
+}
@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockProvider_AnalyzeSketch(t *testing.T) {
+	client := NewMockProvider()
+	ctx := context.Background()
+
+	t.Run("입력 텍스트 없는 경우 기본 응답", func(t *testing.T) {
+		result, err := client.AnalyzeSketch(ctx, []byte{}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "피곤하고 위로받고 싶은", result.Emotion)
+		assert.Contains(t, result.Keywords, "따뜻함")
+		assert.Contains(t, result.Keywords, "포근함")
+		assert.Contains(t, result.Keywords, "집밥")
+		assert.Equal(t, "calm", result.Mood)
+	})
+
+	t.Run("입력 텍스트 있는 경우", func(t *testing.T) {
+		result, err := client.AnalyzeSketch(ctx, []byte{}, "오늘 기분이 좋아요")
+		require.NoError(t, err)
+		assert.Equal(t, "뭔가 특별한 것을 원하는", result.Emotion)
+		assert.Contains(t, result.Keywords, "기대감")
+		assert.Contains(t, result.Keywords, "설렘")
+		assert.Contains(t, result.Keywords, "새로움")
+		assert.Equal(t, "bright", result.Mood)
+	})
+}
+
+func TestMockProvider_GenerateRecommendationReason(t *testing.T) {
+	client := NewMockProvider()
+	ctx := context.Background()
+
+	t.Run("된장찌개 추천 이유", func(t *testing.T) {
+		reason, err := client.GenerateRecommendationReason(ctx, "피곤한", []string{"위로"}, "된장찌개")
+		require.NoError(t, err)
+		assert.Contains(t, reason, "따뜻한 국물")
+		assert.Contains(t, reason, "위로")
+	})
+
+	t.Run("칼국수 추천 이유", func(t *testing.T) {
+		reason, err := client.GenerateRecommendationReason(ctx, "피곤한", []string{"위로"}, "칼국수")
+		require.NoError(t, err)
+		assert.Contains(t, reason, "면발")
+	})
+
+	t.Run("알 수 없는 메뉴는 기본 추천 이유", func(t *testing.T) {
+		reason, err := client.GenerateRecommendationReason(ctx, "피곤한", []string{"위로"}, "알수없는메뉴")
+		require.NoError(t, err)
+		assert.Contains(t, reason, "알수없는메뉴")
+		assert.Contains(t, reason, "딱 맞는 선택")
+	})
+}
+
+func TestMockProvider_StreamRecommendationReason(t *testing.T) {
+	t.Run("목업 추천 이유를 어절 단위로 나눠 전달", func(t *testing.T) {
+		client := NewMockProvider()
+
+		var streamed strings.Builder
+		reason, err := client.StreamRecommendationReason(context.Background(), "피곤한", []string{"위로"}, "칼국수", func(delta string) {
+			streamed.WriteString(delta)
+		})
+		require.NoError(t, err)
+		assert.Contains(t, reason, "면발")
+		assert.Equal(t, reason, streamed.String())
+	})
+}
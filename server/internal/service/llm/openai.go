@@ -0,0 +1,212 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIClient OpenAI 호환 Chat Completions API 제공자
+// OpenAI 외에 같은 API를 제공하는 Ollama, vLLM 등 자체 호스팅 모델 서버에도 사용한다.
+type OpenAIClient struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewOpenAIClient 새 OpenAI 호환 클라이언트 생성
+// baseURL은 /chat/completions 앞까지의 주소 (예: https://api.openai.com/v1, http://ollama:11434/v1),
+// apiKey가 비어 있으면 Authorization 헤더를 보내지 않는다 (인증 없는 로컬 모델 서버용).
+func NewOpenAIClient(baseURL, apiKey, model string) *OpenAIClient {
+	return &OpenAIClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		httpClient: &http.Client{
+			// 로컬 모델은 첫 요청 시 모델 로딩으로 느릴 수 있음
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// chatMessage Chat Completions 메시지 (content는 문자열 또는 text/image_url 파트 배열)
+type chatMessage struct {
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"`
+}
+
+// chatRequest Chat Completions 요청
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+	MaxTokens   int           `json:"max_tokens"`
+	Stream      bool          `json:"stream,omitempty"`
+}
+
+// chatResponse Chat Completions 응답 (스트리밍 조각은 message 대신 delta)
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// Name 제공자 이름
+func (c *OpenAIClient) Name() string {
+	return "openai"
+}
+
+// AnalyzeSketch 스케치 이미지를 분석하여 감정/키워드/분위기 추출 (vision 지원 모델 필요)
+func (c *OpenAIClient) AnalyzeSketch(ctx context.Context, imageData []byte, inputText string) (*AnalysisResult, error) {
+	dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(imageData)
+
+	content, err := c.complete(ctx, chatRequest{
+		Model: c.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: sketchSystemPrompt},
+			{Role: "user", Content: []map[string]interface{}{
+				{"type": "text", "text": sketchUserPrompt(inputText)},
+				{"type": "image_url", "image_url": map[string]string{"url": dataURL}},
+			}},
+		},
+		Temperature: 0.7,
+		MaxTokens:   500,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze sketch: %w", err)
+	}
+
+	return parseAnalysis(content)
+}
+
+// GenerateRecommendationReason 메뉴 추천 이유 생성
+func (c *OpenAIClient) GenerateRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string) (string, error) {
+	content, err := c.complete(ctx, c.reasonRequest(emotion, keywords, menuName, false))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate reason: %w", err)
+	}
+
+	return strings.TrimSpace(content), nil
+}
+
+// StreamRecommendationReason 메뉴 추천 이유를 토큰 단위로 생성 (stream: true)
+func (c *OpenAIClient) StreamRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string, onDelta func(delta string)) (string, error) {
+	resp, err := c.post(ctx, c.reasonRequest(emotion, keywords, menuName, true))
+	if err != nil {
+		return "", fmt.Errorf("failed to stream reason: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 조각마다 "data: {chat.completion.chunk}" 한 줄, 마지막은 "data: [DONE]"
+	var reason strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk chatResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		text := chunk.Choices[0].Delta.Content
+		reason.WriteString(text)
+		onDelta(text)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	if reason.Len() == 0 {
+		return "", fmt.Errorf("no text in stream response")
+	}
+	return strings.TrimSpace(reason.String()), nil
+}
+
+// reasonRequest 추천 이유 생성 요청 (일반/스트리밍 공통)
+func (c *OpenAIClient) reasonRequest(emotion string, keywords []string, menuName string, stream bool) chatRequest {
+	return chatRequest{
+		Model: c.model,
+		Messages: []chatMessage{
+			{Role: "user", Content: reasonPrompt(emotion, keywords, menuName)},
+		},
+		Temperature: 0.8,
+		MaxTokens:   200,
+		Stream:      stream,
+	}
+}
+
+// complete 요청 후 첫 번째 choice의 메시지 내용 반환
+func (c *OpenAIClient) complete(ctx context.Context, body chatRequest) (string, error) {
+	resp, err := c.post(ctx, body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("no choices in response")
+	}
+	if result.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("no content in choice")
+	}
+
+	return result.Choices[0].Message.Content, nil
+}
+
+// post /chat/completions 요청 (200이 아니면 응답 본문을 담은 에러 반환)
+func (c *OpenAIClient) post(ctx context.Context, body chatRequest) (*http.Response, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		respData, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, respData)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIClient_AnalyzeSketch(t *testing.T) {
+	t.Run("vision 메시지로 요청하고 JSON 응답 파싱", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+			assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+
+			var req struct {
+				Model    string `json:"model"`
+				Messages []struct {
+					Role    string          `json:"role"`
+					Content json.RawMessage `json:"content"`
+				} `json:"messages"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "gpt-4o-mini", req.Model)
+			require.Len(t, req.Messages, 2)
+			assert.Equal(t, "system", req.Messages[0].Role)
+			assert.Contains(t, string(req.Messages[1].Content), `"type":"image_url"`)
+			assert.Contains(t, string(req.Messages[1].Content), "data:image/png;base64,")
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"`+"```json\\n"+`{\"emotion\": \"설레는\", \"keywords\": [\"봄\"], \"mood\": \"bright\"}`+"\\n```"+`"}}]}`)
+		}))
+		defer server.Close()
+
+		client := NewOpenAIClient(server.URL+"/v1/", "sk-test", "gpt-4o-mini")
+		result, err := client.AnalyzeSketch(context.Background(), []byte("png"), "")
+		require.NoError(t, err)
+		assert.Equal(t, "설레는", result.Emotion)
+		assert.Equal(t, []string{"봄"}, result.Keywords)
+		assert.Equal(t, "bright", result.Mood)
+	})
+
+	t.Run("API 오류", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":"model not found"}`, http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewOpenAIClient(server.URL, "", "llava")
+		_, err := client.AnalyzeSketch(context.Background(), []byte("png"), "")
+		assert.ErrorContains(t, err, "model not found")
+	})
+}
+
+func TestOpenAIClient_RecommendationReason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 로컬 모델 서버용: API 키가 없으면 Authorization 헤더 없음
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		var req struct {
+			Stream bool `json:"stream"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		if !req.Stream {
+			fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":" 따뜻한 국물이 위로가 될 거예요. "}}]}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			`{"choices":[{"delta":{"role":"assistant"}}]}`,
+			`{"choices":[{"delta":{"content":"따뜻한 국물이 "}}]}`,
+			`{"choices":[{"delta":{"content":"위로가 될 거예요."}}]}`,
+			`[DONE]`,
+		} {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient(server.URL, "", "llama3.2")
+
+	t.Run("추천 이유 생성", func(t *testing.T) {
+		reason, err := client.GenerateRecommendationReason(context.Background(), "피곤한", []string{"위로"}, "된장찌개")
+		require.NoError(t, err)
+		assert.Equal(t, "따뜻한 국물이 위로가 될 거예요.", reason)
+	})
+
+	t.Run("스트리밍 조각을 순서대로 전달", func(t *testing.T) {
+		var deltas []string
+		reason, err := client.StreamRecommendationReason(context.Background(), "피곤한", []string{"위로"}, "된장찌개", func(delta string) {
+			deltas = append(deltas, delta)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"따뜻한 국물이 ", "위로가 될 거예요."}, deltas)
+		assert.Equal(t, "따뜻한 국물이 위로가 될 거예요.", reason)
+	})
+}
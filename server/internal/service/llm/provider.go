@@ -0,0 +1,158 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// Provider 스케치 분석과 추천 이유 생성을 제공하는 LLM 백엔드
+// Gemini, OpenAI 호환 API(OpenAI, Ollama, vLLM 등), 오프라인 목업 구현이 있다.
+type Provider interface {
+	// Name 로그/메트릭용 제공자 이름
+	Name() string
+	// AnalyzeSketch 스케치 이미지를 분석하여 감정/키워드/분위기 추출
+	AnalyzeSketch(ctx context.Context, imageData []byte, inputText string) (*AnalysisResult, error)
+	// GenerateRecommendationReason 메뉴 추천 이유 생성
+	GenerateRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string) (string, error)
+}
+
+// ReasonStreamer 추천 이유를 토큰 단위로 생성할 수 있는 제공자 (선택 구현)
+type ReasonStreamer interface {
+	// StreamRecommendationReason 조각이 도착할 때마다 onDelta를 호출하고 완성된 추천 이유 반환
+	StreamRecommendationReason(ctx context.Context, emotion string, keywords []string, menuName string, onDelta func(delta string)) (string, error)
+}
+
+// 제공자별 인터페이스 구현 확인
+var (
+	_ Provider       = (*GeminiClient)(nil)
+	_ Provider       = (*OpenAIClient)(nil)
+	_ Provider       = (*MockProvider)(nil)
+	_ Provider       = (*Chain)(nil)
+	_ ReasonStreamer = (*GeminiClient)(nil)
+	_ ReasonStreamer = (*OpenAIClient)(nil)
+	_ ReasonStreamer = (*MockProvider)(nil)
+	_ ReasonStreamer = (*Chain)(nil)
+)
+
+// AnalysisResult 스케치 분석 결과
+type AnalysisResult struct {
+	Emotion  string   `json:"emotion"`
+	Keywords []string `json:"keywords"`
+	Mood     string   `json:"mood"`
+	Tier     string   `json:"tier,omitempty"` // 분석을 처리한 단계 (Chain 사용 시, 예: gemini:gemini-1.5-flash, mock)
+}
+
+// sketchSystemPrompt 스케치 분석 시스템 프롬프트 (제공자 공통)
+const sketchSystemPrompt = `당신은 감성적인 음식 추천가입니다. 사용자가 그린 그림이나 낙서를 보고
+그 순간의 기분, 감정, 분위기를 따뜻하게 읽어주세요.
+
+정확한 분석보다는 공감과 위로를 담은 해석을 해주세요.`
+
+// sketchUserPrompt 스케치 분석 요청 프롬프트 (사용자 메시지가 있으면 앞에 추가)
+func sketchUserPrompt(inputText string) string {
+	prompt := `이 그림을 보고 다음을 분석해주세요:
+
+1. 그림에서 느껴지는 감정 (한 문장, 예: "피곤하고 위로받고 싶은")
+2. 연상되는 키워드 3개 (음식과 연관지을 수 있는 것들)
+3. 분위기 (bright/calm/dark 중 하나)
+
+반드시 아래 JSON 형식으로만 응답해주세요:
+{"emotion": "...", "keywords": ["...", "...", "..."], "mood": "..."}`
+
+	if inputText != "" {
+		prompt = fmt.Sprintf(`사용자가 그림과 함께 다음 메시지를 남겼습니다: "%s"
+
+%s`, inputText, prompt)
+	}
+	return prompt
+}
+
+// reasonPrompt 추천 이유 생성 프롬프트 (제공자 공통)
+func reasonPrompt(emotion string, keywords []string, menuName string) string {
+	return fmt.Sprintf(`감정: %s
+키워드: %v
+
+위 상태의 사람에게 어울리는 음식으로 "%s"을 추천합니다.
+왜 이 음식이 어울리는지 2문장 이내로 따뜻하고 공감가는 문체로 설명해주세요.
+설명만 출력하고 다른 텍스트는 포함하지 마세요.`, emotion, keywords, menuName)
+}
+
+// analysisKeywordCount 분석 결과 키워드 수 (프롬프트에서 요청하는 개수)
+const analysisKeywordCount = 3
+
+// parseAnalysis 모델 응답 텍스트에서 분석 결과 JSON 파싱 후 검증/보정
+func parseAnalysis(content string) (*AnalysisResult, error) {
+	// 응답에서 JSON 추출 (마크다운 코드 블록 처리, JSON 모드 응답은 그대로)
+	jsonContent := extractJSON(content)
+
+	var result AnalysisResult
+	if err := json.Unmarshal([]byte(jsonContent), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse analysis result: %w (raw: %s)", err, content)
+	}
+
+	if err := normalizeAnalysis(&result); err != nil {
+		return nil, fmt.Errorf("%w (raw: %s)", err, content)
+	}
+	return &result, nil
+}
+
+// normalizeAnalysis 분석 결과 검증 및 보정
+// 감정과 키워드가 비어 있으면 메뉴 검색에 쓸 수 없으므로 ErrInvalidAnalysis,
+// 키워드는 공백/중복을 제거하고 3개까지만 사용, 분위기는 model.AnalysisMood가 아니면 calm으로 보정한다.
+func normalizeAnalysis(result *AnalysisResult) error {
+	result.Emotion = strings.TrimSpace(result.Emotion)
+	if result.Emotion == "" {
+		return fmt.Errorf("%w: empty emotion", ErrInvalidAnalysis)
+	}
+
+	keywords := make([]string, 0, analysisKeywordCount)
+	seen := make(map[string]bool, len(result.Keywords))
+	for _, keyword := range result.Keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		keywords = append(keywords, keyword)
+		if len(keywords) == analysisKeywordCount {
+			break
+		}
+	}
+	if len(keywords) == 0 {
+		return fmt.Errorf("%w: no keywords", ErrInvalidAnalysis)
+	}
+	result.Keywords = keywords
+
+	switch mood := model.AnalysisMood(strings.ToLower(strings.TrimSpace(result.Mood))); mood {
+	case model.MoodBright, model.MoodCalm, model.MoodDark:
+		result.Mood = string(mood)
+	default:
+		result.Mood = string(model.MoodCalm)
+	}
+	return nil
+}
+
+// extractJSON 모델 응답에서 JSON 부분만 추출
+// 마크다운 코드 블록(```json ... ```) 또는 일반 JSON 모두 처리
+func extractJSON(content string) string {
+	content = strings.TrimSpace(content)
+
+	// 마크다운 코드 블록에서 JSON 추출 (```json ... ``` 또는 ``` ... ```)
+	codeBlockPattern := regexp.MustCompile("(?s)```(?:json)?\\s*(.+?)```")
+	if matches := codeBlockPattern.FindStringSubmatch(content); len(matches) > 1 {
+		return strings.TrimSpace(matches[1])
+	}
+
+	// { ... } 형태의 JSON 객체 추출
+	jsonPattern := regexp.MustCompile(`(?s)\{.+\}`)
+	if match := jsonPattern.FindString(content); match != "" {
+		return match
+	}
+
+	return content
+}
@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/internal/telemetry"
+)
+
+// loginAttemptKeyPrefix 로그인 실패 기록 Redis 키 prefix
+const loginAttemptKeyPrefix = "auth:login:fail:"
+
+// 점진적 지연 설정: 연속 실패 3회부터 1초, 2초, 4초 ... 최대 30초
+const (
+	loginDelayStartFailures = 3
+	loginDelayMax           = 30 * time.Second
+)
+
+// LoginThrottleError 로그인 제한 오류 (잠금 또는 점진적 지연)
+type LoginThrottleError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+// Error 사용자 안내 메시지
+func (e *LoginThrottleError) Error() string {
+	if e.Locked {
+		minutes := int(math.Ceil(e.RetryAfter.Minutes()))
+		return fmt.Sprintf("로그인 시도가 너무 많아 일시적으로 잠겼습니다. %d분 후 다시 시도해 주세요", minutes)
+	}
+	return fmt.Sprintf("로그인 시도가 너무 많습니다. %d초 후 다시 시도해 주세요", e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds 재시도까지 남은 시간 (초, 올림) - Retry-After 헤더용
+func (e *LoginThrottleError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// loginAttemptState 키별 로그인 실패 상태
+type loginAttemptState struct {
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginThrottle 로그인 무차별 대입 방지
+// 계정(이메일)과 IP별로 실패 횟수를 집계하여 점진적 지연 후 일정 횟수 초과 시 잠금한다.
+// Redis가 있으면 Redis에, 없거나 오류 시 DB(login_attempts)에 기록한다.
+type LoginThrottle struct {
+	db      *gorm.DB
+	rdb     *redis.Client
+	logger  *zap.Logger
+	metrics *telemetry.AuthMetrics
+
+	maxPerAccount int
+	maxPerIP      int
+	window        time.Duration
+	lockout       time.Duration
+}
+
+// NewLoginThrottle 새 로그인 제한기 생성 (rdb, metrics는 nil 가능)
+func NewLoginThrottle(db *gorm.DB, rdb *redis.Client, cfg *config.Config, logger *zap.Logger, metrics *telemetry.AuthMetrics) *LoginThrottle {
+	return &LoginThrottle{
+		db:            db,
+		rdb:           rdb,
+		logger:        logger,
+		metrics:       metrics,
+		maxPerAccount: cfg.LoginMaxFailuresPerAccount,
+		maxPerIP:      cfg.LoginMaxFailuresPerIP,
+		window:        time.Duration(cfg.LoginFailureWindowMin) * time.Minute,
+		lockout:       time.Duration(cfg.LoginLockoutMin) * time.Minute,
+	}
+}
+
+// loginThrottleScope 집계 단위 (계정 또는 IP)
+type loginThrottleScope struct {
+	name        string
+	key         string
+	maxFailures int
+}
+
+// accountThrottleKey 계정 단위 집계 키
+func accountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(email)
+}
+
+// scopes 요청에 해당하는 집계 단위 목록 (IP가 없으면 계정만)
+func (t *LoginThrottle) scopes(email, ip string) []loginThrottleScope {
+	scopes := []loginThrottleScope{
+		{name: "account", key: accountThrottleKey(email), maxFailures: t.maxPerAccount},
+	}
+	if ip != "" {
+		scopes = append(scopes, loginThrottleScope{name: "ip", key: "ip:" + ip, maxFailures: t.maxPerIP})
+	}
+	return scopes
+}
+
+// Check 로그인 시도 가능 여부 확인 (잠금 또는 지연 중이면 *LoginThrottleError 반환)
+func (t *LoginThrottle) Check(email, ip string) error {
+	now := time.Now()
+
+	for _, scope := range t.scopes(email, ip) {
+		state, err := t.load(scope.key)
+		if err != nil {
+			// 저장소 오류 시 로그인 자체는 막지 않음 (fail-open)
+			t.logger.Warn("Login throttle lookup failed",
+				zap.Error(err),
+				zap.String("scope", scope.name),
+			)
+			continue
+		}
+		if state == nil {
+			continue
+		}
+
+		if state.LockedUntil != nil && now.Before(*state.LockedUntil) {
+			return &LoginThrottleError{RetryAfter: state.LockedUntil.Sub(now), Locked: true}
+		}
+
+		if wait := state.LastFailureAt.Add(progressiveDelay(state.Failures)).Sub(now); wait > 0 {
+			return &LoginThrottleError{RetryAfter: wait}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure 로그인 실패 기록 (기준 횟수 도달 시 잠금)
+func (t *LoginThrottle) RecordFailure(email, ip string) {
+	for _, scope := range t.scopes(email, ip) {
+		state, err := t.incr(scope.key, scope.maxFailures)
+		if err != nil {
+			t.logger.Warn("Failed to record login failure",
+				zap.Error(err),
+				zap.String("scope", scope.name),
+			)
+			continue
+		}
+
+		if state.Failures == scope.maxFailures {
+			t.logger.Warn("Login locked after repeated failures",
+				zap.String("scope", scope.name),
+				zap.String("key", scope.key),
+				zap.Int("failures", state.Failures),
+				zap.Duration("lockout", t.lockout),
+			)
+			if t.metrics != nil {
+				t.metrics.RecordLoginLockout(context.Background(), scope.name, "locked")
+			}
+		}
+	}
+}
+
+// RecordSuccess 로그인 성공 시 계정 실패 기록 초기화 (IP 기록은 유지)
+func (t *LoginThrottle) RecordSuccess(email string) {
+	key := accountThrottleKey(email)
+	if err := t.reset(key); err != nil {
+		t.logger.Warn("Failed to reset login failures",
+			zap.Error(err),
+		)
+	}
+}
+
+// Forget 계정 실패 기록 삭제 (탈퇴 계정 완전 삭제 시)
+func (t *LoginThrottle) Forget(email string) error {
+	return t.reset(accountThrottleKey(email))
+}
+
+// Unlock 계정 잠금 해제 (비밀번호 재설정 시)
+func (t *LoginThrottle) Unlock(email string) {
+	key := accountThrottleKey(email)
+
+	state, err := t.load(key)
+	if err != nil {
+		t.logger.Warn("Login throttle lookup failed", zap.Error(err))
+	}
+	if err := t.reset(key); err != nil {
+		t.logger.Warn("Failed to unlock account", zap.Error(err))
+		return
+	}
+
+	if state != nil && state.LockedUntil != nil && time.Now().Before(*state.LockedUntil) {
+		t.logger.Info("Login lock released by password reset")
+		if t.metrics != nil {
+			t.metrics.RecordLoginLockout(context.Background(), "account", "unlocked")
+		}
+	}
+}
+
+// Run 주기적으로 만료된 실패 기록 정리 (ctx 취소 시 종료)
+func (t *LoginThrottle) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := t.Cleanup(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			t.logger.Error("Login attempt cleanup failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cleanup 집계 기간이 지났거나 잠금이 끝난 DB 실패 기록 삭제, 삭제한 수 반환 (isStale과 같은 기준)
+// Redis 기록은 TTL로 만료되므로 DB fallback 저장소만 정리한다.
+func (t *LoginThrottle) Cleanup(ctx context.Context) (int64, error) {
+	now := time.Now()
+	result := t.db.WithContext(ctx).
+		Where("(locked_until IS NULL AND last_failure_at < ?) OR locked_until <= ?", now.Add(-t.window), now).
+		Delete(&model.LoginAttempt{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete login attempts: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		t.logger.Info("Login attempts cleaned up", zap.Int64("deleted", result.RowsAffected))
+	}
+	return result.RowsAffected, nil
+}
+
+// progressiveDelay 연속 실패 횟수에 따른 재시도 대기 시간
+func progressiveDelay(failures int) time.Duration {
+	if failures < loginDelayStartFailures {
+		return 0
+	}
+	shift := failures - loginDelayStartFailures
+	if shift > 5 {
+		return loginDelayMax
+	}
+	delay := time.Second << shift
+	if delay > loginDelayMax {
+		return loginDelayMax
+	}
+	return delay
+}
+
+// load 실패 상태 조회 (기록이 없거나 만료되었으면 nil)
+func (t *LoginThrottle) load(key string) (*loginAttemptState, error) {
+	if t.rdb != nil {
+		state, err := t.loadRedis(key)
+		if err == nil {
+			return state, nil
+		}
+		t.logger.Warn("Redis login throttle unavailable, falling back to database", zap.Error(err))
+	}
+	return t.loadDB(key)
+}
+
+// incr 실패 횟수 증가
+func (t *LoginThrottle) incr(key string, maxFailures int) (*loginAttemptState, error) {
+	if t.rdb != nil {
+		state, err := t.incrRedis(key, maxFailures)
+		if err == nil {
+			return state, nil
+		}
+		t.logger.Warn("Redis login throttle unavailable, falling back to database", zap.Error(err))
+	}
+	return t.incrDB(key, maxFailures)
+}
+
+// reset 실패 기록 삭제 (Redis와 DB 모두)
+func (t *LoginThrottle) reset(key string) error {
+	if t.rdb != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := t.rdb.Del(ctx, loginAttemptKeyPrefix+key).Err(); err != nil {
+			t.logger.Warn("Failed to reset Redis login failures", zap.Error(err))
+		}
+	}
+	return t.db.Where("key = ?", key).Delete(&model.LoginAttempt{}).Error
+}
+
+// loadRedis Redis 해시(failures, last_failure, locked_until)에서 상태 조회
+func (t *LoginThrottle) loadRedis(key string) (*loginAttemptState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	values, err := t.rdb.HGetAll(ctx, loginAttemptKeyPrefix+key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return parseRedisAttemptState(values), nil
+}
+
+// incrRedis Redis에 실패 기록 (키는 마지막 실패 후 window 또는 잠금 시간 동안 유지)
+func (t *LoginThrottle) incrRedis(key string, maxFailures int) (*loginAttemptState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	redisKey := loginAttemptKeyPrefix + key
+	now := time.Now()
+
+	var failuresCmd *redis.IntCmd
+	_, err := t.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failuresCmd = pipe.HIncrBy(ctx, redisKey, "failures", 1)
+		pipe.HSet(ctx, redisKey, "last_failure", now.UnixMilli())
+		pipe.Expire(ctx, redisKey, t.window)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := &loginAttemptState{
+		Failures:      int(failuresCmd.Val()),
+		LastFailureAt: now,
+	}
+
+	if state.Failures >= maxFailures {
+		lockedUntil := now.Add(t.lockout)
+		state.LockedUntil = &lockedUntil
+
+		// 잠금 해제 후에는 처음부터 다시 집계되도록 잠금 시간만큼만 유지
+		_, err := t.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, redisKey, "locked_until", lockedUntil.UnixMilli())
+			pipe.Expire(ctx, redisKey, t.lockout)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return state, nil
+}
+
+// parseRedisAttemptState Redis 해시 값을 상태로 변환
+func parseRedisAttemptState(values map[string]string) *loginAttemptState {
+	state := &loginAttemptState{}
+	state.Failures, _ = strconv.Atoi(values["failures"])
+	if ms, err := strconv.ParseInt(values["last_failure"], 10, 64); err == nil {
+		state.LastFailureAt = time.UnixMilli(ms)
+	}
+	if ms, err := strconv.ParseInt(values["locked_until"], 10, 64); err == nil {
+		lockedUntil := time.UnixMilli(ms)
+		state.LockedUntil = &lockedUntil
+	}
+	return state
+}
+
+// loadDB DB에서 상태 조회
+func (t *LoginThrottle) loadDB(key string) (*loginAttemptState, error) {
+	var attempt model.LoginAttempt
+	if err := t.db.Where("key = ?", key).First(&attempt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load login attempts: %w", err)
+	}
+
+	if t.isStale(&attempt, time.Now()) {
+		return nil, nil
+	}
+
+	return &loginAttemptState{
+		Failures:      attempt.Failures,
+		LastFailureAt: attempt.LastFailureAt,
+		LockedUntil:   attempt.LockedUntil,
+	}, nil
+}
+
+// incrDB DB에 실패 기록
+func (t *LoginThrottle) incrDB(key string, maxFailures int) (*loginAttemptState, error) {
+	var state *loginAttemptState
+	now := time.Now()
+
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		var attempt model.LoginAttempt
+		err := tx.Where("key = ?", key).First(&attempt).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) || t.isStale(&attempt, now) {
+			attempt = model.LoginAttempt{Key: key}
+		}
+
+		attempt.Failures++
+		attempt.LastFailureAt = now
+		if attempt.Failures >= maxFailures && !attempt.IsLocked() {
+			lockedUntil := now.Add(t.lockout)
+			attempt.LockedUntil = &lockedUntil
+		}
+
+		if err := tx.Save(&attempt).Error; err != nil {
+			return err
+		}
+
+		state = &loginAttemptState{
+			Failures:      attempt.Failures,
+			LastFailureAt: attempt.LastFailureAt,
+			LockedUntil:   attempt.LockedUntil,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return state, nil
+}
+
+// isStale 집계 기간이 지났거나 잠금이 끝난 기록인지 확인 (Redis TTL 만료와 동일한 기준)
+func (t *LoginThrottle) isStale(attempt *model.LoginAttempt, now time.Time) bool {
+	if attempt.LockedUntil != nil {
+		return !now.Before(*attempt.LockedUntil)
+	}
+	return now.Sub(attempt.LastFailureAt) > t.window
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+func TestProgressiveDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{0, 0},
+		{2, 0},
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{6, 8 * time.Second},
+		{8, 30 * time.Second},
+		{100, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("실패 %d회", tt.failures), func(t *testing.T) {
+			assert.Equal(t, tt.expected, progressiveDelay(tt.failures))
+		})
+	}
+}
+
+func TestLoginThrottle(t *testing.T) {
+	t.Run("계정 실패 횟수 초과 시 잠금", func(t *testing.T) {
+		svc, _ := setupAuthService(t)
+
+		for i := 0; i < svc.cfg.LoginMaxFailuresPerAccount; i++ {
+			svc.throttle.RecordFailure("lock@example.com", "10.0.0.1")
+		}
+
+		err := svc.throttle.Check("lock@example.com", "10.0.0.2")
+		var throttleErr *LoginThrottleError
+		require.ErrorAs(t, err, &throttleErr)
+		assert.True(t, throttleErr.Locked)
+		assert.Greater(t, throttleErr.RetryAfterSeconds(), 14*60)
+
+		// 대소문자만 다른 이메일도 같은 계정으로 집계
+		assert.Error(t, svc.throttle.Check("LOCK@example.com", "10.0.0.2"))
+	})
+
+	t.Run("IP 실패 횟수 초과 시 다른 계정도 차단", func(t *testing.T) {
+		svc, _ := setupAuthService(t)
+
+		for i := 0; i < svc.cfg.LoginMaxFailuresPerIP; i++ {
+			svc.throttle.RecordFailure(fmt.Sprintf("user%d@example.com", i), "10.0.0.3")
+		}
+
+		var throttleErr *LoginThrottleError
+		require.ErrorAs(t, svc.throttle.Check("other@example.com", "10.0.0.3"), &throttleErr)
+		assert.True(t, throttleErr.Locked)
+
+		assert.NoError(t, svc.throttle.Check("other@example.com", "10.0.0.4"))
+	})
+
+	t.Run("집계 기간이 지나면 초기화", func(t *testing.T) {
+		svc, db := setupAuthService(t)
+
+		for i := 0; i < 3; i++ {
+			svc.throttle.RecordFailure("window@example.com", "")
+		}
+		require.Error(t, svc.throttle.Check("window@example.com", ""))
+
+		require.NoError(t, db.Model(&model.LoginAttempt{}).
+			Where("key = ?", "account:window@example.com").
+			Update("last_failure_at", time.Now().Add(-time.Hour)).Error)
+
+		assert.NoError(t, svc.throttle.Check("window@example.com", ""))
+	})
+
+	t.Run("Unlock 시 잠금 해제", func(t *testing.T) {
+		svc, _ := setupAuthService(t)
+
+		for i := 0; i < svc.cfg.LoginMaxFailuresPerAccount; i++ {
+			svc.throttle.RecordFailure("unlock@example.com", "")
+		}
+		require.Error(t, svc.throttle.Check("unlock@example.com", ""))
+
+		svc.throttle.Unlock("unlock@example.com")
+		assert.NoError(t, svc.throttle.Check("unlock@example.com", ""))
+	})
+}
+
+func TestLoginThrottle_Cleanup(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	now := time.Now()
+	lockEnded := now.Add(-time.Minute)
+	locked := now.Add(10 * time.Minute)
+	attempts := []model.LoginAttempt{
+		{Key: "account:recent@example.com", Failures: 1, LastFailureAt: now.Add(-time.Minute)},
+		{Key: "account:old@example.com", Failures: 2, LastFailureAt: now.Add(-time.Hour)},
+		{Key: "ip:10.0.0.5", Failures: 20, LastFailureAt: now.Add(-time.Hour), LockedUntil: &lockEnded},
+		{Key: "ip:10.0.0.6", Failures: 20, LastFailureAt: now.Add(-time.Hour), LockedUntil: &locked},
+	}
+	for i := range attempts {
+		require.NoError(t, db.Create(&attempts[i]).Error)
+	}
+
+	t.Run("집계 기간이 지났거나 잠금이 끝난 기록만 삭제", func(t *testing.T) {
+		deleted, err := svc.throttle.Cleanup(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		var keys []string
+		require.NoError(t, db.Model(&model.LoginAttempt{}).Order("key").Pluck("key", &keys).Error)
+		assert.Equal(t, []string{"account:recent@example.com", "ip:10.0.0.6"}, keys)
+	})
+
+	t.Run("정리할 기록이 없으면 0 반환", func(t *testing.T) {
+		deleted, err := svc.throttle.Cleanup(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+}
+
+func TestAuthService_EmailLogin_Throttle(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "brute@example.com")
+	hashed, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	require.NoError(t, db.Model(user).Update("password", hashed).Error)
+
+	t.Run("연속 실패 후에는 올바른 비밀번호도 지연", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := svc.EmailLogin(&LoginRequest{Email: "brute@example.com", Password: "wrong", Client: ClientInfo{IP: "10.0.0.5"}})
+			require.Error(t, err)
+		}
+
+		_, err := svc.EmailLogin(&LoginRequest{Email: "brute@example.com", Password: "password123", Client: ClientInfo{IP: "10.0.0.5"}})
+		var throttleErr *LoginThrottleError
+		require.ErrorAs(t, err, &throttleErr)
+		assert.False(t, throttleErr.Locked)
+	})
+
+	t.Run("비밀번호 재설정 시 잠금 해제", func(t *testing.T) {
+		resetToken := "reset-token"
+		createVerifiedEmail(t, svc, db, "brute@example.com", resetToken, model.EmailPurposePasswordReset)
+
+		require.NoError(t, svc.PasswordResetConfirm("brute@example.com", "reset-token", "newpassword123"))
+
+		resp, err := svc.EmailLogin(&LoginRequest{Email: "brute@example.com", Password: "newpassword123", Client: ClientInfo{IP: "10.0.0.6"}})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, resp.User.ID)
+	})
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// magicLinkTTL 로그인 링크(인증코드) 유효 시간
+const magicLinkTTL = 15 * time.Minute
+
+// MagicLinkLoginRequest 로그인 링크(인증코드) 사용 요청
+type MagicLinkLoginRequest struct {
+	Email  string
+	Code   string
+	Client ClientInfo // 요청 기기 정보 (세션 기록용)
+}
+
+// RequestMagicLink 비밀번호 없는 로그인 링크 발송
+// 인증코드와 같은 발송 제한(재발송 대기, 이메일/IP별 일일 한도)을 적용하며, 링크에는 이메일과 인증코드가 담긴다.
+func (s *AuthService) RequestMagicLink(email string, client ClientInfo) error {
+	email = normalizeEmail(email)
+
+	code, err := s.issueEmailCode(email, client.IP, model.EmailPurposeMagicLink, magicLinkTTL)
+	if err != nil {
+		var throttleErr *EmailCodeThrottleError
+		if errors.As(err, &throttleErr) {
+			return err
+		}
+		s.logger.Error("Failed to create magic link",
+			zap.Error(err),
+			zap.String("email", email),
+		)
+		return fmt.Errorf("로그인 링크 생성에 실패했습니다: %w", err)
+	}
+
+	link := s.magicLinkURL(email, code)
+	if s.emailService != nil {
+		// 백그라운드에서 이메일 발송 (goroutine)
+		go func() {
+			if err := s.emailService.SendMagicLink(email, code, link); err != nil {
+				s.logger.Error("Failed to send magic link email",
+					zap.Error(err),
+					zap.String("email", email),
+				)
+			} else {
+				s.logger.Info("Magic link sent successfully",
+					zap.String("email", email),
+				)
+			}
+		}()
+	} else {
+		// SMTP 서비스가 없으면 발송 불가 기록, 인증코드/링크는 개발 환경의 디버그 로그에만 남김
+		s.logger.Warn("Magic link not sent (SMTP disabled)",
+			zap.String("email", email),
+		)
+		if s.cfg.AppEnv != "production" {
+			s.logger.Debug("Magic link generated",
+				zap.String("email", email),
+				zap.String("code", code),
+				zap.String("link", link),
+			)
+		}
+	}
+
+	return nil
+}
+
+// magicLinkURL MAGIC_LINK_URL에 email, code 쿼리를 붙인 로그인 링크 (미설정 시 빈 문자열)
+func (s *AuthService) magicLinkURL(email, code string) string {
+	if s.cfg.MagicLinkURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(s.cfg.MagicLinkURL)
+	if err != nil {
+		s.logger.Warn("Invalid MAGIC_LINK_URL, sending code only", zap.Error(err))
+		return ""
+	}
+	q := u.Query()
+	q.Set("email", email)
+	q.Set("code", code)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// MagicLinkLogin 로그인 링크(인증코드)로 로그인
+// 처음 사용하는 이메일이면 이메일 로그인 계정을 만들고(비밀번호 없음), 탈퇴 유예 기간 중인 계정은 복구한다.
+func (s *AuthService) MagicLinkLogin(req *MagicLinkLoginRequest) (*AuthResponse, error) {
+	email := normalizeEmail(req.Email)
+
+	token, err := s.redeemEmailCode(email, req.Code, model.EmailPurposeMagicLink)
+	if err != nil {
+		s.logger.Warn("Magic link login failed: invalid code",
+			zap.String("email", email),
+			zap.Error(err),
+		)
+		if errors.Is(err, ErrEmailCodeInvalid) || errors.Is(err, ErrEmailCodeAttemptsExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("로그인에 실패했습니다: %w", err)
+	}
+
+	var user model.User
+	created, restored := false, false
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		// 링크는 한 번만 사용 가능
+		verification, err := s.findVerifiedEmail(tx, email, token, model.EmailPurposeMagicLink)
+		if err != nil {
+			return err
+		}
+		if verification == nil {
+			return ErrEmailCodeInvalid
+		}
+		if err := tx.Delete(verification).Error; err != nil {
+			return fmt.Errorf("failed to consume magic link: %w", err)
+		}
+
+		found, err := s.findUserByIdentity(tx, model.LoginMethodEmail, email, email)
+		if err != nil {
+			return err
+		}
+		if found != nil {
+			user = *found
+			return nil
+		}
+
+		// 탈퇴 유예 기간 중인 계정은 복구
+		deleted, err := s.findDeletedUserByIdentity(tx, model.LoginMethodEmail, email)
+		if err != nil {
+			return err
+		}
+		if deleted != nil {
+			// 2단계 인증 사용 계정은 코드 확인 후 VerifyMFA에서 복구
+			if !deleted.IsActive || deleted.MFAEnabled() {
+				user = *deleted
+				return nil
+			}
+			if err := s.restoreAccount(tx, deleted); err != nil {
+				return err
+			}
+			user = *deleted
+			restored = true
+			return nil
+		}
+
+		// 다른 로그인 수단으로 가입된 이메일이면 충돌 정책 적용 (링크로 이메일 소유 확인됨)
+		linkedUser, err := s.resolveEmailCollision(tx, model.LoginMethodEmail, email, email, true)
+		if err != nil {
+			return err
+		}
+		if linkedUser != nil {
+			user = *linkedUser
+			return nil
+		}
+
+		// 처음 사용하는 이메일: 비밀번호 없는 이메일 계정 생성 (비밀번호 재설정으로 나중에 설정 가능)
+		user = model.User{
+			Username:    fmt.Sprintf("%s_email", strings.ReplaceAll(email, "@", "_at_")),
+			Email:       email,
+			LoginMethod: model.LoginMethodEmail,
+			IsActive:    true,
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		created = true
+		return s.createIdentity(tx, user.ID, model.LoginMethodEmail, email, email)
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrEmailCollision) || errors.Is(txErr, ErrEmailCodeInvalid) {
+			return nil, txErr
+		}
+		s.logger.Error("Magic link login failed",
+			zap.Error(txErr),
+			zap.String("email", email),
+		)
+		return nil, fmt.Errorf("로그인에 실패했습니다: %w", txErr)
+	}
+
+	// 사용자 활성화 확인
+	if !user.IsActive {
+		s.logger.Warn("Magic link login failed: user inactive",
+			zap.String("email", email),
+			zap.Uint("user_id", user.ID),
+		)
+		return nil, errors.New("비활성화된 계정입니다")
+	}
+
+	// 2단계 인증 사용 계정: 코드 확인 후 토큰 발급
+	if user.MFAEnabled() {
+		return s.issueMFAChallenge(&user)
+	}
+
+	// 마지막 로그인 시간 업데이트
+	now := time.Now()
+	if err := s.db.Model(&user).Update("last_login", &now).Error; err != nil {
+		s.logger.Warn("Failed to update last login",
+			zap.Error(err),
+			zap.Uint("user_id", user.ID),
+		)
+		// 로그인은 계속 진행
+	}
+
+	accessToken, refreshToken, err := s.issueSessionTokens(user.ID, model.LoginMethodEmail, req.Client)
+	if err != nil {
+		s.logger.Error("Failed to generate tokens",
+			zap.Error(err),
+			zap.Uint("user_id", user.ID),
+		)
+		return nil, fmt.Errorf("토큰 생성에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("Magic link login successful",
+		zap.Uint("user_id", user.ID),
+		zap.String("email", email),
+		zap.Bool("created", created),
+		zap.Bool("restored", restored),
+	)
+
+	return &AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "bearer",
+		User: &UserResponse{
+			ID:          user.ID,
+			Email:       user.Email,
+			IsActive:    user.IsActive,
+			DateJoined:  user.DateJoined,
+			LoginMethod: string(user.LoginMethod),
+		},
+		AccountRestored: restored,
+	}, nil
+}
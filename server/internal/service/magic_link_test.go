@@ -0,0 +1,111 @@
+package service
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+func TestAuthService_MagicLinkLogin(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	t.Run("처음 사용하는 이메일은 계정 생성 후 로그인", func(t *testing.T) {
+		code, err := svc.issueEmailCode("new@example.com", "10.0.0.1", model.EmailPurposeMagicLink, magicLinkTTL)
+		require.NoError(t, err)
+
+		resp, err := svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "new@Example.COM", Code: code})
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.Equal(t, "new@example.com", resp.User.Email)
+		assert.Equal(t, string(model.LoginMethodEmail), resp.User.LoginMethod)
+
+		var identity model.UserIdentity
+		require.NoError(t, db.Where("user_id = ? AND provider = ?", resp.User.ID, model.LoginMethodEmail).First(&identity).Error)
+
+		// 비밀번호가 없으므로 비밀번호 로그인 불가
+		_, err = svc.EmailLogin(&LoginRequest{Email: "new@example.com", Password: ""})
+		assert.Error(t, err)
+
+		t.Run("링크는 한 번만 사용 가능", func(t *testing.T) {
+			_, err := svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "new@example.com", Code: code})
+			assert.ErrorIs(t, err, ErrEmailCodeInvalid)
+		})
+
+		t.Run("다시 로그인하면 같은 계정", func(t *testing.T) {
+			code, err := svc.issueEmailCode("new@example.com", "10.0.0.1", model.EmailPurposeMagicLink, magicLinkTTL)
+			require.NoError(t, err)
+			again, err := svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "new@example.com", Code: code})
+			require.NoError(t, err)
+			assert.Equal(t, resp.User.ID, again.User.ID)
+		})
+	})
+
+	t.Run("기존 이메일 계정으로 로그인", func(t *testing.T) {
+		user := createTestUser(t, db, "member@example.com")
+		require.NoError(t, svc.createIdentity(db, user.ID, model.LoginMethodEmail, "member@example.com", "member@example.com"))
+
+		code, err := svc.issueEmailCode("member@example.com", "10.0.0.2", model.EmailPurposeMagicLink, magicLinkTTL)
+		require.NoError(t, err)
+		resp, err := svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "member@example.com", Code: code})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, resp.User.ID)
+	})
+
+	t.Run("회원가입 인증코드로는 로그인 불가", func(t *testing.T) {
+		code, err := svc.issueEmailCode("signup@example.com", "10.0.0.3", model.EmailPurposeSignup, magicLinkTTL)
+		require.NoError(t, err)
+
+		_, err = svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "signup@example.com", Code: code})
+		assert.ErrorIs(t, err, ErrEmailCodeInvalid)
+	})
+
+	t.Run("시도 횟수 초과 시 로그인 불가", func(t *testing.T) {
+		code, err := svc.issueEmailCode("guess@example.com", "10.0.0.4", model.EmailPurposeMagicLink, magicLinkTTL)
+		require.NoError(t, err)
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		for i := 0; i < svc.cfg.EmailCodeMaxAttempts; i++ {
+			_, err = svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "guess@example.com", Code: wrong})
+			require.Error(t, err)
+		}
+		_, err = svc.MagicLinkLogin(&MagicLinkLoginRequest{Email: "guess@example.com", Code: code})
+		assert.ErrorIs(t, err, ErrEmailCodeAttemptsExceeded)
+	})
+}
+
+func TestAuthService_RequestMagicLink(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	t.Run("인증코드와 같은 발송 제한 적용", func(t *testing.T) {
+		require.NoError(t, svc.RequestMagicLink("limit@example.com", ClientInfo{IP: "10.0.0.1"}))
+
+		err := svc.RequestMagicLink("limit@example.com", ClientInfo{IP: "10.0.0.1"})
+		var throttleErr *EmailCodeThrottleError
+		require.ErrorAs(t, err, &throttleErr)
+
+		var count int64
+		db.Model(&model.EmailSendLog{}).Where("email = ? AND purpose = ?", "limit@example.com", model.EmailPurposeMagicLink).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("MAGIC_LINK_URL에 이메일과 인증코드 추가", func(t *testing.T) {
+		assert.Empty(t, svc.magicLinkURL("a@example.com", "123456"))
+
+		svc.cfg.MagicLinkURL = "https://ojeomneo.com/auth/magic?source=email"
+		defer func() { svc.cfg.MagicLinkURL = "" }()
+
+		link, err := url.Parse(svc.magicLinkURL("a+b@example.com", "123456"))
+		require.NoError(t, err)
+		assert.Equal(t, "/auth/magic", link.Path)
+		assert.Equal(t, "a+b@example.com", link.Query().Get("email"))
+		assert.Equal(t, "123456", link.Query().Get("code"))
+		assert.Equal(t, "email", link.Query().Get("source"))
+	})
+}
@@ -0,0 +1,402 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// 2단계 인증 설정
+const (
+	mfaChallengeExpireMin = 5  // 비밀번호 확인 후 코드 입력 제한 시간 (분)
+	recoveryCodeCount     = 10 // 발급하는 복구 코드 수
+)
+
+var (
+	// ErrMFAInvalidCode 인증 코드 또는 복구 코드 불일치
+	ErrMFAInvalidCode = errors.New("인증 코드가 올바르지 않습니다")
+	// ErrMFANotEnabled 2단계 인증을 사용하지 않는 계정
+	ErrMFANotEnabled = errors.New("2단계 인증이 설정되어 있지 않습니다")
+)
+
+// TOTPSetupResponse TOTP 등록 시작 응답 (인증 앱 등록용)
+type TOTPSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// TOTPEnableResponse TOTP 활성화 응답 (복구 코드는 이때 한 번만 노출)
+type TOTPEnableResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// newMFACipher TOTP 비밀키 암호화기 생성
+// MFA_ENCRYPTION_KEY가 없으면 JWT secret에서 키를 파생 (HS256 서명 사용 시에만, 비대칭 서명 전환 시에는 별도 키 필수)
+func newMFACipher(cfg *config.Config, logger *zap.Logger) *auth.SecretCipher {
+	var key []byte
+	if cfg.MFAEncryptionKey != "" {
+		decoded, err := base64.StdEncoding.DecodeString(cfg.MFAEncryptionKey)
+		if err != nil || len(decoded) != 32 {
+			logger.Error("Invalid MFA_ENCRYPTION_KEY (expected base64 encoded 32 bytes), two-factor authentication disabled")
+			return nil
+		}
+		key = decoded
+	} else if cfg.JWTSecretKey != "" {
+		logger.Warn("MFA_ENCRYPTION_KEY not set, deriving TOTP encryption key from JWT secret")
+		sum := sha256.Sum256([]byte("mfa:" + cfg.JWTSecretKey))
+		key = sum[:]
+	} else {
+		logger.Warn("No MFA encryption key configured, two-factor authentication disabled")
+		return nil
+	}
+
+	cipher, err := auth.NewSecretCipher(key)
+	if err != nil {
+		logger.Error("Failed to initialize MFA cipher", zap.Error(err))
+		return nil
+	}
+	return cipher
+}
+
+// hasPasswordLogin 이메일/비밀번호 로그인이 가능한 계정인지 확인
+func (s *AuthService) hasPasswordLogin(user *model.User) (bool, error) {
+	if user.LoginMethod == model.LoginMethodEmail {
+		return true, nil
+	}
+
+	var count int64
+	if err := s.db.Model(&model.UserIdentity{}).
+		Where("user_id = ? AND provider = ?", user.ID, model.LoginMethodEmail).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("로그인 수단 조회에 실패했습니다: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SetupTOTP TOTP 등록 시작 (비밀키 발급, 코드 확인 전까지는 비활성)
+func (s *AuthService) SetupTOTP(userID uint) (*TOTPSetupResponse, error) {
+	if s.mfaCipher == nil {
+		return nil, errors.New("2단계 인증을 사용할 수 없습니다")
+	}
+
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("사용자를 찾을 수 없습니다")
+	}
+
+	ok, err := s.hasPasswordLogin(&user)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("이메일로 로그인하는 계정만 2단계 인증을 설정할 수 있습니다")
+	}
+	if user.MFAEnabled() {
+		return nil, errors.New("이미 2단계 인증이 설정되어 있습니다")
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("비밀키 생성에 실패했습니다: %w", err)
+	}
+	encrypted, err := s.mfaCipher.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("비밀키 암호화에 실패했습니다: %w", err)
+	}
+
+	if err := s.db.Model(&user).Updates(map[string]interface{}{
+		"totp_secret":         encrypted,
+		"totp_enabled_at":     nil,
+		"totp_last_used_step": 0,
+		"totp_recovery_codes": "",
+	}).Error; err != nil {
+		return nil, fmt.Errorf("2단계 인증 설정에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("TOTP setup started",
+		zap.Uint("user_id", user.ID),
+	)
+
+	return &TOTPSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: auth.TOTPURI(s.cfg.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+// EnableTOTP 인증 앱의 코드를 확인하고 2단계 인증 활성화 (복구 코드 발급)
+func (s *AuthService) EnableTOTP(userID uint, code string) (*TOTPEnableResponse, error) {
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("사용자를 찾을 수 없습니다")
+	}
+	if user.MFAEnabled() {
+		return nil, errors.New("이미 2단계 인증이 설정되어 있습니다")
+	}
+	if user.TOTPSecret == "" || s.mfaCipher == nil {
+		return nil, errors.New("2단계 인증 등록을 먼저 시작해 주세요")
+	}
+
+	secret, err := s.mfaCipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return nil, fmt.Errorf("비밀키 복호화에 실패했습니다: %w", err)
+	}
+	step, ok := auth.ValidateTOTP(secret, code, time.Now(), user.TOTPLastUsedStep)
+	if !ok {
+		return nil, ErrMFAInvalidCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(&user).Updates(map[string]interface{}{
+		"totp_enabled_at":     time.Now(),
+		"totp_last_used_step": step,
+		"totp_recovery_codes": hashes,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("2단계 인증 설정에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("TOTP enabled",
+		zap.Uint("user_id", user.ID),
+	)
+
+	return &TOTPEnableResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP 2단계 인증 해제 (현재 인증 코드 또는 복구 코드 필요)
+func (s *AuthService) DisableTOTP(userID uint, code string) error {
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return errors.New("사용자를 찾을 수 없습니다")
+	}
+	if !user.MFAEnabled() {
+		return ErrMFANotEnabled
+	}
+
+	if err := s.verifyMFACode(&user, code); err != nil {
+		return err
+	}
+
+	if err := s.db.Model(&user).Updates(map[string]interface{}{
+		"totp_secret":         "",
+		"totp_enabled_at":     nil,
+		"totp_last_used_step": 0,
+		"totp_recovery_codes": "",
+	}).Error; err != nil {
+		return fmt.Errorf("2단계 인증 해제에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("TOTP disabled",
+		zap.Uint("user_id", user.ID),
+	)
+
+	return nil
+}
+
+// issueMFAChallenge 비밀번호 확인 후 2단계 인증 대기 응답 생성
+func (s *AuthService) issueMFAChallenge(user *model.User) (*AuthResponse, error) {
+	challenge, err := auth.GenerateMFAChallengeToken(user.ID, s.keys, mfaChallengeExpireMin)
+	if err != nil {
+		return nil, fmt.Errorf("토큰 생성에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("Email login requires second factor",
+		zap.Uint("user_id", user.ID),
+	)
+
+	return &AuthResponse{
+		MFARequired: true,
+		MFAToken:    challenge,
+	}, nil
+}
+
+// VerifyMFA 2단계 인증 코드 확인 후 로그인 완료 (challenge 토큰 → Access/Refresh Token)
+func (s *AuthService) VerifyMFA(mfaToken, code string, client ClientInfo) (*AuthResponse, error) {
+	start := time.Now()
+
+	// 폐기 확인은 soft delete된 사용자를 폐기로 보므로 사용자 조회 후 직접 확인
+	expiredErr := errors.New("인증 시간이 만료되었습니다. 다시 로그인해 주세요")
+	claims, err := auth.ValidateMFAChallengeToken(mfaToken, s.keys)
+	if err != nil {
+		return nil, expiredErr
+	}
+
+	var user model.User
+	restoring := false
+	if err := s.db.First(&user, claims.UserID).Error; err == nil {
+		if revoked, err := s.revoker.IsRevoked(claims); err != nil || revoked {
+			return nil, expiredErr
+		}
+	} else {
+		// 탈퇴 유예 기간 중인 계정은 코드 확인 후 복구 (비밀번호나 로그인 링크만으로는 복구하지 않음)
+		deleted, findErr := s.findDeletedUser(s.db, claims.UserID)
+		if findErr != nil || deleted == nil || !deleted.IsActive {
+			return nil, errors.New("사용자를 찾을 수 없습니다")
+		}
+		if deleted.TokensRevokedBefore != nil && claims.IssuedAt != nil &&
+			claims.IssuedAt.Time.Before(*deleted.TokensRevokedBefore) {
+			return nil, expiredErr
+		}
+		user = *deleted
+		restoring = true
+	}
+	if !user.MFAEnabled() {
+		return nil, ErrMFANotEnabled
+	}
+
+	// 코드 추측 방지: 비밀번호 실패와 같은 기준으로 집계
+	if err := s.throttle.Check(user.Email, client.IP); err != nil {
+		return nil, err
+	}
+	if err := s.verifyMFACode(&user, code); err != nil {
+		if errors.Is(err, ErrMFAInvalidCode) {
+			s.throttle.RecordFailure(user.Email, client.IP)
+			s.logger.Warn("MFA verification failed",
+				zap.Uint("user_id", user.ID),
+			)
+		}
+		return nil, err
+	}
+	s.throttle.RecordSuccess(user.Email)
+
+	if restoring {
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			return s.restoreAccount(tx, &user)
+		}); err != nil {
+			return nil, fmt.Errorf("계정 복구에 실패했습니다: %w", err)
+		}
+	}
+
+	now := time.Now()
+	if err := s.db.Model(&user).Update("last_login", now).Error; err != nil {
+		s.logger.Warn("Failed to update last login",
+			zap.Error(err),
+			zap.Uint("user_id", user.ID),
+		)
+	}
+
+	accessToken, refreshToken, err := s.issueSessionTokens(user.ID, model.LoginMethodEmail, client)
+	if err != nil {
+		return nil, fmt.Errorf("토큰 생성에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("Email login successful (two-factor)",
+		zap.Uint("user_id", user.ID),
+		zap.Duration("duration", time.Since(start)),
+	)
+
+	return &AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "bearer",
+		User: &UserResponse{
+			ID:          user.ID,
+			Email:       user.Email,
+			IsActive:    user.IsActive,
+			DateJoined:  user.DateJoined,
+			LoginMethod: string(user.LoginMethod),
+		},
+		AccountRestored: restoring,
+	}, nil
+}
+
+// verifyMFACode TOTP 코드 또는 복구 코드 확인 (사용한 코드는 재사용 불가)
+func (s *AuthService) verifyMFACode(user *model.User, code string) error {
+	if s.mfaCipher == nil {
+		return errors.New("2단계 인증을 사용할 수 없습니다")
+	}
+
+	secret, err := s.mfaCipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return fmt.Errorf("비밀키 복호화에 실패했습니다: %w", err)
+	}
+
+	if step, ok := auth.ValidateTOTP(secret, code, time.Now(), user.TOTPLastUsedStep); ok {
+		// 동시 요청으로 같은 코드가 두 번 사용되지 않도록 조건부 갱신 (탈퇴 유예 중 복구 로그인 포함)
+		result := s.db.Unscoped().Model(&model.User{}).
+			Where("id = ? AND totp_last_used_step < ?", user.ID, step).
+			Update("totp_last_used_step", step)
+		if result.Error != nil {
+			return fmt.Errorf("인증 처리에 실패했습니다: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrMFAInvalidCode
+		}
+		return nil
+	}
+
+	return s.consumeRecoveryCode(user, code)
+}
+
+// consumeRecoveryCode 복구 코드 확인 후 사용 처리
+func (s *AuthService) consumeRecoveryCode(user *model.User, code string) error {
+	var hashes []string
+	if user.TOTPRecoveryCodes != "" {
+		if err := json.Unmarshal([]byte(user.TOTPRecoveryCodes), &hashes); err != nil {
+			return fmt.Errorf("복구 코드 조회에 실패했습니다: %w", err)
+		}
+	}
+
+	hashed := auth.HashRecoveryCode(code)
+	for i, h := range hashes {
+		if h != hashed {
+			continue
+		}
+
+		remaining := append(hashes[:i:i], hashes[i+1:]...)
+		encoded, err := json.Marshal(remaining)
+		if err != nil {
+			return fmt.Errorf("복구 코드 처리에 실패했습니다: %w", err)
+		}
+
+		// 조회 이후 다른 요청이 같은 코드를 사용했다면 갱신되지 않음 (탈퇴 유예 중 복구 로그인 포함)
+		result := s.db.Unscoped().Model(&model.User{}).
+			Where("id = ? AND totp_recovery_codes = ?", user.ID, user.TOTPRecoveryCodes).
+			Update("totp_recovery_codes", string(encoded))
+		if result.Error != nil {
+			return fmt.Errorf("복구 코드 처리에 실패했습니다: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrMFAInvalidCode
+		}
+
+		s.logger.Info("Recovery code used",
+			zap.Uint("user_id", user.ID),
+			zap.Int("remaining", len(remaining)),
+		)
+		return nil
+	}
+
+	return ErrMFAInvalidCode
+}
+
+// newRecoveryCodes 복구 코드 생성 (평문 목록, 저장용 해시 JSON)
+func newRecoveryCodes() ([]string, string, error) {
+	codes, err := auth.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, "", fmt.Errorf("복구 코드 생성에 실패했습니다: %w", err)
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, auth.HashRecoveryCode(code))
+	}
+	encoded, err := json.Marshal(hashes)
+	if err != nil {
+		return nil, "", fmt.Errorf("복구 코드 생성에 실패했습니다: %w", err)
+	}
+
+	return codes, string(encoded), nil
+}
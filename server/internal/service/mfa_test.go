@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+func TestAuthService_TOTP(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "mfa@example.com")
+	hashed, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	require.NoError(t, db.Model(user).Update("password", hashed).Error)
+
+	setup, err := svc.SetupTOTP(user.ID)
+	require.NoError(t, err)
+	assert.Contains(t, setup.OTPAuthURI, "otpauth://totp/")
+
+	t.Run("비밀키는 암호화되어 저장", func(t *testing.T) {
+		var stored model.User
+		require.NoError(t, db.First(&stored, user.ID).Error)
+		assert.NotEmpty(t, stored.TOTPSecret)
+		assert.NotEqual(t, setup.Secret, stored.TOTPSecret)
+		assert.False(t, stored.MFAEnabled())
+	})
+
+	t.Run("잘못된 코드로는 활성화 불가", func(t *testing.T) {
+		_, err := svc.EnableTOTP(user.ID, "000000")
+		assert.ErrorIs(t, err, ErrMFAInvalidCode)
+	})
+
+	code, err := auth.TOTPCode(setup.Secret, time.Now())
+	require.NoError(t, err)
+	enabled, err := svc.EnableTOTP(user.ID, code)
+	require.NoError(t, err)
+	require.Len(t, enabled.RecoveryCodes, recoveryCodeCount)
+
+	login := func(t *testing.T) string {
+		response, err := svc.EmailLogin(&LoginRequest{Email: "mfa@example.com", Password: "password123"})
+		require.NoError(t, err)
+		assert.True(t, response.MFARequired)
+		assert.Empty(t, response.AccessToken)
+		require.NotEmpty(t, response.MFAToken)
+		return response.MFAToken
+	}
+
+	t.Run("비밀번호 확인 후 challenge 토큰만 발급", func(t *testing.T) {
+		challenge := login(t)
+
+		// challenge 토큰으로는 API 접근 불가
+		_, err := auth.ValidateAccessToken(challenge, svc.keys)
+		assert.Error(t, err)
+	})
+
+	t.Run("활성화에 사용한 코드는 재사용 불가", func(t *testing.T) {
+		_, err := svc.VerifyMFA(login(t), code, ClientInfo{})
+		assert.ErrorIs(t, err, ErrMFAInvalidCode)
+	})
+
+	t.Run("다음 코드로 로그인 완료", func(t *testing.T) {
+		next, err := auth.TOTPCode(setup.Secret, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+
+		response, err := svc.VerifyMFA(login(t), next, ClientInfo{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.AccessToken)
+		assert.NotEmpty(t, response.RefreshToken)
+		assert.Equal(t, user.ID, response.User.ID)
+	})
+
+	t.Run("복구 코드는 한 번만 사용 가능", func(t *testing.T) {
+		response, err := svc.VerifyMFA(login(t), enabled.RecoveryCodes[0], ClientInfo{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.AccessToken)
+
+		_, err = svc.VerifyMFA(login(t), enabled.RecoveryCodes[0], ClientInfo{})
+		assert.ErrorIs(t, err, ErrMFAInvalidCode)
+	})
+
+	t.Run("복구 코드로 2단계 인증 해제", func(t *testing.T) {
+		require.NoError(t, svc.DisableTOTP(user.ID, enabled.RecoveryCodes[1]))
+
+		response, err := svc.EmailLogin(&LoginRequest{Email: "mfa@example.com", Password: "password123"})
+		require.NoError(t, err)
+		assert.False(t, response.MFARequired)
+		assert.NotEmpty(t, response.AccessToken)
+	})
+}
+
+func TestAuthService_SetupTOTP_SocialOnly(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := &model.User{
+		Username:    "kakao_mfa",
+		Email:       "kakao-mfa@example.com",
+		LoginMethod: model.LoginMethodKakao,
+		SocialID:    "kakao-mfa",
+		IsActive:    true,
+	}
+	require.NoError(t, db.Create(user).Error)
+
+	_, err := svc.SetupTOTP(user.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/webauthn"
+)
+
+// Passkey 설정
+const (
+	passkeyChallengeExpireMin = 5  // 등록/로그인 ceremony 제한 시간 (분)
+	maxPasskeysPerUser        = 10 // 계정당 등록 가능한 passkey 수
+	passkeyNameMaxLength      = 100
+
+	passkeyPurposeRegister = "passkey_register"
+	passkeyPurposeLogin    = "passkey_login"
+)
+
+var (
+	// ErrPasskeyDisabled passkey 미설정 (WEBAUTHN_RP_ID 없음)
+	ErrPasskeyDisabled = errors.New("passkey 로그인을 사용할 수 없습니다")
+	// ErrPasskeyNotFound 등록되지 않은 passkey
+	ErrPasskeyNotFound = errors.New("등록되지 않은 passkey입니다")
+)
+
+// PasskeyAttestation passkey 등록 응답 (navigator.credentials.create 결과, 바이너리는 base64url)
+type PasskeyAttestation struct {
+	ID                string `json:"id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AttestationObject string `json:"attestation_object"`
+	Name              string `json:"name,omitempty"` // 사용자가 구분하기 위한 이름 (예: iPhone)
+}
+
+// PasskeyAssertion passkey 로그인 응답 (navigator.credentials.get 결과, 바이너리는 base64url)
+type PasskeyAssertion struct {
+	ID                string `json:"id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AuthenticatorData string `json:"authenticator_data"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"user_handle,omitempty"`
+}
+
+// newWebAuthnConfig passkey 설정 생성 (RP ID가 없으면 nil: passkey 비활성)
+// origin을 지정하지 않으면 https://<RP ID>만 허용
+func newWebAuthnConfig(cfg *config.Config, logger *zap.Logger) *webauthn.Config {
+	if cfg.WebAuthnRPID == "" {
+		logger.Warn("WEBAUTHN_RP_ID not set, passkey login disabled")
+		return nil
+	}
+
+	origins := cfg.WebAuthnOrigins
+	if len(origins) == 0 {
+		origins = []string{"https://" + cfg.WebAuthnRPID}
+	}
+
+	return &webauthn.Config{
+		RPID:    cfg.WebAuthnRPID,
+		RPName:  cfg.WebAuthnRPName,
+		Origins: origins,
+	}
+}
+
+// passkeyUserHandle WebAuthn user handle (개인정보를 담지 않도록 사용자 ID만 사용)
+func passkeyUserHandle(userID uint) []byte {
+	handle := make([]byte, 8)
+	binary.BigEndian.PutUint64(handle, uint64(userID))
+	return handle
+}
+
+// BeginPasskeyRegistration passkey 등록 시작 (정회원 전용)
+func (s *AuthService) BeginPasskeyRegistration(userID uint) (*webauthn.CreationOptions, error) {
+	if s.webauthn == nil {
+		return nil, ErrPasskeyDisabled
+	}
+
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("사용자를 찾을 수 없습니다")
+	}
+	if user.IsGuest {
+		return nil, errors.New("익명 사용자는 passkey를 등록할 수 없습니다")
+	}
+
+	var existing []model.PasskeyCredential
+	if err := s.db.Where("user_id = ?", userID).Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("passkey 조회에 실패했습니다: %w", err)
+	}
+	if len(existing) >= maxPasskeysPerUser {
+		return nil, fmt.Errorf("passkey는 최대 %d개까지 등록할 수 있습니다", maxPasskeysPerUser)
+	}
+
+	// 같은 인증기에 중복 등록 방지
+	exclude := make([]webauthn.CredentialDescriptor, 0, len(existing))
+	for _, credential := range existing {
+		exclude = append(exclude, webauthn.CredentialDescriptor{Type: "public-key", ID: credential.CredentialID})
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, fmt.Errorf("challenge 생성에 실패했습니다: %w", err)
+	}
+	if err := s.challenges.Save(passkeyPurposeRegister, challenge, strconv.FormatUint(uint64(userID), 10),
+		passkeyChallengeExpireMin*time.Minute); err != nil {
+		return nil, fmt.Errorf("passkey 등록 준비에 실패했습니다: %w", err)
+	}
+
+	displayName := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if displayName == "" {
+		displayName = user.Email
+	}
+
+	return s.webauthn.CreationOptions(challenge, passkeyUserHandle(user.ID), user.Email, displayName, exclude), nil
+}
+
+// FinishPasskeyRegistration 인증기 응답 검증 후 passkey 저장
+func (s *AuthService) FinishPasskeyRegistration(userID uint, req *PasskeyAttestation) (*model.PasskeyCredential, error) {
+	if s.webauthn == nil {
+		return nil, ErrPasskeyDisabled
+	}
+
+	clientData, err := webauthn.DecodeString(req.ClientDataJSON)
+	if err != nil {
+		return nil, errors.New("client_data_json 형식이 올바르지 않습니다")
+	}
+	attestationObject, err := webauthn.DecodeString(req.AttestationObject)
+	if err != nil {
+		return nil, errors.New("attestation_object 형식이 올바르지 않습니다")
+	}
+
+	challenge, err := webauthn.ChallengeFromClientData(clientData)
+	if err != nil {
+		return nil, errors.New("client_data_json 형식이 올바르지 않습니다")
+	}
+	payload, err := s.challenges.Consume(passkeyPurposeRegister, challenge)
+	if err != nil {
+		return nil, err
+	}
+	if payload != strconv.FormatUint(uint64(userID), 10) {
+		return nil, ErrChallengeNotFound
+	}
+
+	verified, err := s.webauthn.VerifyRegistration(challenge, clientData, attestationObject)
+	if err != nil {
+		s.logger.Warn("Passkey registration verification failed",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+		return nil, fmt.Errorf("passkey 검증에 실패했습니다: %w", err)
+	}
+
+	credentialID := webauthn.EncodeToString(verified.ID)
+	var count int64
+	if err := s.db.Model(&model.PasskeyCredential{}).
+		Where("credential_id = ?", credentialID).
+		Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("passkey 조회에 실패했습니다: %w", err)
+	}
+	if count > 0 {
+		return nil, errors.New("이미 등록된 passkey입니다")
+	}
+
+	name := truncate(strings.TrimSpace(req.Name), passkeyNameMaxLength)
+	if name == "" {
+		name = "Passkey"
+	}
+	aaguid := ""
+	if id, err := uuid.FromBytes(verified.AAGUID); err == nil {
+		aaguid = id.String()
+	}
+
+	credential := &model.PasskeyCredential{
+		UserID:       userID,
+		CredentialID: credentialID,
+		PublicKey:    verified.PublicKey,
+		Algorithm:    verified.Algorithm,
+		SignCount:    verified.SignCount,
+		AAGUID:       aaguid,
+		Name:         name,
+	}
+	if err := s.db.Create(credential).Error; err != nil {
+		return nil, fmt.Errorf("passkey 등록에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("Passkey registered",
+		zap.Uint("user_id", userID),
+		zap.Uint("passkey_id", credential.ID),
+		zap.String("aaguid", aaguid),
+	)
+
+	return credential, nil
+}
+
+// BeginPasskeyLogin passkey 로그인 시작 (기기에 저장된 passkey 중 사용자가 선택)
+func (s *AuthService) BeginPasskeyLogin() (*webauthn.RequestOptions, error) {
+	if s.webauthn == nil {
+		return nil, ErrPasskeyDisabled
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, fmt.Errorf("challenge 생성에 실패했습니다: %w", err)
+	}
+	if err := s.challenges.Save(passkeyPurposeLogin, challenge, "", passkeyChallengeExpireMin*time.Minute); err != nil {
+		return nil, fmt.Errorf("passkey 로그인 준비에 실패했습니다: %w", err)
+	}
+
+	return s.webauthn.RequestOptions(challenge), nil
+}
+
+// FinishPasskeyLogin 인증기 서명 검증 후 로그인 (Access/Refresh Token 발급)
+// 사용자 확인(생체/PIN)을 거친 passkey는 그 자체로 2단계 인증을 만족하므로 TOTP를 묻지 않음
+func (s *AuthService) FinishPasskeyLogin(req *PasskeyAssertion, client ClientInfo) (*AuthResponse, error) {
+	start := time.Now()
+
+	if s.webauthn == nil {
+		return nil, ErrPasskeyDisabled
+	}
+
+	rawID, err := webauthn.DecodeString(req.ID)
+	if err != nil || len(rawID) == 0 {
+		return nil, errors.New("id 형식이 올바르지 않습니다")
+	}
+	clientData, err := webauthn.DecodeString(req.ClientDataJSON)
+	if err != nil {
+		return nil, errors.New("client_data_json 형식이 올바르지 않습니다")
+	}
+	authData, err := webauthn.DecodeString(req.AuthenticatorData)
+	if err != nil {
+		return nil, errors.New("authenticator_data 형식이 올바르지 않습니다")
+	}
+	signature, err := webauthn.DecodeString(req.Signature)
+	if err != nil {
+		return nil, errors.New("signature 형식이 올바르지 않습니다")
+	}
+
+	challenge, err := webauthn.ChallengeFromClientData(clientData)
+	if err != nil {
+		return nil, errors.New("client_data_json 형식이 올바르지 않습니다")
+	}
+	if _, err := s.challenges.Consume(passkeyPurposeLogin, challenge); err != nil {
+		return nil, err
+	}
+
+	var credential model.PasskeyCredential
+	if err := s.db.Where("credential_id = ?", webauthn.EncodeToString(rawID)).First(&credential).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Passkey login failed: unknown credential")
+			return nil, ErrPasskeyNotFound
+		}
+		return nil, fmt.Errorf("passkey 조회에 실패했습니다: %w", err)
+	}
+
+	if req.UserHandle != "" {
+		handle, err := webauthn.DecodeString(req.UserHandle)
+		if err != nil || string(handle) != string(passkeyUserHandle(credential.UserID)) {
+			s.logger.Warn("Passkey login failed: user handle mismatch",
+				zap.Uint("user_id", credential.UserID),
+			)
+			return nil, errors.New("passkey 인증에 실패했습니다")
+		}
+	}
+
+	signCount, err := s.webauthn.VerifyAssertion(challenge, credential.PublicKey, credential.SignCount, clientData, authData, signature)
+	if err != nil {
+		s.logger.Warn("Passkey login failed: assertion verification failed",
+			zap.Error(err),
+			zap.Uint("user_id", credential.UserID),
+			zap.Uint("passkey_id", credential.ID),
+		)
+		return nil, errors.New("passkey 인증에 실패했습니다")
+	}
+
+	var user model.User
+	restored := false
+	if err := s.db.First(&user, credential.UserID).Error; err != nil {
+		// 탈퇴 유예 기간 중인 계정이면 복구 후 로그인
+		deleted, findErr := s.findDeletedUser(s.db, credential.UserID)
+		if findErr != nil || deleted == nil {
+			return nil, errors.New("사용자를 찾을 수 없습니다")
+		}
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			return s.restoreAccount(tx, deleted)
+		}); err != nil {
+			return nil, fmt.Errorf("계정 복구에 실패했습니다: %w", err)
+		}
+		user = *deleted
+		restored = true
+	}
+	if !user.IsActive {
+		s.logger.Warn("Passkey login failed: user inactive",
+			zap.Uint("user_id", user.ID),
+		)
+		return nil, errors.New("비활성화된 계정입니다")
+	}
+
+	// 같은 서명이 동시에 두 번 사용되지 않도록 조건부 갱신
+	now := time.Now()
+	result := s.db.Model(&model.PasskeyCredential{}).
+		Where("id = ? AND sign_count = ?", credential.ID, credential.SignCount).
+		Updates(map[string]interface{}{
+			"sign_count":   signCount,
+			"last_used_at": now,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("passkey 인증 처리에 실패했습니다: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("passkey 인증에 실패했습니다")
+	}
+
+	if err := s.db.Model(&user).Update("last_login", now).Error; err != nil {
+		s.logger.Warn("Failed to update last login",
+			zap.Error(err),
+			zap.Uint("user_id", user.ID),
+		)
+	}
+
+	accessToken, refreshToken, err := s.issueSessionTokens(user.ID, model.LoginMethodPasskey, client)
+	if err != nil {
+		return nil, fmt.Errorf("토큰 생성에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("Passkey login successful",
+		zap.Uint("user_id", user.ID),
+		zap.Uint("passkey_id", credential.ID),
+		zap.Duration("duration", time.Since(start)),
+	)
+
+	return &AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "bearer",
+		User: &UserResponse{
+			ID:          user.ID,
+			Email:       user.Email,
+			IsActive:    user.IsActive,
+			DateJoined:  user.DateJoined,
+			LoginMethod: string(user.LoginMethod),
+		},
+		AccountRestored: restored,
+	}, nil
+}
+
+// ListPasskeys 등록된 passkey 목록 조회
+func (s *AuthService) ListPasskeys(userID uint) ([]model.PasskeyCredential, error) {
+	var credentials []model.PasskeyCredential
+	if err := s.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&credentials).Error; err != nil {
+		return nil, fmt.Errorf("passkey 조회에 실패했습니다: %w", err)
+	}
+	return credentials, nil
+}
+
+// DeletePasskey passkey 삭제 (본인 passkey만)
+func (s *AuthService) DeletePasskey(userID, passkeyID uint) error {
+	result := s.db.Where("id = ? AND user_id = ?", passkeyID, userID).Delete(&model.PasskeyCredential{})
+	if result.Error != nil {
+		return fmt.Errorf("passkey 삭제에 실패했습니다: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPasskeyNotFound
+	}
+
+	s.logger.Info("Passkey deleted",
+		zap.Uint("user_id", userID),
+		zap.Uint("passkey_id", passkeyID),
+	)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/webauthn"
+)
+
+const testPasskeyOrigin = "https://ojeomneo.com"
+
+// testPasskey 테스트용 ES256 인증기
+type testPasskey struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+}
+
+func newTestPasskey(t *testing.T) *testPasskey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &testPasskey{key: key, credentialID: []byte("passkey-" + t.Name())}
+}
+
+// authData rpIdHash | flags(UP|UV[|AT]) | signCount | [AAGUID | credentialId | COSE_Key]
+func (p *testPasskey) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte("ojeomneo.com"))
+	out := append([]byte{}, rpIDHash[:]...)
+	if attested {
+		out = append(out, 0x45)
+	} else {
+		out = append(out, 0x05)
+	}
+	out = binary.BigEndian.AppendUint32(out, p.signCount)
+	if attested {
+		out = append(out, make([]byte, 16)...)
+		out = binary.BigEndian.AppendUint16(out, uint16(len(p.credentialID)))
+		out = append(out, p.credentialID...)
+		out = append(out, 0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20)
+		out = append(out, p.key.PublicKey.X.FillBytes(make([]byte, 32))...)
+		out = append(out, 0x22, 0x58, 0x20)
+		out = append(out, p.key.PublicKey.Y.FillBytes(make([]byte, 32))...)
+	}
+	return out
+}
+
+func testClientData(t *testing.T, ceremony, challenge string) []byte {
+	data, err := json.Marshal(map[string]string{"type": ceremony, "challenge": challenge, "origin": testPasskeyOrigin})
+	require.NoError(t, err)
+	return data
+}
+
+// register navigator.credentials.create() 응답 생성
+func (p *testPasskey) register(t *testing.T, challenge string) *PasskeyAttestation {
+	authData := p.authData(true)
+	attestation := []byte{0xa3, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e', 0x67}
+	attestation = append(attestation, "attStmt"...)
+	attestation = append(attestation, 0xa0, 0x68)
+	attestation = append(attestation, "authData"...)
+	attestation = append(attestation, 0x59)
+	attestation = binary.BigEndian.AppendUint16(attestation, uint16(len(authData)))
+	attestation = append(attestation, authData...)
+
+	return &PasskeyAttestation{
+		ID:                webauthn.EncodeToString(p.credentialID),
+		ClientDataJSON:    webauthn.EncodeToString(testClientData(t, "webauthn.create", challenge)),
+		AttestationObject: webauthn.EncodeToString(attestation),
+		Name:              "iPhone",
+	}
+}
+
+// login navigator.credentials.get() 응답 생성
+func (p *testPasskey) login(t *testing.T, challenge string, userID uint) *PasskeyAssertion {
+	p.signCount++
+	authData := p.authData(false)
+	clientData := testClientData(t, "webauthn.get", challenge)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, p.key, digest[:])
+	require.NoError(t, err)
+
+	return &PasskeyAssertion{
+		ID:                webauthn.EncodeToString(p.credentialID),
+		ClientDataJSON:    webauthn.EncodeToString(clientData),
+		AuthenticatorData: webauthn.EncodeToString(authData),
+		Signature:         webauthn.EncodeToString(signature),
+		UserHandle:        webauthn.EncodeToString(passkeyUserHandle(userID)),
+	}
+}
+
+func TestAuthService_Passkey(t *testing.T) {
+	svc, db := setupAuthService(t)
+	svc.webauthn = &webauthn.Config{RPID: "ojeomneo.com", RPName: "Ojeomneo", Origins: []string{testPasskeyOrigin}}
+	user := createTestUser(t, db, "passkey@example.com")
+	passkey := newTestPasskey(t)
+
+	options, err := svc.BeginPasskeyRegistration(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ojeomneo.com", options.RP.ID)
+	assert.Equal(t, "required", options.AuthenticatorSelection.UserVerification)
+
+	credential, err := svc.FinishPasskeyRegistration(user.ID, passkey.register(t, options.Challenge))
+	require.NoError(t, err)
+	assert.Equal(t, "iPhone", credential.Name)
+
+	t.Run("등록 challenge는 한 번만 사용 가능", func(t *testing.T) {
+		_, err := svc.FinishPasskeyRegistration(user.ID, passkey.register(t, options.Challenge))
+		assert.ErrorIs(t, err, ErrChallengeNotFound)
+	})
+
+	t.Run("다른 사용자의 등록 challenge 거부", func(t *testing.T) {
+		other := createTestUser(t, db, "other-passkey@example.com")
+		otherOptions, err := svc.BeginPasskeyRegistration(other.ID)
+		require.NoError(t, err)
+
+		_, err = svc.FinishPasskeyRegistration(user.ID, newTestPasskey(t).register(t, otherOptions.Challenge))
+		assert.ErrorIs(t, err, ErrChallengeNotFound)
+	})
+
+	t.Run("등록된 passkey는 재등록 옵션에서 제외", func(t *testing.T) {
+		options, err := svc.BeginPasskeyRegistration(user.ID)
+		require.NoError(t, err)
+		require.Len(t, options.ExcludeCredentials, 1)
+		assert.Equal(t, webauthn.EncodeToString(passkey.credentialID), options.ExcludeCredentials[0].ID)
+	})
+
+	t.Run("passkey 로그인 시 세션 토큰 발급", func(t *testing.T) {
+		loginOptions, err := svc.BeginPasskeyLogin()
+		require.NoError(t, err)
+
+		response, err := svc.FinishPasskeyLogin(passkey.login(t, loginOptions.Challenge, user.ID), ClientInfo{Platform: "ios"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.AccessToken)
+		assert.Equal(t, user.ID, response.User.ID)
+
+		var session model.Session
+		require.NoError(t, db.Where("user_id = ?", user.ID).First(&session).Error)
+		assert.Equal(t, model.LoginMethodPasskey, session.LoginMethod)
+
+		var stored model.PasskeyCredential
+		require.NoError(t, db.First(&stored, credential.ID).Error)
+		assert.Equal(t, passkey.signCount, stored.SignCount)
+		assert.NotNil(t, stored.LastUsedAt)
+	})
+
+	t.Run("로그인 challenge 재사용 거부", func(t *testing.T) {
+		loginOptions, err := svc.BeginPasskeyLogin()
+		require.NoError(t, err)
+
+		assertion := passkey.login(t, loginOptions.Challenge, user.ID)
+		_, err = svc.FinishPasskeyLogin(assertion, ClientInfo{})
+		require.NoError(t, err)
+
+		_, err = svc.FinishPasskeyLogin(assertion, ClientInfo{})
+		assert.ErrorIs(t, err, ErrChallengeNotFound)
+	})
+
+	t.Run("user handle 불일치 거부", func(t *testing.T) {
+		loginOptions, err := svc.BeginPasskeyLogin()
+		require.NoError(t, err)
+
+		_, err = svc.FinishPasskeyLogin(passkey.login(t, loginOptions.Challenge, user.ID+100), ClientInfo{})
+		assert.Error(t, err)
+	})
+
+	t.Run("삭제한 passkey로 로그인 불가", func(t *testing.T) {
+		assert.ErrorIs(t, svc.DeletePasskey(user.ID+100, credential.ID), ErrPasskeyNotFound)
+		require.NoError(t, svc.DeletePasskey(user.ID, credential.ID))
+
+		passkeys, err := svc.ListPasskeys(user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, passkeys)
+
+		loginOptions, err := svc.BeginPasskeyLogin()
+		require.NoError(t, err)
+		_, err = svc.FinishPasskeyLogin(passkey.login(t, loginOptions.Challenge, user.ID), ClientInfo{})
+		assert.ErrorIs(t, err, ErrPasskeyNotFound)
+	})
+}
+
+func TestAuthService_PasskeyDisabled(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "nopasskey@example.com")
+
+	_, err := svc.BeginPasskeyRegistration(user.ID)
+	assert.ErrorIs(t, err, ErrPasskeyDisabled)
+
+	_, err = svc.BeginPasskeyLogin()
+	assert.ErrorIs(t, err, ErrPasskeyDisabled)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// issueTokenPair Access/Refresh Token 발급 및 Refresh Token 기록 저장
+// familyID가 비어 있으면 새 토큰 패밀리를 시작 (신규 로그인)
+func (s *AuthService) issueTokenPair(userID uint, familyID string) (accessToken, refreshToken, refreshTokenID string, err error) {
+	refreshTokenID = uuid.NewString()
+	if familyID == "" {
+		familyID = refreshTokenID
+	}
+
+	accessToken, refreshToken, err = auth.GenerateTokenPair(
+		userID,
+		familyID,
+		refreshTokenID,
+		s.keys,
+		s.cfg.JWTAccessTokenExpireMin,
+		s.cfg.JWTRefreshTokenExpireDays,
+	)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	record := model.RefreshToken{
+		ID:        refreshTokenID,
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Duration(s.cfg.JWTRefreshTokenExpireDays) * 24 * time.Hour),
+	}
+	if err := s.db.Create(&record).Error; err != nil {
+		return "", "", "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return accessToken, refreshToken, refreshTokenID, nil
+}
+
+// legacyRefreshTokenNamespace jti 없는 레거시 Refresh Token의 기록 ID 생성용 UUID 네임스페이스
+var legacyRefreshTokenNamespace = uuid.MustParse("4b5f3c1e-8a3d-4f6b-9c2e-7d1a0e6f5b84")
+
+// rotateRefreshToken 사용된 Refresh Token을 폐기하고 같은 패밀리로 새 토큰 발급
+// 이미 회전(폐기)된 토큰이 다시 사용되면 탈취로 간주하여 패밀리(세션) 전체를 폐기
+func (s *AuthService) rotateRefreshToken(rawToken string, claims *auth.Claims, client ClientInfo) (accessToken, refreshToken string, err error) {
+	ctx := context.Background()
+
+	// jti가 없는 토큰은 저장소 도입 이전에 발급된 토큰: 한 번만 새 패밀리로 전환
+	if claims.ID == "" {
+		if err := s.redeemLegacyRefreshToken(rawToken, claims); err != nil {
+			return "", "", err
+		}
+		s.logger.Info("Legacy refresh token used, starting new token family",
+			zap.Uint("user_id", claims.UserID),
+		)
+		s.recordRefreshMetric(ctx, "legacy")
+		return s.issueSessionTokens(claims.UserID, "", client)
+	}
+
+	var record model.RefreshToken
+	if err := s.db.Where("id = ? AND user_id = ?", claims.ID, claims.UserID).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Refresh token not found in store",
+				zap.String("token_id", claims.ID),
+				zap.Uint("user_id", claims.UserID),
+			)
+			s.recordRefreshMetric(ctx, "invalid")
+			return "", "", errors.New("유효하지 않은 토큰입니다")
+		}
+		return "", "", fmt.Errorf("토큰 조회에 실패했습니다: %w", err)
+	}
+
+	if record.IsRevoked() {
+		s.handleRefreshTokenReuse(ctx, &record)
+		return "", "", errors.New("유효하지 않은 토큰입니다. 다시 로그인해 주세요")
+	}
+
+	if record.IsExpired() {
+		s.recordRefreshMetric(ctx, "expired")
+		return "", "", errors.New("로그인이 만료되었습니다. 다시 로그인해 주세요")
+	}
+
+	// 새 토큰 발급 (같은 패밀리)
+	accessToken, refreshToken, newTokenID, err := s.issueTokenPair(record.UserID, record.FamilyID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// 기존 토큰 폐기: revoked_at IS NULL 조건으로 동시 요청 중 하나만 성공
+	now := time.Now()
+	result := s.db.Model(&model.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", record.ID).
+		Updates(map[string]interface{}{
+			"revoked_at":  now,
+			"replaced_by": newTokenID,
+		})
+	if result.Error != nil {
+		return "", "", fmt.Errorf("토큰 갱신에 실패했습니다: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// 검증과 폐기 사이에 같은 토큰이 이미 사용됨
+		s.handleRefreshTokenReuse(ctx, &record)
+		return "", "", errors.New("유효하지 않은 토큰입니다. 다시 로그인해 주세요")
+	}
+
+	s.touchSession(record.FamilyID, record.UserID, client, time.Now().Add(time.Duration(s.cfg.JWTRefreshTokenExpireDays)*24*time.Hour))
+
+	s.recordRefreshMetric(ctx, "rotated")
+	return accessToken, refreshToken, nil
+}
+
+// redeemLegacyRefreshToken 레거시 Refresh Token을 사용 완료로 기록 (토큰마다 한 번만 성공)
+// 토큰 해시에서 만든 ID를 폐기 상태로 저장하므로, 같은 토큰을 다시 쓰면 기본 키 충돌로 거부된다.
+func (s *AuthService) redeemLegacyRefreshToken(rawToken string, claims *auth.Claims) error {
+	recordID := uuid.NewSHA1(legacyRefreshTokenNamespace, []byte(rawToken)).String()
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(s.cfg.JWTRefreshTokenExpireDays) * 24 * time.Hour)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	record := model.RefreshToken{
+		ID:        recordID,
+		FamilyID:  recordID,
+		UserID:    claims.UserID,
+		ExpiresAt: expiresAt,
+		RevokedAt: &now,
+	}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return fmt.Errorf("토큰 갱신에 실패했습니다: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		s.logger.Warn("Legacy refresh token reuse rejected",
+			zap.Uint("user_id", claims.UserID),
+		)
+		s.recordRefreshMetric(context.Background(), "reuse_detected")
+		return errors.New("유효하지 않은 토큰입니다. 다시 로그인해 주세요")
+	}
+	return nil
+}
+
+// handleRefreshTokenReuse 재사용 탐지 시 토큰 패밀리(세션) 전체 폐기
+func (s *AuthService) handleRefreshTokenReuse(ctx context.Context, record *model.RefreshToken) {
+	s.logger.Warn("Refresh token reuse detected, revoking token family",
+		zap.String("token_id", record.ID),
+		zap.String("family_id", record.FamilyID),
+		zap.Uint("user_id", record.UserID),
+	)
+	s.recordRefreshMetric(ctx, "reuse_detected")
+
+	if err := s.endSession(record.FamilyID); err != nil {
+		s.logger.Error("Failed to revoke refresh token family",
+			zap.Error(err),
+			zap.String("family_id", record.FamilyID),
+		)
+	}
+}
+
+// recordRefreshMetric Refresh Token 메트릭 기록 (메트릭 비활성 시 무시)
+func (s *AuthService) recordRefreshMetric(ctx context.Context, status string) {
+	if s.metrics != nil {
+		s.metrics.RecordRefreshToken(ctx, status)
+	}
+}
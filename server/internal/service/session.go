@@ -0,0 +1,234 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// guestTokenExpireDays 익명 토큰(및 익명 세션) 만료 기간
+const guestTokenExpireDays = 7
+
+// ErrSessionNotFound 세션이 없거나 다른 사용자의 세션
+var ErrSessionNotFound = errors.New("세션을 찾을 수 없습니다")
+
+// ClientInfo 토큰 발급 요청을 보낸 기기/클라이언트 정보
+type ClientInfo struct {
+	DeviceID   string // 앱 설치 단위 식별자 (User.DeviceID, Sketch.DeviceID와 동일)
+	Platform   string // ios, android
+	AppVersion string
+	IP         string // 프록시 헤더를 반영한 클라이언트 IP (로그인 제한, 이메일 발송 한도 집계 기준)
+	UserAgent  string
+}
+
+// SessionResponse 세션 목록 응답
+type SessionResponse struct {
+	ID          string    `json:"id"`
+	LoginMethod string    `json:"login_method"`
+	DeviceID    *string   `json:"device_id,omitempty"`
+	Platform    string    `json:"platform"`
+	AppVersion  string    `json:"app_version"`
+	IP          string    `json:"ip"`
+	UserAgent   string    `json:"user_agent"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	Current     bool      `json:"current"` // 요청한 토큰의 세션 여부
+}
+
+// issueSessionTokens 새 세션을 시작하고 Access/Refresh Token 발급 (신규 로그인)
+func (s *AuthService) issueSessionTokens(userID uint, method model.LoginMethod, client ClientInfo) (accessToken, refreshToken string, err error) {
+	expiresAt := time.Now().Add(time.Duration(s.cfg.JWTRefreshTokenExpireDays) * 24 * time.Hour)
+
+	sessionID, err := s.startSession(userID, method, client, expiresAt)
+	if err != nil {
+		return "", "", err
+	}
+
+	accessToken, refreshToken, _, err = s.issueTokenPair(userID, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// issueGuestSessionToken 새 익명 세션을 시작하고 익명 Access Token 발급
+func (s *AuthService) issueGuestSessionToken(userID uint, client ClientInfo) (string, error) {
+	expiresAt := time.Now().Add(guestTokenExpireDays * 24 * time.Hour)
+
+	sessionID, err := s.startSession(userID, model.LoginMethodGuest, client, expiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	return auth.GenerateGuestToken(userID, sessionID, s.keys, guestTokenExpireDays)
+}
+
+// startSession 세션 기록 생성 (세션 ID는 Refresh Token FamilyID 및 JWT sid로 사용)
+func (s *AuthService) startSession(userID uint, method model.LoginMethod, client ClientInfo, expiresAt time.Time) (string, error) {
+	session := newSession(uuid.NewString(), userID, method, client, expiresAt)
+	if err := s.db.Create(session).Error; err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return session.ID, nil
+}
+
+// newSession 클라이언트 정보로 세션 기록 구성 (헤더 값은 컬럼 길이에 맞게 자름)
+func newSession(sessionID string, userID uint, method model.LoginMethod, client ClientInfo, expiresAt time.Time) *model.Session {
+	session := &model.Session{
+		ID:          sessionID,
+		UserID:      userID,
+		LoginMethod: method,
+		Platform:    truncate(client.Platform, 20),
+		AppVersion:  truncate(client.AppVersion, 20),
+		IP:          truncate(client.IP, 45),
+		UserAgent:   truncate(client.UserAgent, 255),
+		LastSeenAt:  time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+	if client.DeviceID != "" {
+		deviceID := truncate(client.DeviceID, 255)
+		session.DeviceID = &deviceID
+	}
+	return session
+}
+
+// touchSession Refresh Token 회전 시 세션의 마지막 접속 정보 갱신
+// 세션 기록 도입 이전에 발급된 토큰 패밀리는 이 시점에 세션 기록을 생성
+func (s *AuthService) touchSession(sessionID string, userID uint, client ClientInfo, expiresAt time.Time) {
+	updates := map[string]interface{}{
+		"last_seen_at": time.Now(),
+		"expires_at":   expiresAt,
+	}
+	if client.IP != "" {
+		updates["ip"] = truncate(client.IP, 45)
+	}
+	if client.AppVersion != "" {
+		updates["app_version"] = truncate(client.AppVersion, 20)
+	}
+	if client.UserAgent != "" {
+		updates["user_agent"] = truncate(client.UserAgent, 255)
+	}
+
+	result := s.db.Model(&model.Session{}).
+		Where("id = ? AND user_id = ?", sessionID, userID).
+		Updates(updates)
+	if result.Error != nil {
+		s.logger.Warn("Failed to update session",
+			zap.Error(result.Error),
+			zap.String("session_id", sessionID),
+		)
+		return
+	}
+	if result.RowsAffected > 0 {
+		return
+	}
+
+	session := newSession(sessionID, userID, "", client, expiresAt)
+	if err := s.db.Create(session).Error; err != nil {
+		s.logger.Warn("Failed to create session for legacy token family",
+			zap.Error(err),
+			zap.String("session_id", sessionID),
+		)
+	}
+}
+
+// endSession 세션 종료 (세션 폐기 + 같은 세션의 Refresh Token 패밀리 폐기)
+// 세션에 속한 Access Token은 TokenRevoker가 세션 폐기 여부로 거부
+func (s *AuthService) endSession(sessionID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&model.Session{}).
+			Where("id = ? AND revoked_at IS NULL", sessionID).
+			Update("revoked_at", now).Error; err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+		if err := tx.Model(&model.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", sessionID).
+			Update("revoked_at", now).Error; err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListSessions 사용자의 유효한 로그인 세션 목록 (최근 접속 순)
+func (s *AuthService) ListSessions(userID uint, currentSessionID string) ([]SessionResponse, error) {
+	var sessions []model.Session
+	if err := s.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error; err != nil {
+		s.logger.Error("Failed to list sessions",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+		return nil, fmt.Errorf("세션 목록 조회에 실패했습니다: %w", err)
+	}
+
+	responses := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, SessionResponse{
+			ID:          session.ID,
+			LoginMethod: string(session.LoginMethod),
+			DeviceID:    session.DeviceID,
+			Platform:    session.Platform,
+			AppVersion:  session.AppVersion,
+			IP:          session.IP,
+			UserAgent:   session.UserAgent,
+			CreatedAt:   session.CreatedAt,
+			LastSeenAt:  session.LastSeenAt,
+			Current:     session.ID == currentSessionID,
+		})
+	}
+
+	return responses, nil
+}
+
+// RevokeSession 특정 세션 로그아웃 (분실한 기기 로그아웃)
+func (s *AuthService) RevokeSession(userID uint, sessionID string) error {
+	var session model.Session
+	if err := s.db.Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("세션 조회에 실패했습니다: %w", err)
+	}
+
+	if err := s.endSession(session.ID); err != nil {
+		s.logger.Error("Failed to revoke session",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+			zap.String("session_id", sessionID),
+		)
+		return fmt.Errorf("세션 로그아웃에 실패했습니다: %w", err)
+	}
+
+	s.logger.Info("Session revoked",
+		zap.Uint("user_id", userID),
+		zap.String("session_id", sessionID),
+	)
+
+	return nil
+}
+
+// truncate 문자열을 최대 길이(바이트)로 자름 (멀티바이트 문자 중간에서 자르지 않음)
+func truncate(value string, maxLen int) string {
+	if len(value) <= maxLen {
+		return value
+	}
+	for maxLen > 0 && !utf8.RuneStart(value[maxLen]) {
+		maxLen--
+	}
+	return value[:maxLen]
+}
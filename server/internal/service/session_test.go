@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+func TestAuthService_Sessions(t *testing.T) {
+	svc, db := setupAuthService(t)
+	user := createTestUser(t, db, "session@example.com")
+
+	phone := ClientInfo{DeviceID: "device-phone", Platform: "ios", AppVersion: "1.0.0", IP: "10.0.0.1", UserAgent: "ojeomneo-ios"}
+	tablet := ClientInfo{DeviceID: "device-tablet", Platform: "android", AppVersion: "1.0.0", IP: "10.0.0.2"}
+
+	phoneAccess, phoneRefresh, err := svc.issueSessionTokens(user.ID, model.LoginMethodEmail, phone)
+	require.NoError(t, err)
+	tabletAccess, tabletRefresh, err := svc.issueSessionTokens(user.ID, model.LoginMethodGoogle, tablet)
+	require.NoError(t, err)
+
+	phoneClaims, err := auth.ValidateAccessToken(phoneAccess, svc.keys)
+	require.NoError(t, err)
+	tabletClaims, err := auth.ValidateAccessToken(tabletAccess, svc.keys)
+	require.NoError(t, err)
+
+	t.Run("로그인 시 기기 정보와 함께 세션 기록", func(t *testing.T) {
+		sessions, err := svc.ListSessions(user.ID, phoneClaims.SessionID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+
+		var current *SessionResponse
+		for i := range sessions {
+			if sessions[i].Current {
+				current = &sessions[i]
+			}
+		}
+		require.NotNil(t, current)
+		assert.Equal(t, phoneClaims.SessionID, current.ID)
+		assert.Equal(t, "email", current.LoginMethod)
+		require.NotNil(t, current.DeviceID)
+		assert.Equal(t, "device-phone", *current.DeviceID)
+		assert.Equal(t, "ios", current.Platform)
+		assert.Equal(t, "10.0.0.1", current.IP)
+	})
+
+	t.Run("토큰 갱신 시 같은 세션의 접속 정보 갱신", func(t *testing.T) {
+		refreshed, err := svc.RefreshToken(phoneRefresh, ClientInfo{AppVersion: "1.1.0", IP: "10.0.0.9"})
+		require.NoError(t, err)
+		phoneRefresh = refreshed.RefreshToken
+
+		claims, err := auth.ValidateAccessToken(refreshed.AccessToken, svc.keys)
+		require.NoError(t, err)
+		assert.Equal(t, phoneClaims.SessionID, claims.SessionID)
+
+		var session model.Session
+		require.NoError(t, db.First(&session, "id = ?", phoneClaims.SessionID).Error)
+		assert.Equal(t, "1.1.0", session.AppVersion)
+		assert.Equal(t, "10.0.0.9", session.IP)
+		assert.Equal(t, "ios", session.Platform)
+	})
+
+	t.Run("다른 사용자의 세션은 로그아웃할 수 없음", func(t *testing.T) {
+		other := createTestUser(t, db, "other-session@example.com")
+		err := svc.RevokeSession(other.ID, tabletClaims.SessionID)
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+
+	t.Run("세션 로그아웃 시 해당 기기 토큰만 폐기", func(t *testing.T) {
+		require.NoError(t, svc.RevokeSession(user.ID, tabletClaims.SessionID))
+
+		_, err := auth.ValidateAccessToken(tabletAccess, svc.keys, svc.revoker)
+		assert.ErrorIs(t, err, auth.ErrTokenRevoked)
+		_, err = svc.RefreshToken(tabletRefresh, ClientInfo{})
+		assert.Error(t, err)
+
+		_, err = auth.ValidateAccessToken(phoneAccess, svc.keys, svc.revoker)
+		assert.NoError(t, err)
+		_, err = svc.RefreshToken(phoneRefresh, ClientInfo{})
+		assert.NoError(t, err)
+
+		sessions, err := svc.ListSessions(user.ID, "")
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, phoneClaims.SessionID, sessions[0].ID)
+
+		assert.ErrorIs(t, svc.RevokeSession(user.ID, tabletClaims.SessionID), ErrSessionNotFound)
+	})
+
+	t.Run("전체 로그아웃 시 모든 세션 종료", func(t *testing.T) {
+		require.NoError(t, svc.LogoutAll(user.ID))
+
+		sessions, err := svc.ListSessions(user.ID, "")
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
+
+func TestAuthService_GuestLogin_Session(t *testing.T) {
+	svc, db := setupAuthService(t)
+
+	response, err := svc.GuestLogin("guest-device", ClientInfo{Platform: "android"})
+	require.NoError(t, err)
+
+	claims, err := auth.ValidateAccessToken(response.AccessToken, svc.keys, svc.revoker)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.SessionID)
+
+	var session model.Session
+	require.NoError(t, db.First(&session, "id = ?", claims.SessionID).Error)
+	assert.Equal(t, model.LoginMethodGuest, session.LoginMethod)
+	require.NotNil(t, session.DeviceID)
+	assert.Equal(t, "guest-device", *session.DeviceID)
+}
@@ -22,7 +22,7 @@ import (
 // SketchService 스케치 서비스
 type SketchService struct {
 	db          *gorm.DB
-	llmClient   *llm.Client
+	llmClient   llm.Provider
 	menuService *MenuService
 	uploadPath  string
 	reasonCache *cache.RecommendationCache
@@ -30,7 +30,7 @@ type SketchService struct {
 }
 
 // NewSketchService 새 스케치 서비스 생성
-func NewSketchService(db *gorm.DB, llmClient *llm.Client, menuService *MenuService, logger *zap.Logger) *SketchService {
+func NewSketchService(db *gorm.DB, llmClient llm.Provider, menuService *MenuService, logger *zap.Logger) *SketchService {
 	uploadPath := os.Getenv("UPLOAD_PATH")
 	if uploadPath == "" {
 		uploadPath = "./uploads"
@@ -58,6 +58,8 @@ type AnalyzeRequest struct {
 	InputText string
 	DeviceID  string
 	UserID    *uint
+	OnStage   func(stage SketchJobStatus) // 진행 단계 알림 (비동기 작업 상태 갱신용, nil 가능)
+	OnEvent   func(event SketchEvent)     // 중간 결과 알림 (SSE 스트리밍용, nil 가능, 여러 goroutine에서 호출)
 }
 
 // AnalyzeResponse 스케치 분석 응답
@@ -94,6 +96,7 @@ func (s *SketchService) Analyze(ctx context.Context, req *AnalyzeRequest) (*Anal
 	)
 
 	// 2. LLM으로 스케치 분석 (비동기 처리 준비)
+	req.notifyStage(SketchJobAnalyzing)
 	llmStart := time.Now()
 	analysis, err := s.llmClient.AnalyzeSketch(ctx, req.ImageData, req.InputText)
 	llmDuration := time.Since(llmStart)
@@ -110,6 +113,7 @@ func (s *SketchService) Analyze(ctx context.Context, req *AnalyzeRequest) (*Anal
 	s.logger.Debug("LLM analysis completed",
 		zap.String("device_id", req.DeviceID),
 		zap.Int("keyword_count", len(analysis.Keywords)),
+		zap.String("tier", analysis.Tier),
 		zap.Duration("llm_duration", llmDuration),
 	)
 
@@ -131,8 +135,10 @@ func (s *SketchService) Analyze(ctx context.Context, req *AnalyzeRequest) (*Anal
 	if err := s.db.WithContext(ctx).Create(sketch).Error; err != nil {
 		return nil, fmt.Errorf("failed to save sketch: %w", err)
 	}
+	req.emit(SketchEventAnalysis, &SketchAnalysisEvent{SketchID: sketch.ID, Analysis: analysis})
 
 	// 5. 키워드 기반 메뉴 검색 (Primary 1개 + Alternative 1개 = 2개만 필요)
+	req.notifyStage(SketchJobMatching)
 	menus, err := s.menuService.FindByKeywords(ctx, analysis.Keywords, 2)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find menus: %w", err)
@@ -141,9 +147,16 @@ func (s *SketchService) Analyze(ctx context.Context, req *AnalyzeRequest) (*Anal
 	if len(menus) == 0 {
 		return nil, fmt.Errorf("no menus found")
 	}
+	if req.streaming() {
+		candidates := make([]model.MenuRecommendation, len(menus))
+		for i := range menus {
+			candidates[i] = *s.toMenuRecommendation(&menus[i], "")
+		}
+		req.emit(SketchEventMenus, &SketchMenusEvent{Menus: candidates})
+	}
 
 	// 6. 추천 이유 생성 및 저장
-	recommendations, err := s.createRecommendations(ctx, sketch.ID, analysis, menus)
+	recommendations, err := s.createRecommendations(ctx, req, sketch.ID, analysis, menus)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create recommendations: %w", err)
 	}
@@ -158,10 +171,18 @@ func (s *SketchService) Analyze(ctx context.Context, req *AnalyzeRequest) (*Anal
 			Alternatives: s.toAlternatives(menus[1:], recommendations[1:]),
 		},
 	}
+	req.emit(SketchEventDone, response)
 
 	return response, nil
 }
 
+// notifyStage 진행 단계 알림 (OnStage가 없으면 무시)
+func (r *AnalyzeRequest) notifyStage(stage SketchJobStatus) {
+	if r.OnStage != nil {
+		r.OnStage(stage)
+	}
+}
+
 // recommendationResult goroutine 결과를 담는 구조체
 type recommendationResult struct {
 	index  int
@@ -170,7 +191,8 @@ type recommendationResult struct {
 }
 
 // createRecommendations 추천 생성 및 저장 (goroutine 병렬 처리 + 캐싱)
-func (s *SketchService) createRecommendations(ctx context.Context, sketchID uuid.UUID, analysis *llm.AnalysisResult, menus []model.Menu) ([]model.Recommendation, error) {
+// 스트리밍 요청이면 메뉴별로 완성되는 대로 reason 이벤트를 보내며, 제공자가 지원하면(llm.ReasonStreamer) 토큰 단위 조각도 보낸다.
+func (s *SketchService) createRecommendations(ctx context.Context, req *AnalyzeRequest, sketchID uuid.UUID, analysis *llm.AnalysisResult, menus []model.Menu) ([]model.Recommendation, error) {
 	recommendations := make([]model.Recommendation, len(menus))
 	reasons := make([]string, len(menus))
 
@@ -182,15 +204,24 @@ func (s *SketchService) createRecommendations(ctx context.Context, sketchID uuid
 		// 캐시에서 먼저 확인
 		if cachedReason, found := s.reasonCache.Get(analysis.Emotion, analysis.Keywords, menu.Name); found {
 			reasons[i] = cachedReason
+			req.emit(SketchEventReason, &SketchReasonEvent{MenuID: menu.ID, Rank: i + 1, Reason: cachedReason, Done: true})
 			continue
 		}
 
 		// 캐시 미스: goroutine으로 LLM 호출
 		wg.Add(1)
-		go func(idx int, menuName string) {
+		go func(idx int, menuID uint, menuName string) {
 			defer wg.Done()
 
-			reason, err := s.llmClient.GenerateRecommendationReason(ctx, analysis.Emotion, analysis.Keywords, menuName)
+			var reason string
+			var err error
+			if streamer, ok := s.llmClient.(llm.ReasonStreamer); ok && req.streaming() {
+				reason, err = streamer.StreamRecommendationReason(ctx, analysis.Emotion, analysis.Keywords, menuName, func(delta string) {
+					req.emit(SketchEventReason, &SketchReasonEvent{MenuID: menuID, Rank: idx + 1, Delta: delta})
+				})
+			} else {
+				reason, err = s.llmClient.GenerateRecommendationReason(ctx, analysis.Emotion, analysis.Keywords, menuName)
+			}
 			if err != nil {
 				// 에러 시 기본 이유 사용
 				reason = fmt.Sprintf("%s이(가) 지금 당신에게 딱 맞는 선택이에요!", menuName)
@@ -198,13 +229,14 @@ func (s *SketchService) createRecommendations(ctx context.Context, sketchID uuid
 				// 성공 시 캐시에 저장
 				s.reasonCache.Set(analysis.Emotion, analysis.Keywords, menuName, reason)
 			}
+			req.emit(SketchEventReason, &SketchReasonEvent{MenuID: menuID, Rank: idx + 1, Reason: reason, Done: true})
 
 			resultChan <- recommendationResult{
 				index:  idx,
 				reason: reason,
 				err:    nil,
 			}
-		}(i, menu.Name)
+		}(i, menu.ID, menu.Name)
 	}
 
 	// 모든 goroutine 완료 대기 후 채널 닫기
@@ -290,16 +322,20 @@ func (s *SketchService) toAlternatives(menus []model.Menu, recommendations []mod
 }
 
 // GetHistory 디바이스별 히스토리 조회
-// userID가 nil인 경우(비로그인 사용자)는 3일 이상 된 데이터는 제외
+// userID가 있으면(로그인 사용자) 계정 기준으로 전체 기간을 조회하고,
+// nil인 경우(비로그인 사용자)는 device_id 기준으로 3일 이상 된 데이터는 제외
 func (s *SketchService) GetHistory(ctx context.Context, deviceID string, userID *uint, page, limit int) ([]model.Sketch, int64, error) {
 	var sketches []model.Sketch
 	var total int64
 
-	query := s.db.WithContext(ctx).Model(&model.Sketch{}).
-		Where("device_id = ?", deviceID)
+	query := s.db.WithContext(ctx).Model(&model.Sketch{})
+
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	} else {
+		query = query.Where("device_id = ?", deviceID)
 
-	// 비로그인 사용자(userID == nil)인 경우 3일 이내 데이터만 조회
-	if userID == nil {
+		// 비로그인 사용자(userID == nil)인 경우 3일 이내 데이터만 조회
 		threeDaysAgo := time.Now().AddDate(0, 0, -3)
 		query = query.Where("created_at >= ?", threeDaysAgo)
 	}
@@ -361,3 +397,51 @@ func (s *SketchService) GetByID(ctx context.Context, id uuid.UUID) (*model.Sketc
 	}
 	return &sketch, nil
 }
+
+// PurgeUserSketches 사용자의 스케치, 추천 기록, 업로드 이미지 파일 완전 삭제 (탈퇴 계정 정리용)
+// soft delete된 스케치도 포함하며, 삭제한 스케치 수 반환
+func (s *SketchService) PurgeUserSketches(ctx context.Context, userID uint) (int, error) {
+	var sketches []model.Sketch
+	if err := s.db.WithContext(ctx).Unscoped().
+		Select("id", "image_path").
+		Where("user_id = ?", userID).
+		Find(&sketches).Error; err != nil {
+		return 0, fmt.Errorf("failed to list sketches: %w", err)
+	}
+	if len(sketches) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, len(sketches))
+	for i := range sketches {
+		ids[i] = sketches[i].ID
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("sketch_id IN ?", ids).Delete(&model.Recommendation{}).Error; err != nil {
+			return fmt.Errorf("failed to delete recommendations: %w", err)
+		}
+		if err := tx.Unscoped().Where("id IN ?", ids).Delete(&model.Sketch{}).Error; err != nil {
+			return fmt.Errorf("failed to delete sketches: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// DB 삭제 후 이미지 파일 삭제 (파일 삭제 실패는 로그만 남김)
+	for i := range sketches {
+		if sketches[i].ImagePath == "" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.uploadPath, sketches[i].ImagePath)); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("Failed to remove sketch image",
+				zap.Error(err),
+				zap.String("image_path", sketches[i].ImagePath),
+			)
+		}
+	}
+
+	return len(sketches), nil
+}
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+)
+
+const (
+	// sketchJobQueueKey 대기 중인 작업 ID 목록 (LPUSH/BRPOP)
+	sketchJobQueueKey = "sketch:jobs:queue"
+	// sketchJobKeyPrefix 작업 상태/입력 Redis 키 prefix
+	sketchJobKeyPrefix = "sketch:job:"
+	// sketchJobTTL 작업 상태 보관 시간 (완료 후 폴링 가능 기간 포함)
+	sketchJobTTL = time.Hour
+	// sketchJobTimeout 작업 하나의 최대 처리 시간 (이미지 분석 + 추천 이유 생성)
+	sketchJobTimeout = 2 * time.Minute
+	// sketchJobPollTimeout Redis 대기열 BRPOP 대기 시간 (종료 신호 확인 주기)
+	sketchJobPollTimeout = 2 * time.Second
+
+	defaultSketchJobWorkers   = 4
+	defaultSketchJobQueueSize = 100
+)
+
+// SketchJobStatus 비동기 스케치 분석 작업 상태
+type SketchJobStatus string
+
+const (
+	SketchJobQueued    SketchJobStatus = "queued"    // 대기 중
+	SketchJobAnalyzing SketchJobStatus = "analyzing" // 이미지 저장 및 LLM 분석 중
+	SketchJobMatching  SketchJobStatus = "matching"  // 메뉴 검색 및 추천 이유 생성 중
+	SketchJobDone      SketchJobStatus = "done"      // 완료 (result 포함)
+	SketchJobFailed    SketchJobStatus = "failed"    // 실패 (error 포함)
+)
+
+var (
+	// ErrSketchJobNotFound 없거나 보관 기간이 지난 작업
+	ErrSketchJobNotFound = errors.New("분석 작업을 찾을 수 없습니다")
+	// ErrSketchQueueFull 대기열이 가득 참 (잠시 후 재시도)
+	ErrSketchQueueFull = errors.New("분석 요청이 많습니다. 잠시 후 다시 시도해 주세요")
+)
+
+// SketchJob 비동기 스케치 분석 작업
+type SketchJob struct {
+	ID        string           `json:"id"`
+	Status    SketchJobStatus  `json:"status"`
+	Result    *AnalyzeResponse `json:"result,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// IsFinished 완료 또는 실패 여부
+func (j *SketchJob) IsFinished() bool {
+	return j.Status == SketchJobDone || j.Status == SketchJobFailed
+}
+
+// sketchJobInput 작업 입력 (Redis에는 상태와 별도 키로 저장, 처리 시작 시 삭제)
+type sketchJobInput struct {
+	ImageData []byte `json:"image_data"`
+	InputText string `json:"input_text"`
+	DeviceID  string `json:"device_id"`
+	UserID    *uint  `json:"user_id,omitempty"`
+}
+
+// SketchJobQueue 비동기 스케치 분석 대기열과 작업자
+// Redis가 있으면 대기열과 상태를 Redis에 두어 여러 인스턴스가 나눠 처리하고,
+// 없거나 Redis 오류 시에는 프로세스 안의 대기열로 처리한다 (재시작 시 대기 중인 작업은 사라짐).
+type SketchJobQueue struct {
+	sketches  *SketchService
+	rdb       *redis.Client
+	workers   int
+	logger    *zap.Logger
+	local     chan string
+	mu        sync.Mutex
+	jobs      map[string]*SketchJob      // 프로세스 안 대기열 작업 상태
+	inputs    map[string]*sketchJobInput // 프로세스 안 대기열 작업 입력
+	wg        sync.WaitGroup
+	stopOnce  sync.Once
+	stopQueue context.CancelFunc
+}
+
+// NewSketchJobQueue 새 스케치 분석 대기열 생성 (rdb는 nil 가능)
+func NewSketchJobQueue(sketches *SketchService, rdb *redis.Client, cfg *config.Config, logger *zap.Logger) *SketchJobQueue {
+	workers := cfg.SketchJobWorkers
+	if workers <= 0 {
+		workers = defaultSketchJobWorkers
+	}
+	queueSize := cfg.SketchJobQueueSize
+	if queueSize <= 0 {
+		queueSize = defaultSketchJobQueueSize
+	}
+
+	return &SketchJobQueue{
+		sketches: sketches,
+		rdb:      rdb,
+		workers:  workers,
+		logger:   logger,
+		local:    make(chan string, queueSize),
+		jobs:     make(map[string]*SketchJob),
+		inputs:   make(map[string]*sketchJobInput),
+	}
+}
+
+// Enqueue 분석 작업 등록 후 대기 상태의 작업 반환
+func (q *SketchJobQueue) Enqueue(ctx context.Context, req *AnalyzeRequest) (*SketchJob, error) {
+	now := time.Now()
+	job := &SketchJob{
+		ID:        uuid.NewString(),
+		Status:    SketchJobQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	input := &sketchJobInput{
+		ImageData: req.ImageData,
+		InputText: req.InputText,
+		DeviceID:  req.DeviceID,
+		UserID:    req.UserID,
+	}
+
+	if q.rdb != nil {
+		err := q.enqueueRedis(ctx, job, input)
+		if err == nil {
+			return job, nil
+		}
+		q.logger.Warn("Sketch job enqueue to Redis failed, falling back to in-process queue",
+			zap.Error(err),
+			zap.String("job_id", job.ID),
+		)
+	}
+
+	// 대기열에 넣는 즉시 작업자가 상태를 바꿀 수 있으므로 응답용 복사본은 먼저 만든다
+	q.mu.Lock()
+	q.sweepLocked(now)
+	q.jobs[job.ID] = job
+	q.inputs[job.ID] = input
+	queued := q.snapshot(job)
+	q.mu.Unlock()
+
+	select {
+	case q.local <- job.ID:
+		return queued, nil
+	default:
+		q.mu.Lock()
+		delete(q.jobs, job.ID)
+		delete(q.inputs, job.ID)
+		q.mu.Unlock()
+		return nil, ErrSketchQueueFull
+	}
+}
+
+// enqueueRedis 입력, 상태 저장 후 대기열에 추가
+func (q *SketchJobQueue) enqueueRedis(ctx context.Context, job *SketchJob, input *sketchJobInput) error {
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sketch job input: %w", err)
+	}
+	jobJSON, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sketch job: %w", err)
+	}
+
+	_, err = q.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sketchJobKeyPrefix+job.ID+":input", inputJSON, sketchJobTTL)
+		pipe.Set(ctx, sketchJobKeyPrefix+job.ID, jobJSON, sketchJobTTL)
+		pipe.LPush(ctx, sketchJobQueueKey, job.ID)
+		return nil
+	})
+	return err
+}
+
+// Get 작업 상태 조회 (없거나 보관 기간이 지나면 ErrSketchJobNotFound)
+func (q *SketchJobQueue) Get(ctx context.Context, id string) (*SketchJob, error) {
+	if q.rdb != nil {
+		data, err := q.rdb.Get(ctx, sketchJobKeyPrefix+id).Bytes()
+		if err == nil {
+			var job SketchJob
+			if err := json.Unmarshal(data, &job); err != nil {
+				return nil, fmt.Errorf("failed to decode sketch job: %w", err)
+			}
+			return &job, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			q.logger.Warn("Sketch job lookup in Redis failed",
+				zap.Error(err),
+				zap.String("job_id", id),
+			)
+		}
+		// Redis 장애 중 프로세스 안 대기열에 등록된 작업일 수 있으므로 계속 확인
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, ok := q.jobs[id]
+	if !ok || time.Since(job.UpdatedAt) > sketchJobTTL {
+		return nil, ErrSketchJobNotFound
+	}
+	return q.snapshot(job), nil
+}
+
+// Start 작업자 실행 (Stop 호출 전까지 대기열 처리)
+func (q *SketchJobQueue) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	q.stopQueue = cancel
+
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	q.logger.Info("Sketch job workers started",
+		zap.Int("workers", q.workers),
+		zap.Bool("redis_queue", q.rdb != nil),
+	)
+}
+
+// Stop 새 작업 수신을 멈추고 처리 중인 작업이 끝날 때까지 대기 (ctx 만료 시 대기 중단)
+func (q *SketchJobQueue) Stop(ctx context.Context) error {
+	q.stopOnce.Do(func() {
+		if q.stopQueue != nil {
+			q.stopQueue()
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("sketch workers did not stop in time: %w", ctx.Err())
+	}
+}
+
+// work 작업자 루프 (프로세스 안 대기열을 먼저 확인하고, Redis가 있으면 Redis 대기열 대기)
+func (q *SketchJobQueue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-q.local:
+			q.process(id, q.takeLocalInput(id))
+			continue
+		default:
+		}
+
+		if q.rdb == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case id := <-q.local:
+				q.process(id, q.takeLocalInput(id))
+			}
+			continue
+		}
+
+		result, err := q.rdb.BRPop(ctx, sketchJobPollTimeout, sketchJobQueueKey).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			q.logger.Warn("Sketch job dequeue from Redis failed", zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(sketchJobPollTimeout):
+			}
+			continue
+		}
+		q.process(result[1], q.takeRedisInput(result[1]))
+	}
+}
+
+// takeLocalInput 프로세스 안 대기열 작업 입력 꺼내기
+func (q *SketchJobQueue) takeLocalInput(id string) *sketchJobInput {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	input := q.inputs[id]
+	delete(q.inputs, id)
+	return input
+}
+
+// takeRedisInput Redis 작업 입력 꺼내기 (한 작업자만 가져감)
+func (q *SketchJobQueue) takeRedisInput(id string) *sketchJobInput {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	data, err := q.rdb.GetDel(ctx, sketchJobKeyPrefix+id+":input").Bytes()
+	if err != nil {
+		q.logger.Warn("Sketch job input not found",
+			zap.Error(err),
+			zap.String("job_id", id),
+		)
+		return nil
+	}
+
+	var input sketchJobInput
+	if err := json.Unmarshal(data, &input); err != nil {
+		q.logger.Warn("Failed to decode sketch job input",
+			zap.Error(err),
+			zap.String("job_id", id),
+		)
+		return nil
+	}
+	return &input
+}
+
+// process 작업 하나 처리 (작업자 종료와 무관하게 sketchJobTimeout까지 진행)
+func (q *SketchJobQueue) process(id string, input *sketchJobInput) {
+	if input == nil {
+		q.update(id, func(job *SketchJob) {
+			job.Status = SketchJobFailed
+			job.Error = "분석 요청이 만료되었습니다. 다시 시도해 주세요"
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sketchJobTimeout)
+	defer cancel()
+
+	start := time.Now()
+	q.update(id, func(job *SketchJob) { job.Status = SketchJobAnalyzing })
+
+	result, err := q.sketches.Analyze(ctx, &AnalyzeRequest{
+		ImageData: input.ImageData,
+		InputText: input.InputText,
+		DeviceID:  input.DeviceID,
+		UserID:    input.UserID,
+		OnStage: func(stage SketchJobStatus) {
+			q.update(id, func(job *SketchJob) { job.Status = stage })
+		},
+	})
+	if err != nil {
+		q.logger.Error("Sketch job failed",
+			zap.Error(err),
+			zap.String("job_id", id),
+			zap.String("device_id", input.DeviceID),
+			zap.Duration("duration", time.Since(start)),
+		)
+		q.update(id, func(job *SketchJob) {
+			job.Status = SketchJobFailed
+			job.Error = sketchJobErrorMessage(err)
+		})
+		return
+	}
+
+	q.update(id, func(job *SketchJob) {
+		job.Status = SketchJobDone
+		job.Result = result
+	})
+	q.logger.Info("Sketch job completed",
+		zap.String("job_id", id),
+		zap.String("device_id", input.DeviceID),
+		zap.String("sketch_id", result.SketchID.String()),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+// sketchJobErrorMessage 작업 실패 시 사용자에게 보여줄 메시지 (상세 오류는 로그에만 남김)
+func sketchJobErrorMessage(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "분석 시간이 초과되었습니다. 다시 시도해 주세요"
+	}
+	return "분석에 실패했습니다. 잠시 후 다시 시도해 주세요"
+}
+
+// update 작업 상태 변경 (Redis 작업이면 Redis에, 아니면 프로세스 안 상태에 저장)
+func (q *SketchJobQueue) update(id string, apply func(job *SketchJob)) {
+	q.mu.Lock()
+	if job, ok := q.jobs[id]; ok {
+		apply(job)
+		job.UpdatedAt = time.Now()
+		q.mu.Unlock()
+		return
+	}
+	q.mu.Unlock()
+
+	if q.rdb == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	key := sketchJobKeyPrefix + id
+	job := SketchJob{ID: id, CreatedAt: time.Now()}
+	if data, err := q.rdb.Get(ctx, key).Bytes(); err == nil {
+		if err := json.Unmarshal(data, &job); err != nil {
+			q.logger.Warn("Failed to decode sketch job", zap.Error(err), zap.String("job_id", id))
+		}
+	}
+	apply(&job)
+	job.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(&job)
+	if err != nil {
+		q.logger.Warn("Failed to encode sketch job", zap.Error(err), zap.String("job_id", id))
+		return
+	}
+	if err := q.rdb.Set(ctx, key, data, sketchJobTTL).Err(); err != nil {
+		q.logger.Warn("Failed to save sketch job status",
+			zap.Error(err),
+			zap.String("job_id", id),
+			zap.String("status", string(job.Status)),
+		)
+	}
+}
+
+// snapshot 작업 상태 복사본 (프로세스 안 작업은 q.mu 보유 상태에서 호출)
+func (q *SketchJobQueue) snapshot(job *SketchJob) *SketchJob {
+	copied := *job
+	return &copied
+}
+
+// sweepLocked 보관 기간이 지난 프로세스 안 작업 상태 정리 (q.mu 보유 상태에서 호출)
+func (q *SketchJobQueue) sweepLocked(now time.Time) {
+	for id, job := range q.jobs {
+		if job.IsFinished() && now.Sub(job.UpdatedAt) > sketchJobTTL {
+			delete(q.jobs, id)
+		}
+	}
+}
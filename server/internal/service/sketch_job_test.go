@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/internal/service/llm"
+)
+
+// setupSketchJobQueue 프로세스 안 대기열(Redis 없음)로 동작하는 테스트용 대기열
+func setupSketchJobQueue(t *testing.T, queueSize int) *SketchJobQueue {
+	_, db := setupAuthService(t)
+	createSketchTables(t, db)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}, &model.MenuImage{}))
+	createTestMenus(t, db)
+
+	logger := setupTestLogger()
+	sketches := NewSketchService(db, llm.NewMockProvider(), NewMenuService(db, logger), logger)
+	sketches.uploadPath = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(sketches.uploadPath, "sketches"), 0o755))
+
+	return NewSketchJobQueue(sketches, nil, &config.Config{SketchJobWorkers: 2, SketchJobQueueSize: queueSize}, logger)
+}
+
+func TestSketchJobQueue_InProcess(t *testing.T) {
+	// SQLite에는 jsonb 연산자가 없어 메뉴 검색 단계(matching)에서 실패하므로 실패 처리까지 확인
+	t.Run("분석 단계를 거쳐 완료 상태로 기록", func(t *testing.T) {
+		queue := setupSketchJobQueue(t, 10)
+		queue.Start()
+		defer queue.Stop(context.Background())
+
+		var stages []SketchJobStatus
+		_, err := queue.sketches.Analyze(context.Background(), &AnalyzeRequest{
+			ImageData: []byte("png"),
+			DeviceID:  "device-stage",
+			OnStage:   func(stage SketchJobStatus) { stages = append(stages, stage) },
+		})
+		require.Error(t, err)
+		assert.Equal(t, []SketchJobStatus{SketchJobAnalyzing, SketchJobMatching}, stages)
+
+		job, err := queue.Enqueue(context.Background(), &AnalyzeRequest{ImageData: []byte("png"), DeviceID: "device-job"})
+		require.NoError(t, err)
+		assert.Equal(t, SketchJobQueued, job.Status)
+
+		require.Eventually(t, func() bool {
+			got, err := queue.Get(context.Background(), job.ID)
+			return err == nil && got.IsFinished()
+		}, 10*time.Second, 20*time.Millisecond)
+
+		got, err := queue.Get(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, SketchJobFailed, got.Status)
+		assert.Equal(t, "분석에 실패했습니다. 잠시 후 다시 시도해 주세요", got.Error)
+		assert.NotContains(t, got.Error, "failed to find menus")
+		assert.Nil(t, got.Result)
+	})
+
+	t.Run("작업자 실행 중 등록해도 대기 상태 복사본 반환", func(t *testing.T) {
+		queue := setupSketchJobQueue(t, 10)
+		queue.Start()
+		defer queue.Stop(context.Background())
+
+		for i := 0; i < 5; i++ {
+			job, err := queue.Enqueue(context.Background(), &AnalyzeRequest{ImageData: []byte("png"), DeviceID: "device-race"})
+			require.NoError(t, err)
+			assert.Equal(t, SketchJobQueued, job.Status)
+		}
+	})
+
+	t.Run("대기열이 가득 차면 ErrSketchQueueFull", func(t *testing.T) {
+		queue := setupSketchJobQueue(t, 1) // 작업자 미실행
+
+		_, err := queue.Enqueue(context.Background(), &AnalyzeRequest{ImageData: []byte("png"), DeviceID: "device-1"})
+		require.NoError(t, err)
+		_, err = queue.Enqueue(context.Background(), &AnalyzeRequest{ImageData: []byte("png"), DeviceID: "device-2"})
+		assert.ErrorIs(t, err, ErrSketchQueueFull)
+	})
+
+	t.Run("없는 작업 조회", func(t *testing.T) {
+		queue := setupSketchJobQueue(t, 1)
+
+		_, err := queue.Get(context.Background(), "00000000-0000-0000-0000-000000000000")
+		assert.ErrorIs(t, err, ErrSketchJobNotFound)
+	})
+
+	t.Run("종료 시 처리 중인 작업 완료 대기", func(t *testing.T) {
+		queue := setupSketchJobQueue(t, 10)
+		job, err := queue.Enqueue(context.Background(), &AnalyzeRequest{ImageData: []byte("png"), DeviceID: "device-stop"})
+		require.NoError(t, err)
+
+		queue.Start()
+		require.Eventually(t, func() bool {
+			got, err := queue.Get(context.Background(), job.ID)
+			return err == nil && got.Status != SketchJobQueued
+		}, 10*time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, queue.Stop(ctx))
+
+		got, err := queue.Get(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.True(t, got.IsFinished())
+	})
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/internal/service/llm"
+)
+
+// SketchEventType 스트리밍 분석 이벤트 종류 (SSE event 이름)
+type SketchEventType string
+
+const (
+	SketchEventAnalysis SketchEventType = "analysis" // 감정 분석 완료
+	SketchEventMenus    SketchEventType = "menus"    // 추천 메뉴 확정 (추천 이유 제외)
+	SketchEventReason   SketchEventType = "reason"   // 추천 이유 조각 또는 완성본
+	SketchEventDone     SketchEventType = "done"     // 전체 분석 결과
+	SketchEventError    SketchEventType = "error"    // 분석 실패 (핸들러에서 전송)
+)
+
+// SketchEvent 스트리밍 분석 이벤트
+type SketchEvent struct {
+	Type SketchEventType
+	Data interface{}
+}
+
+// SketchAnalysisEvent analysis 이벤트 데이터
+type SketchAnalysisEvent struct {
+	SketchID uuid.UUID           `json:"sketch_id"`
+	Analysis *llm.AnalysisResult `json:"analysis"`
+}
+
+// SketchMenusEvent menus 이벤트 데이터 (순서대로 rank 1, 2, ...)
+type SketchMenusEvent struct {
+	Menus []model.MenuRecommendation `json:"menus"`
+}
+
+// SketchReasonEvent reason 이벤트 데이터
+// 생성 중에는 delta에 이어 붙일 조각을, 완성되면 done=true와 최종 reason을 보낸다.
+// 스트리밍이 중간에 실패하면 기본 문구로 대체되므로, 클라이언트는 done 이벤트의 reason으로 덮어써야 한다.
+type SketchReasonEvent struct {
+	MenuID uint   `json:"menu_id"`
+	Rank   int    `json:"rank"`
+	Delta  string `json:"delta,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	Done   bool   `json:"done"`
+}
+
+// streaming 이벤트 수신자가 있는지 여부 (있으면 추천 이유를 토큰 단위로 생성)
+func (r *AnalyzeRequest) streaming() bool {
+	return r.OnEvent != nil
+}
+
+// emit 이벤트 전달 (OnEvent가 없으면 무시, 여러 goroutine에서 호출될 수 있음)
+func (r *AnalyzeRequest) emit(eventType SketchEventType, data interface{}) {
+	if r.OnEvent != nil {
+		r.OnEvent(SketchEvent{Type: eventType, Data: data})
+	}
+}
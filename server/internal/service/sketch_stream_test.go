@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/internal/service/llm"
+)
+
+func TestSketchService_CreateRecommendations_Stream(t *testing.T) {
+	queue := setupSketchJobQueue(t, 1)
+	sketches := queue.sketches
+	menus := findTestMenus(t, sketches)[:2]
+	analysis := &llm.AnalysisResult{Emotion: "피곤하고 위로받고 싶은", Keywords: []string{"따뜻함"}, Mood: "calm"}
+
+	var mu sync.Mutex
+	var events []SketchEvent
+	req := &AnalyzeRequest{OnEvent: func(event SketchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}}
+
+	t.Run("메뉴별 추천 이유를 조각으로 보낸 뒤 완성본 전송", func(t *testing.T) {
+		recommendations, err := sketches.createRecommendations(context.Background(), req, uuid.New(), analysis, menus)
+		require.NoError(t, err)
+		require.Len(t, recommendations, 2)
+
+		for i, menu := range menus {
+			var streamed strings.Builder
+			var final *SketchReasonEvent
+			for _, event := range events {
+				reason := event.Data.(*SketchReasonEvent)
+				assert.Equal(t, SketchEventReason, event.Type)
+				if reason.MenuID != menu.ID {
+					continue
+				}
+				assert.Equal(t, i+1, reason.Rank)
+				require.Nil(t, final, "완성본 이후 조각 전송")
+				if reason.Done {
+					final = reason
+				} else {
+					streamed.WriteString(reason.Delta)
+				}
+			}
+			require.NotNil(t, final)
+			assert.Equal(t, recommendations[i].Reason, final.Reason)
+			assert.Equal(t, final.Reason, streamed.String())
+		}
+	})
+
+	t.Run("캐시된 추천 이유는 완성본만 전송", func(t *testing.T) {
+		events = nil
+		_, err := sketches.createRecommendations(context.Background(), req, uuid.New(), analysis, menus)
+		require.NoError(t, err)
+
+		require.Len(t, events, 2)
+		for _, event := range events {
+			assert.True(t, event.Data.(*SketchReasonEvent).Done)
+		}
+	})
+
+	t.Run("수신자가 없으면 이벤트 없이 생성", func(t *testing.T) {
+		events = nil
+		_, err := sketches.createRecommendations(context.Background(), &AnalyzeRequest{}, uuid.New(), analysis, []model.Menu{menus[0]})
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
+
+func TestSketchService_CreateRecommendations_NonStreamingProvider(t *testing.T) {
+	queue := setupSketchJobQueue(t, 1)
+	sketches := queue.sketches
+	// 스트리밍 미지원 제공자 (Provider 메서드만 노출)
+	sketches.llmClient = struct{ llm.Provider }{llm.NewMockProvider()}
+	menus := findTestMenus(t, sketches)[:2]
+	analysis := &llm.AnalysisResult{Emotion: "설레는", Keywords: []string{"봄"}, Mood: "bright"}
+
+	var mu sync.Mutex
+	var events []*SketchReasonEvent
+	req := &AnalyzeRequest{OnEvent: func(event SketchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event.Data.(*SketchReasonEvent))
+	}}
+
+	t.Run("조각 없이 메뉴별 완성본만 전송", func(t *testing.T) {
+		recommendations, err := sketches.createRecommendations(context.Background(), req, uuid.New(), analysis, menus)
+		require.NoError(t, err)
+
+		require.Len(t, events, 2)
+		for _, event := range events {
+			assert.True(t, event.Done)
+			assert.Empty(t, event.Delta)
+			assert.Equal(t, recommendations[event.Rank-1].Reason, event.Reason)
+		}
+	})
+}
+
+// findTestMenus setupSketchJobQueue에서 만든 테스트 메뉴 조회
+func findTestMenus(t *testing.T, sketches *SketchService) []model.Menu {
+	var menus []model.Menu
+	require.NoError(t, sketches.db.Order("id").Find(&menus).Error)
+	require.NotEmpty(t, menus)
+	return menus
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ggorockee/ojeomneo/server/internal/config"
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/sns"
+)
+
+// snsVerifyTimeout SNS 제공자 토큰 검증 제한 시간
+const snsVerifyTimeout = 10 * time.Second
+
+var (
+	// ErrUnsupportedProvider 등록되지 않은 SNS 제공자
+	ErrUnsupportedProvider = errors.New("지원하지 않는 로그인 방식입니다")
+	// ErrSNSTokenInvalid SNS 토큰 검증 실패 (만료, 위조, 제공자 API 오류)
+	ErrSNSTokenInvalid = errors.New("SNS 토큰 검증에 실패했습니다")
+	// ErrSNSEmailRequired 이메일 제공에 동의하지 않은 SNS 계정
+	ErrSNSEmailRequired = errors.New("이메일 제공 동의가 필요합니다")
+)
+
+// newIdentityProviders 설정이 있는 SNS 제공자만 등록한 목록 생성
+// 설정되지 않은 제공자는 ErrUnsupportedProvider로 거부된다 (Apple은 ClientID 없이 aud 검증 불가).
+// 제공자 API 주소는 설정으로 바꿀 수 있어 로컬 stub 서버로 전체 흐름을 테스트할 수 있음
+func newIdentityProviders(cfg *config.Config) *sns.Registry {
+	registry := sns.NewRegistry()
+	if cfg.FirebaseAdminSDKKey != "" {
+		registry.Register(sns.GoogleProvider{})
+	}
+	if cfg.AppleClientID != "" {
+		registry.Register(sns.AppleProvider{ClientID: cfg.AppleClientID, KeysURL: cfg.AppleKeysURL})
+	}
+	if cfg.KakaoRestAPIKey != "" {
+		registry.Register(sns.KakaoProvider{BaseURL: cfg.KakaoAPIBaseURL})
+	}
+	if cfg.NaverClientID != "" && cfg.NaverClientSecret != "" {
+		registry.Register(sns.NaverProvider{BaseURL: cfg.NaverAPIBaseURL})
+	}
+	return registry
+}
+
+// snsEmailRequired 이메일이 없으면 로그인할 수 없는 제공자인지 확인
+// Apple은 첫 로그인 이후 이메일을 주지 않으므로 social_id(sub)만으로 로그인 허용
+func snsEmailRequired(provider model.LoginMethod) bool {
+	return provider != model.LoginMethodApple
+}
+
+// verifySNSToken 제공자별 SNS 토큰 검증
+func (s *AuthService) verifySNSToken(provider model.LoginMethod, token string) (*sns.Identity, error) {
+	p, ok := s.providers.Get(string(provider))
+	if !ok {
+		return nil, ErrUnsupportedProvider
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), snsVerifyTimeout)
+	defer cancel()
+
+	identity, err := p.VerifyToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w (%s): %w", ErrSNSTokenInvalid, provider, err)
+	}
+	return identity, nil
+}
+
+// SNSLogin SNS 로그인 처리 (등록된 제공자로 토큰 검증 후 로그인/가입)
+func (s *AuthService) SNSLogin(provider model.LoginMethod, token string, client ClientInfo) (*AuthResponse, error) {
+	start := time.Now()
+	ctx := context.Background()
+	name := string(provider)
+
+	if _, ok := s.providers.Get(name); !ok {
+		return nil, ErrUnsupportedProvider
+	}
+
+	s.logger.Debug("Starting SNS login",
+		zap.String("provider", name),
+	)
+
+	identity, err := s.verifySNSToken(provider, token)
+	verifyDuration := time.Since(start)
+
+	if err != nil {
+		s.logger.Warn("SNS login token verification failed",
+			zap.Error(err),
+			zap.String("provider", name),
+			zap.Duration("duration", verifyDuration),
+		)
+		// 메트릭 기록: 토큰 검증 실패
+		if s.metrics != nil {
+			s.metrics.RecordSNSLogin(ctx, name, "token_invalid", float64(time.Since(start).Milliseconds()))
+		}
+		return nil, err
+	}
+
+	if identity.Email == "" && snsEmailRequired(provider) {
+		s.logger.Warn("SNS login missing email",
+			zap.String("provider", name),
+			zap.String("user_id", identity.Subject),
+		)
+		if s.metrics != nil {
+			s.metrics.RecordSNSLogin(ctx, name, "failed", float64(time.Since(start).Milliseconds()))
+		}
+		return nil, ErrSNSEmailRequired
+	}
+
+	s.logger.Debug("SNS token verified",
+		zap.String("provider", name),
+		zap.String("email", identity.Email),
+		zap.String("user_id", identity.Subject),
+		zap.Duration("verify_duration", verifyDuration),
+	)
+
+	response, err := s.handleSNSLogin(
+		name,
+		identity.Subject,
+		identity.Email, // Apple은 빈 문자열일 수 있음
+		identity.EmailVerified,
+		identity.Name,
+		client,
+	)
+
+	if err != nil {
+		s.logger.Error("SNS login failed",
+			zap.Error(err),
+			zap.String("provider", name),
+			zap.String("email", identity.Email),
+			zap.Duration("total_duration", time.Since(start)),
+		)
+		// 메트릭 기록: 로그인 실패
+		if s.metrics != nil {
+			s.metrics.RecordSNSLogin(ctx, name, "failed", float64(time.Since(start).Milliseconds()))
+		}
+		return nil, err
+	}
+
+	s.logger.Info("SNS login successful",
+		zap.String("provider", name),
+		zap.Uint("user_id", response.User.ID),
+		zap.String("email", response.User.Email),
+		zap.Duration("total_duration", time.Since(start)),
+	)
+
+	// 메트릭 기록: 로그인 성공
+	if s.metrics != nil {
+		s.metrics.RecordSNSLogin(ctx, name, "success", float64(time.Since(start).Milliseconds()))
+		s.metrics.RecordTokenIssued(ctx, "access")
+		s.metrics.RecordTokenIssued(ctx, "refresh")
+	}
+
+	return response, nil
+}
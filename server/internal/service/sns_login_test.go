@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/sns"
+)
+
+// newSNSStubServer 제공자 API stub 서버 (Kakao 사용자 정보, Naver 프로필, Apple JWKS)
+func newSNSStubServer(t *testing.T, appleKey *rsa.PrivateKey) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/user/me", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer kakao-token":
+			_, _ = w.Write([]byte(`{"id":1001,"kakao_account":{"email":"kakao@example.com","profile":{"nickname":"카카오"}}}`))
+		case "Bearer kakao-no-email":
+			_, _ = w.Write([]byte(`{"id":1002,"kakao_account":{"profile":{"nickname":"이메일없음"}}}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":-401,"msg":"this access token does not exist"}`))
+		}
+	})
+	mux.HandleFunc("/v1/nid/me", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer naver-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"resultcode":"024","message":"Authentication failed"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"resultcode":"00","message":"success","response":{"id":"naver-sub-1","email":"Naver.User@EXAMPLE.com","name":"네이버"}}`))
+	})
+	mux.HandleFunc("/auth/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-kid",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(appleKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(appleKey.E)).Bytes()),
+			}},
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// appleIdentityToken 테스트용 Apple Identity Token 생성
+func appleIdentityToken(t *testing.T, key *rsa.PrivateKey, subject, email string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   "https://appleid.apple.com",
+		"aud":   "com.example.ojeomneo",
+		"sub":   subject,
+		"email": email,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "test-kid"
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// setupSNSAuthService 제공자 API를 stub 서버로 연결한 인증 서비스
+func setupSNSAuthService(t *testing.T) (*AuthService, *rsa.PrivateKey) {
+	appleKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server := newSNSStubServer(t, appleKey)
+
+	svc, _ := setupAuthService(t)
+	svc.cfg.AppleClientID = "com.example.ojeomneo"
+	svc.cfg.AppleKeysURL = server.URL + "/auth/keys"
+	svc.cfg.KakaoRestAPIKey = "kakao-rest-key"
+	svc.cfg.NaverClientID = "naver-client-id"
+	svc.cfg.NaverClientSecret = "naver-client-secret"
+	svc.cfg.KakaoAPIBaseURL = server.URL
+	svc.cfg.NaverAPIBaseURL = server.URL
+	svc.providers = newIdentityProviders(svc.cfg)
+	return svc, appleKey
+}
+
+func TestAuthService_SNSLogin(t *testing.T) {
+	svc, appleKey := setupSNSAuthService(t)
+
+	t.Run("Kakao 신규 가입 후 같은 계정으로 재로그인", func(t *testing.T) {
+		first, err := svc.SNSLogin(model.LoginMethodKakao, "kakao-token", ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, "kakao@example.com", first.User.Email)
+		assert.Equal(t, string(model.LoginMethodKakao), first.User.LoginMethod)
+
+		second, err := svc.SNSLogin(model.LoginMethodKakao, "kakao-token", ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, first.User.ID, second.User.ID)
+	})
+
+	t.Run("Naver 로그인 시 identity 연결", func(t *testing.T) {
+		response, err := svc.SNSLogin(model.LoginMethodNaver, "naver-token", ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, "Naver.User@example.com", response.User.Email)
+
+		var identity model.UserIdentity
+		require.NoError(t, svc.db.Where("provider = ? AND subject = ?", model.LoginMethodNaver, "naver-sub-1").First(&identity).Error)
+		assert.Equal(t, response.User.ID, identity.UserID)
+	})
+
+	t.Run("Apple은 재로그인 시 이메일이 없어도 로그인", func(t *testing.T) {
+		first, err := svc.SNSLogin(model.LoginMethodApple, appleIdentityToken(t, appleKey, "apple-sub-1", "apple@example.com"), ClientInfo{})
+		require.NoError(t, err)
+
+		second, err := svc.SNSLogin(model.LoginMethodApple, appleIdentityToken(t, appleKey, "apple-sub-1", ""), ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, first.User.ID, second.User.ID)
+	})
+
+	t.Run("다른 키로 서명한 Apple 토큰 거부", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		_, err = svc.SNSLogin(model.LoginMethodApple, appleIdentityToken(t, otherKey, "apple-sub-2", "forged@example.com"), ClientInfo{})
+		assert.ErrorIs(t, err, ErrSNSTokenInvalid)
+	})
+
+	t.Run("유효하지 않은 토큰 거부", func(t *testing.T) {
+		_, err := svc.SNSLogin(model.LoginMethodKakao, "expired-token", ClientInfo{})
+		assert.ErrorIs(t, err, ErrSNSTokenInvalid)
+	})
+
+	t.Run("이메일 미동의 계정 거부", func(t *testing.T) {
+		_, err := svc.SNSLogin(model.LoginMethodKakao, "kakao-no-email", ClientInfo{})
+		assert.ErrorIs(t, err, ErrSNSEmailRequired)
+	})
+
+	t.Run("등록되지 않은 제공자 거부", func(t *testing.T) {
+		_, err := svc.SNSLogin("facebook", "token", ClientInfo{})
+		assert.ErrorIs(t, err, ErrUnsupportedProvider)
+
+		_, err = svc.SNSLogin(model.LoginMethodEmail, "token", ClientInfo{})
+		assert.ErrorIs(t, err, ErrUnsupportedProvider)
+	})
+}
+
+// fakeIdentityProvider 테스트용 제공자 (레지스트리 확장 확인)
+type fakeIdentityProvider struct{}
+
+func (fakeIdentityProvider) Name() string { return "line" }
+
+func (fakeIdentityProvider) VerifyToken(ctx context.Context, token string) (*sns.Identity, error) {
+	return &sns.Identity{Subject: "line-" + token, Email: token + "@line.example.com"}, nil
+}
+
+func TestAuthService_SNSLogin_CustomProvider(t *testing.T) {
+	svc, _ := setupAuthService(t)
+	svc.providers.Register(fakeIdentityProvider{})
+
+	response, err := svc.SNSLogin("line", "user1", ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "user1@line.example.com", response.User.Email)
+	assert.Contains(t, svc.providers.Names(), "line")
+}
+
+func TestNewIdentityProviders(t *testing.T) {
+	t.Run("설정이 없는 제공자는 등록하지 않음", func(t *testing.T) {
+		svc, _ := setupAuthService(t)
+		assert.Empty(t, svc.providers.Names())
+
+		_, err := svc.SNSLogin(model.LoginMethodApple, "token", ClientInfo{})
+		assert.ErrorIs(t, err, ErrUnsupportedProvider)
+	})
+
+	t.Run("설정이 있는 제공자만 등록", func(t *testing.T) {
+		svc, _ := setupSNSAuthService(t)
+		assert.Equal(t, []string{"apple", "kakao", "naver"}, svc.providers.Names())
+
+		svc.cfg.NaverClientSecret = ""
+		assert.Equal(t, []string{"apple", "kakao"}, newIdentityProviders(svc.cfg).Names())
+	})
+}
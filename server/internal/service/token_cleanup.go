@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+// TokenCleanupResult 토큰 기록 정리 결과
+type TokenCleanupResult struct {
+	RefreshTokens int64 // 삭제한 Refresh Token 기록 수
+	Sessions      int64 // 삭제한 세션 기록 수
+}
+
+// TokenCleaner 만료된 Refresh Token, 세션 기록 정리 작업
+// 폐기(회전)된 Refresh Token은 재사용 탐지에, 종료된 세션은 해당 세션으로 발급된
+// 액세스 토큰 거부에 필요하므로 만료 시각이 지난 뒤에 삭제한다.
+// (세션 기록이 없으면 세션 기준으로는 폐기되지 않은 토큰으로 취급된다)
+type TokenCleaner struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewTokenCleaner 새 토큰 기록 정리 작업 생성
+func NewTokenCleaner(db *gorm.DB, logger *zap.Logger) *TokenCleaner {
+	return &TokenCleaner{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Run 주기적으로 토큰 기록 정리 (ctx 취소 시 종료)
+func (c *TokenCleaner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Cleanup(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			c.logger.Error("Token cleanup failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cleanup 만료된 Refresh Token, 세션 기록 삭제 (폐기 여부와 관계없이 만료 시각 기준)
+func (c *TokenCleaner) Cleanup(ctx context.Context) (*TokenCleanupResult, error) {
+	db := c.db.WithContext(ctx)
+	now := time.Now()
+
+	refreshTokens := db.Where("expires_at <= ?", now).Delete(&model.RefreshToken{})
+	if refreshTokens.Error != nil {
+		return nil, fmt.Errorf("failed to delete refresh tokens: %w", refreshTokens.Error)
+	}
+
+	sessions := db.Where("expires_at <= ?", now).Delete(&model.Session{})
+	if sessions.Error != nil {
+		return nil, fmt.Errorf("failed to delete sessions: %w", sessions.Error)
+	}
+
+	result := &TokenCleanupResult{
+		RefreshTokens: refreshTokens.RowsAffected,
+		Sessions:      sessions.RowsAffected,
+	}
+	if result.RefreshTokens > 0 || result.Sessions > 0 {
+		c.logger.Info("Expired tokens cleaned up",
+			zap.Int64("refresh_tokens", result.RefreshTokens),
+			zap.Int64("sessions", result.Sessions),
+		)
+	}
+	return result, nil
+}
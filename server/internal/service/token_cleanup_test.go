@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+)
+
+func TestTokenCleaner_Cleanup(t *testing.T) {
+	_, db := setupAuthService(t)
+	cleaner := NewTokenCleaner(db, setupTestLogger())
+
+	now := time.Now()
+	revokedAt := now.Add(-time.Hour)
+	refreshTokens := []model.RefreshToken{
+		{ID: "active", FamilyID: "family-1", UserID: 1, ExpiresAt: now.Add(time.Hour)},
+		{ID: "rotated", FamilyID: "family-1", UserID: 1, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt},
+		{ID: "expired", FamilyID: "family-2", UserID: 1, ExpiresAt: now.Add(-time.Minute)},
+		{ID: "expired-revoked", FamilyID: "family-2", UserID: 1, ExpiresAt: now.Add(-time.Minute), RevokedAt: &revokedAt},
+	}
+	for i := range refreshTokens {
+		require.NoError(t, db.Create(&refreshTokens[i]).Error)
+	}
+
+	sessions := []model.Session{
+		{ID: "active", UserID: 1, LastSeenAt: now, ExpiresAt: now.Add(time.Hour)},
+		{ID: "revoked", UserID: 1, LastSeenAt: now, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt},
+		{ID: "expired", UserID: 1, LastSeenAt: now, ExpiresAt: now.Add(-time.Minute)},
+		{ID: "expired-revoked", UserID: 1, LastSeenAt: now, ExpiresAt: now.Add(-time.Minute), RevokedAt: &revokedAt},
+	}
+	for i := range sessions {
+		require.NoError(t, db.Create(&sessions[i]).Error)
+	}
+
+	t.Run("만료된 기록만 삭제 (폐기 기록은 만료까지 유지)", func(t *testing.T) {
+		result, err := cleaner.Cleanup(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.RefreshTokens)
+		assert.Equal(t, int64(2), result.Sessions)
+
+		var ids []string
+		require.NoError(t, db.Model(&model.RefreshToken{}).Order("id").Pluck("id", &ids).Error)
+		assert.Equal(t, []string{"active", "rotated"}, ids)
+
+		var sessionIDs []string
+		require.NoError(t, db.Model(&model.Session{}).Order("id").Pluck("id", &sessionIDs).Error)
+		assert.Equal(t, []string{"active", "revoked"}, sessionIDs)
+	})
+
+	t.Run("정리할 기록이 없으면 0 반환", func(t *testing.T) {
+		result, err := cleaner.Cleanup(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, result.RefreshTokens)
+		assert.Zero(t, result.Sessions)
+	})
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/ggorockee/ojeomneo/server/internal/model"
+	"github.com/ggorockee/ojeomneo/server/pkg/auth"
+)
+
+// tokenDenylistKeyPrefix 폐기된 Access Token(jti) Redis 키 prefix
+const tokenDenylistKeyPrefix = "auth:denylist:"
+
+// TokenRevoker 토큰 폐기 저장소
+// 개별 토큰은 Redis denylist(jti)로, 세션 단위는 user_sessions.revoked_at으로,
+// 사용자 전체 토큰은 users.tokens_revoked_before로 폐기한다.
+// Redis가 없으면 개별 폐기 요청도 사용자 전체 폐기로 대체한다.
+type TokenRevoker struct {
+	db     *gorm.DB
+	rdb    *redis.Client
+	logger *zap.Logger
+}
+
+// NewTokenRevoker 새 토큰 폐기 저장소 생성 (rdb는 nil 가능)
+func NewTokenRevoker(db *gorm.DB, rdb *redis.Client, logger *zap.Logger) *TokenRevoker {
+	return &TokenRevoker{
+		db:     db,
+		rdb:    rdb,
+		logger: logger,
+	}
+}
+
+// IsRevoked 토큰 폐기 여부 확인 (auth.RevocationChecker 구현)
+// 탈퇴/비활성 사용자, 전체 로그아웃 이전 발급 토큰, denylist에 등록된 토큰, 종료된 세션의 토큰은 폐기로 판단
+func (r *TokenRevoker) IsRevoked(claims *auth.Claims) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if claims.ID != "" && r.rdb != nil {
+		exists, err := r.rdb.Exists(ctx, tokenDenylistKeyPrefix+claims.ID).Result()
+		if err != nil {
+			// Redis 오류 시 denylist 확인만 생략하고 DB 확인은 계속 (fail-open)
+			r.logger.Warn("Token denylist lookup failed",
+				zap.Error(err),
+				zap.Uint("user_id", claims.UserID),
+			)
+		} else if exists > 0 {
+			return true, nil
+		}
+	}
+
+	var user model.User
+	err := r.db.WithContext(ctx).
+		Select("id", "is_active", "tokens_revoked_before").
+		First(&user, claims.UserID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 탈퇴(soft delete)되었거나 존재하지 않는 사용자
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	if !user.IsActive {
+		return true, nil
+	}
+
+	if user.TokensRevokedBefore != nil && claims.IssuedAt != nil &&
+		claims.IssuedAt.Time.Before(*user.TokensRevokedBefore) {
+		return true, nil
+	}
+
+	if claims.SessionID != "" {
+		// 세션 기록이 없는 토큰(세션 도입 이전 발급)은 세션 기준으로 폐기하지 않음
+		var sessions []model.Session
+		if err := r.db.WithContext(ctx).
+			Select("id", "revoked_at").
+			Where("id = ?", claims.SessionID).
+			Limit(1).
+			Find(&sessions).Error; err != nil {
+			return false, fmt.Errorf("failed to check session revocation: %w", err)
+		}
+		if len(sessions) > 0 && sessions[0].RevokedAt != nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RevokeToken 개별 Access Token 폐기 (만료 시각까지 denylist 유지)
+// Redis를 사용할 수 없으면 사용자 전체 토큰 폐기로 대체
+func (r *TokenRevoker) RevokeToken(userID uint, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if r.rdb != nil && tokenID != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		err := r.rdb.Set(ctx, tokenDenylistKeyPrefix+tokenID, userID, ttl).Err()
+		if err == nil {
+			return nil
+		}
+		r.logger.Warn("Failed to add token to denylist, falling back to user-wide revocation",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+	}
+
+	return r.RevokeAllForUser(userID)
+}
+
+// RevokeAllForUser 사용자의 모든 토큰 폐기 (Access Token 기준 시각 갱신 + 세션/Refresh Token 전체 폐기)
+func (r *TokenRevoker) RevokeAllForUser(userID uint) error {
+	// JWT iat는 초 단위이므로 기준 시각도 초 단위로 절삭 (폐기 직후 재로그인 토큰 보호)
+	now := time.Now().Truncate(time.Second)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// 탈퇴 처리된 사용자도 갱신할 수 있도록 Unscoped 사용
+		if err := tx.Unscoped().Model(&model.User{}).
+			Where("id = ?", userID).
+			Update("tokens_revoked_before", now).Error; err != nil {
+			return fmt.Errorf("failed to update token revocation time: %w", err)
+		}
+
+		if err := tx.Model(&model.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+
+		if err := tx.Model(&model.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+
+		return nil
+	})
+}
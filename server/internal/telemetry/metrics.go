@@ -24,6 +24,8 @@ type AuthMetrics struct {
 	PasswordResetSent     metric.Int64Counter     // 비밀번호 재설정 발송 카운터
 	GuestLoginCounter     metric.Int64Counter     // 익명 로그인 카운터
 	GuestToUserConversion metric.Int64Counter     // 익명 → 정회원 전환 카운터
+	RefreshTokenCounter   metric.Int64Counter     // Refresh Token 회전 결과 카운터
+	LoginLockoutCounter   metric.Int64Counter     // 로그인 잠금/해제 카운터
 }
 
 // RegisterAuthMetrics 인증 메트릭 등록
@@ -112,6 +114,26 @@ func RegisterAuthMetrics(mp *sdkmetric.MeterProvider) (*AuthMetrics, error) {
 		return nil, err
 	}
 
+	// Refresh Token 회전 결과 카운터
+	refreshTokenCounter, err := meter.Int64Counter(
+		"auth.refresh.total",
+		metric.WithDescription("Total number of refresh token rotations by result"),
+		metric.WithUnit("{refresh}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 로그인 잠금/해제 카운터
+	loginLockoutCounter, err := meter.Int64Counter(
+		"auth.login.lockout.total",
+		metric.WithDescription("Total number of login lockout and unlock events"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &AuthMetrics{
 		LoginCounter:          loginCounter,
 		LoginDuration:         loginDuration,
@@ -121,13 +143,15 @@ func RegisterAuthMetrics(mp *sdkmetric.MeterProvider) (*AuthMetrics, error) {
 		PasswordResetSent:     passwordResetSent,
 		GuestLoginCounter:     guestLoginCounter,
 		GuestToUserConversion: guestToUserConversion,
+		RefreshTokenCounter:   refreshTokenCounter,
+		LoginLockoutCounter:   loginLockoutCounter,
 	}, nil
 }
 
 // RecordLogin 로그인 시도 기록
 func (m *AuthMetrics) RecordLogin(ctx context.Context, method, status string, durationMs float64) {
 	attrs := metric.WithAttributes(
-		attribute.String("auth.method", method),      // "email", "google", "apple", "kakao"
+		attribute.String("auth.method", method),      // "email", "google", "apple", "kakao", "naver"
 		attribute.String("auth.status", status),      // "success", "failed"
 	)
 	m.LoginCounter.Add(ctx, 1, attrs)
@@ -139,7 +163,7 @@ func (m *AuthMetrics) RecordLogin(ctx context.Context, method, status string, du
 // RecordSNSLogin SNS 로그인 기록
 func (m *AuthMetrics) RecordSNSLogin(ctx context.Context, provider, status string, durationMs float64) {
 	attrs := metric.WithAttributes(
-		attribute.String("sns.provider", provider),   // "google", "apple", "kakao"
+		attribute.String("sns.provider", provider),   // "google", "apple", "kakao", "naver"
 		attribute.String("auth.status", status),      // "success", "failed", "token_invalid"
 	)
 	m.SNSLoginCounter.Add(ctx, 1, attrs)
@@ -179,7 +203,22 @@ func (m *AuthMetrics) RecordGuestLogin(ctx context.Context, status string) {
 // RecordGuestToUserConversion 익명 → 정회원 전환 기록
 func (m *AuthMetrics) RecordGuestToUserConversion(ctx context.Context, conversionMethod string) {
 	m.GuestToUserConversion.Add(ctx, 1, metric.WithAttributes(
-		attribute.String("conversion.method", conversionMethod), // "email", "google", "apple", "kakao"
+		attribute.String("conversion.method", conversionMethod), // "email", "google", "apple", "kakao", "naver"
+	))
+}
+
+// RecordRefreshToken Refresh Token 회전 결과 기록
+func (m *AuthMetrics) RecordRefreshToken(ctx context.Context, status string) {
+	m.RefreshTokenCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("auth.status", status), // "rotated", "reuse_detected", "expired", "invalid"
+	))
+}
+
+// RecordLoginLockout 로그인 잠금/해제 기록
+func (m *AuthMetrics) RecordLoginLockout(ctx context.Context, scope, event string) {
+	m.LoginLockoutCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("lockout.scope", scope), // "account", "ip"
+		attribute.String("lockout.event", event), // "locked", "unlocked"
 	))
 }
 
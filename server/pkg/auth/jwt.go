@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type TokenType string
@@ -12,82 +13,116 @@ type TokenType string
 const (
 	AccessToken  TokenType = "access"
 	RefreshToken TokenType = "refresh"
+	// MFAChallengeToken 비밀번호 확인 후 2단계 인증 코드와 교환하는 단기 토큰 (API 접근 불가)
+	MFAChallengeToken TokenType = "mfa_challenge"
 )
 
+// ErrTokenRevoked 로그아웃/탈퇴 등으로 폐기된 토큰
+var ErrTokenRevoked = errors.New("token revoked")
+
 type Claims struct {
-	UserID  uint      `json:"user_id"`
-	Type    TokenType `json:"type"`
-	IsGuest bool      `json:"is_guest"` // 익명 사용자 여부
+	UserID    uint      `json:"user_id"`
+	Type      TokenType `json:"type"`
+	IsGuest   bool      `json:"is_guest"`      // 익명 사용자 여부
+	SessionID string    `json:"sid,omitempty"` // 로그인 세션 (Refresh Token 패밀리) ID
 	jwt.RegisteredClaims
 }
 
+// RevocationChecker checks whether validated claims have been revoked
+// (logout, logout-all, account deletion/deactivation)
+type RevocationChecker interface {
+	IsRevoked(claims *Claims) (bool, error)
+}
+
 // GenerateAccessToken generates an access token
-func GenerateAccessToken(userID uint, secretKey string, expireMinutes int) (string, error) {
+// sessionID는 함께 발급된 Refresh Token 패밀리 ID (로그아웃 시 세션 단위 폐기용)
+func GenerateAccessToken(userID uint, sessionID string, keys *KeySet, expireMinutes int) (string, error) {
 	claims := Claims{
-		UserID:  userID,
-		Type:    AccessToken,
-		IsGuest: false,
+		UserID:    userID,
+		Type:      AccessToken,
+		IsGuest:   false,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expireMinutes) * time.Minute)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secretKey))
+	return keys.sign(claims)
 }
 
 // GenerateGuestToken generates a guest (anonymous) access token
 // 익명 사용자는 access token만 발급 (refresh token 없음)
 // 기본 만료 시간: 7일 (10080분)
-func GenerateGuestToken(userID uint, secretKey string, expireDays int) (string, error) {
+// sessionID는 익명 로그인 세션 ID (세션 단위 로그아웃용)
+func GenerateGuestToken(userID uint, sessionID string, keys *KeySet, expireDays int) (string, error) {
 	claims := Claims{
-		UserID:  userID,
-		Type:    AccessToken,
-		IsGuest: true,
+		UserID:    userID,
+		Type:      AccessToken,
+		IsGuest:   true,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expireDays) * 24 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secretKey))
+	return keys.sign(claims)
 }
 
 // GenerateRefreshToken generates a refresh token
-func GenerateRefreshToken(userID uint, secretKey string, expireDays int) (string, error) {
+// tokenID는 jti 클레임으로 저장되어 서버 측 토큰 저장소(회전/재사용 탐지)와 매칭됨
+func GenerateRefreshToken(userID uint, tokenID, sessionID string, keys *KeySet, expireDays int) (string, error) {
 	claims := Claims{
-		UserID:  userID,
-		Type:    RefreshToken,
-		IsGuest: false,
+		UserID:    userID,
+		Type:      RefreshToken,
+		IsGuest:   false,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expireDays) * 24 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secretKey))
+	return keys.sign(claims)
 }
 
 // ValidateAccessToken validates an access token
-func ValidateAccessToken(tokenString, secretKey string) (*Claims, error) {
-	return validateToken(tokenString, secretKey, AccessToken)
+// checkers가 주어지면 서명/만료 검증 후 폐기 여부도 확인
+func ValidateAccessToken(tokenString string, keys *KeySet, checkers ...RevocationChecker) (*Claims, error) {
+	return validateToken(tokenString, keys, AccessToken, checkers)
+}
+
+// GenerateMFAChallengeToken generates a short-lived token proving the first factor (password)
+func GenerateMFAChallengeToken(userID uint, keys *KeySet, expireMinutes int) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Type:   MFAChallengeToken,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expireMinutes) * time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return keys.sign(claims)
+}
+
+// ValidateMFAChallengeToken validates an MFA challenge token
+func ValidateMFAChallengeToken(tokenString string, keys *KeySet, checkers ...RevocationChecker) (*Claims, error) {
+	return validateToken(tokenString, keys, MFAChallengeToken, checkers)
 }
 
 // ValidateRefreshToken validates a refresh token
-func ValidateRefreshToken(tokenString, secretKey string) (*Claims, error) {
-	return validateToken(tokenString, secretKey, RefreshToken)
+func ValidateRefreshToken(tokenString string, keys *KeySet, checkers ...RevocationChecker) (*Claims, error) {
+	return validateToken(tokenString, keys, RefreshToken, checkers)
 }
 
-func validateToken(tokenString, secretKey string, expectedType TokenType) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(secretKey), nil
-	})
+func validateToken(tokenString string, keys *KeySet, expectedType TokenType, checkers []RevocationChecker) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keys.keyFunc)
 
 	if err != nil {
 		return nil, err
@@ -102,11 +137,22 @@ func validateToken(tokenString, secretKey string, expectedType TokenType) (*Clai
 		return nil, errors.New("invalid token type")
 	}
 
+	for _, checker := range checkers {
+		revoked, err := checker.IsRevoked(claims)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
 	return claims, nil
 }
 
 // GenerateTokenPair generates both access and refresh tokens concurrently
-func GenerateTokenPair(userID uint, secretKey string, accessExpireMin, refreshExpireDays int) (accessToken, refreshToken string, err error) {
+// refreshTokenID는 refresh token의 jti, sessionID는 두 토큰의 sid 클레임으로 사용됨
+func GenerateTokenPair(userID uint, sessionID, refreshTokenID string, keys *KeySet, accessExpireMin, refreshExpireDays int) (accessToken, refreshToken string, err error) {
 	type tokenResult struct {
 		token string
 		err   error
@@ -117,13 +163,13 @@ func GenerateTokenPair(userID uint, secretKey string, accessExpireMin, refreshEx
 
 	// Generate access token in goroutine
 	go func() {
-		token, err := GenerateAccessToken(userID, secretKey, accessExpireMin)
+		token, err := GenerateAccessToken(userID, sessionID, keys, accessExpireMin)
 		accessCh <- tokenResult{token, err}
 	}()
 
 	// Generate refresh token in goroutine
 	go func() {
-		token, err := GenerateRefreshToken(userID, secretKey, refreshExpireDays)
+		token, err := GenerateRefreshToken(userID, refreshTokenID, sessionID, keys, refreshExpireDays)
 		refreshCh <- tokenResult{token, err}
 	}()
 
@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported asymmetric signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// KeyConfig describes a single JWT key loaded from config
+// PrivateKeyPEM이 있으면 서명 가능 키, PublicKeyPEM만 있으면 검증 전용 키(교체 후 기존 토큰 만료 대기용)
+type KeyConfig struct {
+	KID           string `json:"kid"`
+	Algorithm     string `json:"alg"`
+	PrivateKeyPEM string `json:"private_key,omitempty"`
+	PublicKeyPEM  string `json:"public_key,omitempty"`
+}
+
+// verificationKey is a public key registered for a kid
+type verificationKey struct {
+	kid       string
+	algorithm string
+	method    jwt.SigningMethod
+	publicKey crypto.PublicKey
+}
+
+// KeySet holds the active signing key and every key accepted for verification
+//
+// 무중단 키 교체 절차:
+//  1. 새 키를 JWT_KEYS에 추가 (JWKS에 공개되어 검증 측이 미리 받아감)
+//  2. JWT_SIGNING_KEY_ID를 새 kid로 변경 (이후 발급 토큰은 새 키로 서명)
+//  3. 기존 키는 public_key만 남겨 검증 전용으로 유지
+//  4. 기존 키로 서명된 토큰이 모두 만료되면(Refresh Token 만료 기간 경과) 기존 키 제거
+//
+// 비대칭 키가 없으면 HS256(secret) 서명으로 동작한다. 비대칭 서명 전환 후에는 acceptHS256이
+// 켜져 있을 때만 전환 이전에 발급된 HS256 토큰을 검증한다 (secret을 가진 쪽이 토큰을 위조할 수 있으므로
+// 기존 토큰이 모두 만료되면 끈다).
+type KeySet struct {
+	signingKID    string
+	signingMethod jwt.SigningMethod
+	signingKey    crypto.PrivateKey
+
+	keys        map[string]*verificationKey
+	secret      []byte
+	acceptHS256 bool
+}
+
+// NewHMACKeySet creates a KeySet that signs and verifies with HS256 only
+func NewHMACKeySet(secretKey string) *KeySet {
+	return &KeySet{
+		keys:        map[string]*verificationKey{},
+		secret:      []byte(secretKey),
+		acceptHS256: true,
+	}
+}
+
+// NewKeySet creates a KeySet from config
+// activeKID가 비어 있으면 HS256으로 서명 (비대칭 키는 검증에만 사용)
+// activeKID가 있으면 acceptHS256이 true이고 secretKey가 있을 때만 기존 HS256 토큰을 검증
+func NewKeySet(secretKey string, acceptHS256 bool, activeKID string, configs []KeyConfig) (*KeySet, error) {
+	ks := NewHMACKeySet(secretKey)
+
+	var activeKey crypto.PrivateKey
+	for _, cfg := range configs {
+		if cfg.KID == "" {
+			return nil, errors.New("jwt key: kid is required")
+		}
+		if _, exists := ks.keys[cfg.KID]; exists {
+			return nil, fmt.Errorf("jwt key %s: duplicate kid", cfg.KID)
+		}
+
+		method, err := signingMethodFor(cfg.Algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", cfg.KID, err)
+		}
+
+		var privateKey crypto.PrivateKey
+		var publicKey crypto.PublicKey
+		if cfg.PrivateKeyPEM != "" {
+			privateKey, publicKey, err = parsePrivateKey(cfg.Algorithm, cfg.PrivateKeyPEM)
+		} else if cfg.PublicKeyPEM != "" {
+			publicKey, err = parsePublicKey(cfg.Algorithm, cfg.PublicKeyPEM)
+		} else {
+			err = errors.New("private_key or public_key is required")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", cfg.KID, err)
+		}
+
+		ks.keys[cfg.KID] = &verificationKey{
+			kid:       cfg.KID,
+			algorithm: cfg.Algorithm,
+			method:    method,
+			publicKey: publicKey,
+		}
+
+		if cfg.KID == activeKID {
+			if privateKey == nil {
+				return nil, fmt.Errorf("jwt key %s: active signing key requires private_key", cfg.KID)
+			}
+			activeKey = privateKey
+		}
+	}
+
+	if activeKID != "" {
+		if activeKey == nil {
+			return nil, fmt.Errorf("jwt key %s: active signing key not found", activeKID)
+		}
+		ks.signingKID = activeKID
+		ks.signingMethod = ks.keys[activeKID].method
+		ks.signingKey = activeKey
+		ks.acceptHS256 = acceptHS256
+	} else if len(ks.secret) == 0 {
+		return nil, errors.New("jwt: either a secret key or an active signing key is required")
+	}
+
+	return ks, nil
+}
+
+// ParseKeyConfigs parses the JSON key list from config (JWT_KEYS)
+// 환경변수로 주입된 PEM의 "\n" 이스케이프는 줄바꿈으로 복원
+func ParseKeyConfigs(raw string) ([]KeyConfig, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var configs []KeyConfig
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse jwt keys: %w", err)
+	}
+	for i := range configs {
+		configs[i].PrivateKeyPEM = strings.ReplaceAll(configs[i].PrivateKeyPEM, `\n`, "\n")
+		configs[i].PublicKeyPEM = strings.ReplaceAll(configs[i].PublicKeyPEM, `\n`, "\n")
+	}
+	return configs, nil
+}
+
+// SigningKeyID returns the kid of the active signing key (empty for HS256)
+func (k *KeySet) SigningKeyID() string {
+	return k.signingKID
+}
+
+// AcceptsHS256 reports whether HS256 tokens signed with the shared secret are accepted
+func (k *KeySet) AcceptsHS256() bool {
+	return len(k.secret) > 0 && k.acceptHS256
+}
+
+// sign signs claims with the active key and sets the kid header
+func (k *KeySet) sign(claims jwt.Claims) (string, error) {
+	if k.signingKey == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(k.secret)
+	}
+
+	token := jwt.NewWithClaims(k.signingMethod, claims)
+	token.Header["kid"] = k.signingKID
+	return token.SignedString(k.signingKey)
+}
+
+// keyFunc resolves the verification key from the token header
+func (k *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if !k.AcceptsHS256() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return k.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.publicKey, nil
+}
+
+// JWK is a single public key in JWKS format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 (OKP)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is the public key set published at /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns every asymmetric verification key (HS256 secret is never published)
+func (k *KeySet) JWKS() JWKS {
+	kids := make([]string, 0, len(k.keys))
+	for kid := range k.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(kids))}
+	for _, kid := range kids {
+		key := k.keys[kid]
+		jwk := JWK{KeyID: kid, Use: "sig", Algorithm: key.algorithm}
+
+		switch pub := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func signingMethodFor(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %q", algorithm)
+	}
+}
+
+func parsePrivateKey(algorithm, pemData string) (crypto.PrivateKey, crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, nil, errors.New("invalid private key PEM")
+	}
+
+	var parsed interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if algorithm != AlgorithmRS256 {
+			return nil, nil, errors.New("RSA key requires RS256")
+		}
+		return key, &key.PublicKey, nil
+	case ed25519.PrivateKey:
+		if algorithm != AlgorithmEdDSA {
+			return nil, nil, errors.New("Ed25519 key requires EdDSA")
+		}
+		return key, key.Public(), nil
+	default:
+		return nil, nil, errors.New("unsupported private key type")
+	}
+}
+
+func parsePublicKey(algorithm, pemData string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, errors.New("invalid public key PEM")
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PublicKey:
+		if algorithm != AlgorithmRS256 {
+			return nil, errors.New("RSA key requires RS256")
+		}
+		return key, nil
+	case ed25519.PublicKey:
+		if algorithm != AlgorithmEdDSA {
+			return nil, errors.New("Ed25519 key requires EdDSA")
+		}
+		return key, nil
+	default:
+		return nil, errors.New("unsupported public key type")
+	}
+}
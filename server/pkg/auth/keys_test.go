@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateEdDSAKey 테스트용 Ed25519 키 생성 (PKCS8 개인키, PKIX 공개키 PEM)
+func generateEdDSAKey(t *testing.T) (privatePEM, publicPEM string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return encodeKeyPair(t, priv, pub)
+}
+
+// generateRSAKey 테스트용 RSA 키 생성
+func generateRSAKey(t *testing.T) (privatePEM, publicPEM string) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return encodeKeyPair(t, priv, &priv.PublicKey)
+}
+
+func encodeKeyPair(t *testing.T, priv, pub interface{}) (string, string) {
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return string(privatePEM), string(publicPEM)
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	oldPrivate, oldPublic := generateRSAKey(t)
+	newPrivate, _ := generateEdDSAKey(t)
+
+	// 교체 전: 기존 RSA 키로 서명
+	before, err := NewKeySet("", false, "old", []KeyConfig{
+		{KID: "old", Algorithm: AlgorithmRS256, PrivateKeyPEM: oldPrivate},
+	})
+	require.NoError(t, err)
+	oldToken, err := GenerateAccessToken(1, "session", before, 15)
+	require.NoError(t, err)
+
+	// 교체 후: 새 EdDSA 키로 서명, 기존 키는 검증 전용
+	after, err := NewKeySet("", false, "new", []KeyConfig{
+		{KID: "new", Algorithm: AlgorithmEdDSA, PrivateKeyPEM: newPrivate},
+		{KID: "old", Algorithm: AlgorithmRS256, PublicKeyPEM: oldPublic},
+	})
+	require.NoError(t, err)
+
+	t.Run("기존 키로 서명된 토큰 검증", func(t *testing.T) {
+		claims, err := ValidateAccessToken(oldToken, after)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), claims.UserID)
+	})
+
+	t.Run("새 키로 서명 및 검증", func(t *testing.T) {
+		token, err := GenerateAccessToken(2, "session", after, 15)
+		require.NoError(t, err)
+
+		claims, err := ValidateAccessToken(token, after)
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), claims.UserID)
+
+		// 교체 전 키셋은 새 kid를 모르므로 거부
+		_, err = ValidateAccessToken(token, before)
+		assert.Error(t, err)
+	})
+
+	t.Run("JWKS에 모든 검증 키 공개", func(t *testing.T) {
+		jwks := after.JWKS()
+		require.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "new", jwks.Keys[0].KeyID)
+		assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+		assert.Equal(t, "old", jwks.Keys[1].KeyID)
+		assert.Equal(t, "RSA", jwks.Keys[1].KeyType)
+	})
+}
+
+func TestKeySet_LegacyHS256(t *testing.T) {
+	privatePEM, _ := generateEdDSAKey(t)
+	legacy := NewHMACKeySet("secret")
+	legacyToken, err := GenerateAccessToken(1, "", legacy, 15)
+	require.NoError(t, err)
+
+	t.Run("JWT_ACCEPT_HS256 설정 시 기존 HS256 토큰 허용", func(t *testing.T) {
+		keys, err := NewKeySet("secret", true, "k1", []KeyConfig{
+			{KID: "k1", Algorithm: AlgorithmEdDSA, PrivateKeyPEM: privatePEM},
+		})
+		require.NoError(t, err)
+
+		_, err = ValidateAccessToken(legacyToken, keys)
+		assert.NoError(t, err)
+	})
+
+	t.Run("secret이 있어도 JWT_ACCEPT_HS256 미설정 시 HS256 토큰 거부", func(t *testing.T) {
+		keys, err := NewKeySet("secret", false, "k1", []KeyConfig{
+			{KID: "k1", Algorithm: AlgorithmEdDSA, PrivateKeyPEM: privatePEM},
+		})
+		require.NoError(t, err)
+		assert.False(t, keys.AcceptsHS256())
+
+		_, err = ValidateAccessToken(legacyToken, keys)
+		assert.Error(t, err)
+	})
+
+	t.Run("HS256 서명 모드에서는 항상 허용", func(t *testing.T) {
+		keys, err := NewKeySet("secret", false, "", nil)
+		require.NoError(t, err)
+
+		_, err = ValidateAccessToken(legacyToken, keys)
+		assert.NoError(t, err)
+	})
+
+	t.Run("secret 미설정 시 HS256 토큰 거부", func(t *testing.T) {
+		keys, err := NewKeySet("", false, "k1", []KeyConfig{
+			{KID: "k1", Algorithm: AlgorithmEdDSA, PrivateKeyPEM: privatePEM},
+		})
+		require.NoError(t, err)
+
+		_, err = ValidateAccessToken(legacyToken, keys)
+		assert.Error(t, err)
+		assert.Empty(t, keys.JWKS().Keys[0].N)
+	})
+}
+
+func TestNewKeySet_InvalidConfig(t *testing.T) {
+	_, publicPEM := generateEdDSAKey(t)
+
+	_, err := NewKeySet("", false, "", nil)
+	assert.Error(t, err, "서명 키 없음")
+
+	_, err = NewKeySet("", false, "k1", []KeyConfig{{KID: "k1", Algorithm: AlgorithmEdDSA, PublicKeyPEM: publicPEM}})
+	assert.Error(t, err, "검증 전용 키는 서명 키로 사용 불가")
+
+	_, err = NewKeySet("", false, "k1", []KeyConfig{{KID: "k1", Algorithm: AlgorithmRS256, PublicKeyPEM: publicPEM}})
+	assert.Error(t, err, "알고리즘과 키 종류 불일치")
+}
@@ -1,23 +1,187 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 )
 
-const bcryptCost = 12
+// Password hashes are stored in Django's "<algorithm>$<params>$<salt>$<hash>" format so that
+// the Django admin (/admin) and the Go API can share the users table.
+// 새 해시는 Django 기본 hasher(PBKDF2PasswordHasher)와 같은 형식으로 저장하고,
+// 검증은 Django 기본 PASSWORD_HASHERS 형식과 이전 Go 서버의 bcrypt 해시를 모두 지원한다.
+
+const (
+	// PBKDF2Iterations matches Django 5.2's PBKDF2PasswordHasher.iterations (admin/uv.lock).
+	// Django re-hashes on login when its default differs, so keep this in step with the admin's Django version.
+	PBKDF2Iterations = 1000000
 
-// HashPassword hashes a plain text password using bcrypt
+	pbkdf2SaltLength = 22
+	saltAlphabet     = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// HashPassword hashes a plain text password as pbkdf2_sha256 (readable by both Django and Go)
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	salt, err := generateSalt(pbkdf2SaltLength)
 	if err != nil {
 		return "", err
 	}
-	return string(bytes), nil
+	return encodePBKDF2("pbkdf2_sha256", sha256.New, password, salt, PBKDF2Iterations), nil
+}
+
+// CheckPassword compares a plain text password with a stored hash
+func CheckPassword(password, encoded string) bool {
+	ok, _ := VerifyPassword(password, encoded)
+	return ok
+}
+
+// VerifyPassword compares a plain text password with a stored hash in any supported format.
+// needsRehash reports whether a successfully verified hash should be replaced with HashPassword's output.
+//
+// Supported formats:
+//   - pbkdf2_sha256$<iterations>$<salt>$<base64 hash>  (Django default)
+//   - pbkdf2_sha1$<iterations>$<salt>$<base64 hash>
+//   - bcrypt_sha256$<bcrypt hash of hex sha256(password)>
+//   - bcrypt$<bcrypt hash>
+//   - argon2$argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>
+//   - scrypt$<N>$<salt>$<r>$<p>$<base64 hash>
+//   - $2a$... / $2b$...  (raw bcrypt written by earlier versions of this server)
+//
+// Unusable passwords (Django's "!..." marker) and empty hashes never verify.
+func VerifyPassword(password, encoded string) (ok, needsRehash bool) {
+	if strings.HasPrefix(encoded, "$2") {
+		// 이전 Go 서버의 bcrypt 해시 (Django는 읽을 수 없으므로 업그레이드 필요)
+		return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil, true
+	}
+
+	algorithm, rest, found := strings.Cut(encoded, "$")
+	if !found {
+		return false, false
+	}
+
+	switch algorithm {
+	case "pbkdf2_sha256":
+		iterations, ok := verifyPBKDF2(algorithm, sha256.New, password, rest)
+		// 반복 횟수가 더 많은 해시(Django 업그레이드 후 생성)는 낮추지 않는다
+		return ok, ok && iterations < PBKDF2Iterations
+	case "pbkdf2_sha1":
+		_, ok := verifyPBKDF2(algorithm, sha1.New, password, rest)
+		return ok, true
+	case "bcrypt_sha256":
+		sum := sha256.Sum256([]byte(password))
+		return bcrypt.CompareHashAndPassword([]byte(rest), []byte(hex.EncodeToString(sum[:]))) == nil, true
+	case "bcrypt":
+		return bcrypt.CompareHashAndPassword([]byte(rest), []byte(password)) == nil, true
+	case "argon2":
+		return verifyArgon2(password, rest), true
+	case "scrypt":
+		return verifyScrypt(password, rest), true
+	}
+	return false, false
+}
+
+// encodePBKDF2 builds a Django PBKDF2 hash string
+func encodePBKDF2(algorithm string, h func() hash.Hash, password, salt string, iterations int) string {
+	key := pbkdf2.Key([]byte(password), []byte(salt), iterations, h().Size(), h)
+	return fmt.Sprintf("%s$%d$%s$%s", algorithm, iterations, salt, base64.StdEncoding.EncodeToString(key))
 }
 
-// CheckPassword compares a plain text password with a hash
-func CheckPassword(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// verifyPBKDF2 checks "<iterations>$<salt>$<hash>" and returns the iteration count on success
+func verifyPBKDF2(algorithm string, h func() hash.Hash, password, rest string) (int, bool) {
+	parts := strings.Split(rest, "$")
+	if len(parts) != 3 {
+		return 0, false
+	}
+	iterations, err := strconv.Atoi(parts[0])
+	if err != nil || iterations <= 0 {
+		return 0, false
+	}
+
+	expected := encodePBKDF2(algorithm, h, password, parts[1], iterations)
+	actual := algorithm + "$" + rest
+	return iterations, subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
 }
 
+// verifyArgon2 checks "argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>" (unpadded base64)
+func verifyArgon2(password, rest string) bool {
+	parts := strings.Split(rest, "$")
+	if len(parts) != 5 || parts[0] != "argon2id" || parts[1] != "v=19" {
+		return false
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[2], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	key := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(expected)))
+	return subtle.ConstantTimeCompare(key, expected) == 1
+}
+
+// verifyScrypt checks "<N>$<salt>$<r>$<p>$<hash>" (Django ScryptPasswordHasher)
+func verifyScrypt(password, rest string) bool {
+	parts := strings.Split(rest, "$")
+	if len(parts) != 5 {
+		return false
+	}
+
+	var params [3]int
+	for i, p := range []string{parts[0], parts[2], parts[3]} {
+		v, err := strconv.Atoi(p)
+		if err != nil || v <= 0 {
+			return false
+		}
+		params[i] = v
+	}
+	expected, err := base64.StdEncoding.DecodeString(parts[4])
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	key, err := scrypt.Key([]byte(password), []byte(parts[1]), params[0], params[1], params[2], len(expected))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, expected) == 1
+}
+
+// generateSalt returns a random alphanumeric salt like Django's get_random_string
+func generateSalt(length int) (string, error) {
+	// 62의 배수(248) 미만 바이트만 사용해 문자 분포를 고르게 유지
+	const limit = 256 - 256%len(saltAlphabet)
+
+	salt := make([]byte, 0, length)
+	buf := make([]byte, length)
+	for len(salt) < length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to generate salt: %w", err)
+		}
+		for _, b := range buf {
+			if int(b) < limit && len(salt) < length {
+				salt = append(salt, saltAlphabet[int(b)%len(saltAlphabet)])
+			}
+		}
+	}
+	return string(salt), nil
+}
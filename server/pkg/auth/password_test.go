@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashPassword_DjangoFormat(t *testing.T) {
+	hashed, err := HashPassword("password123")
+	require.NoError(t, err)
+
+	parts := strings.Split(hashed, "$")
+	require.Len(t, parts, 4)
+	assert.Equal(t, "pbkdf2_sha256", parts[0])
+	assert.Equal(t, "1000000", parts[1])
+	assert.Len(t, parts[2], pbkdf2SaltLength)
+	assert.LessOrEqual(t, len(hashed), 128) // users.password varchar(128)
+
+	ok, needsRehash := VerifyPassword("password123", hashed)
+	assert.True(t, ok)
+	assert.False(t, needsRehash)
+	assert.False(t, CheckPassword("wrong", hashed))
+
+	other, err := HashPassword("password123")
+	require.NoError(t, err)
+	assert.NotEqual(t, hashed, other)
+}
+
+func TestVerifyPassword_Formats(t *testing.T) {
+	const password = "lètmein"
+
+	bcryptSHA256 := func() string {
+		sum := sha256.Sum256([]byte(password))
+		h, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(sum[:])), bcrypt.MinCost)
+		require.NoError(t, err)
+		return "bcrypt_sha256$" + string(h)
+	}
+	rawBcrypt := func() string {
+		h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		require.NoError(t, err)
+		return string(h)
+	}
+	argon2id := func() string {
+		salt := []byte("seasaltseasalt")
+		key := argon2.IDKey([]byte(password), salt, 1, 1024, 1, 32)
+		return "argon2$argon2id$v=19$m=1024,t=1,p=1$" +
+			base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(key)
+	}
+
+	tests := []struct {
+		name        string
+		encoded     string
+		needsRehash bool
+	}{
+		// Django make_password 결과와 같은 값 (Python hashlib으로 생성)
+		{"pbkdf2_sha256 (현재 반복 횟수)", "pbkdf2_sha256$1000000$seasalt$r1uLUxoxpP2Ued/qxvmje7UH9PUJBkRrvf9gGPL7Cps=", false},
+		{"pbkdf2_sha256 (더 많은 반복 횟수)", "pbkdf2_sha256$1200000$seasalt$6sTlFi4QohxXLuZigqDIUNX8xG9NxrTmV8+flFQdBqE=", false},
+		{"pbkdf2_sha256 (이전 반복 횟수)", "pbkdf2_sha256$870000$seasalt$wJSpLMQRQz0Dhj/pFpbyjMj71B2gUYp6HJS5AU+32Ac=", true},
+		{"pbkdf2_sha256 (오래된 반복 횟수)", "pbkdf2_sha256$720000$seasalt$eDupbcisD1UuIiou3hMuMu8oe/XwnpDw45r6AA5iv0E=", true},
+		{"pbkdf2_sha1", "pbkdf2_sha1$260000$seasalt2$wAibXvW6jgvatCdONi6SMJ6q7mI=", true},
+		{"scrypt", "scrypt$16384$seasalt$8$1$Qj3+9PPyRjSJIebHnG81TMjsqtaIGxNQG/aEB/NYafTJ7tibgfYz71m0ldQESkXFRkdVCBhhY8mx7rQwite/Pw==", true},
+		{"bcrypt_sha256", bcryptSHA256(), true},
+		{"bcrypt (Django)", "bcrypt$" + rawBcrypt(), true},
+		{"bcrypt (이전 Go 서버)", rawBcrypt(), true},
+		{"argon2", argon2id(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needsRehash := VerifyPassword(password, tt.encoded)
+			assert.True(t, ok)
+			assert.Equal(t, tt.needsRehash, needsRehash)
+
+			ok, _ = VerifyPassword("letmein", tt.encoded)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestVerifyPassword_Unusable(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"!Tz2bx0MbHd7vwZCz8Xwe1rKz1LLoSLXWeWBRP5bL", // Django set_unusable_password
+		"md5$seasalt$f5531bef9f3687d0ccf0f617f0e25573",
+		"pbkdf2_sha256$abc$seasalt$hash",
+		"pbkdf2_sha256$1000000$seasalt",
+	} {
+		ok, needsRehash := VerifyPassword("", encoded)
+		assert.False(t, ok, encoded)
+		assert.False(t, needsRehash, encoded)
+	}
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 부록 B 테스트 벡터 (SHA1, 하위 6자리)
+func TestTOTPCode_RFC6238(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := TOTPCode(secret, time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	now := time.Now()
+
+	code, err := TOTPCode(secret, now)
+	require.NoError(t, err)
+
+	t.Run("현재 코드 허용", func(t *testing.T) {
+		step, ok := ValidateTOTP(secret, code, now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, now.Unix()/30, step)
+	})
+
+	t.Run("앞뒤 30초 시계 오차 허용", func(t *testing.T) {
+		_, ok := ValidateTOTP(secret, code, now.Add(30*time.Second), 0)
+		assert.True(t, ok)
+		_, ok = ValidateTOTP(secret, code, now.Add(-30*time.Second), 0)
+		assert.True(t, ok)
+	})
+
+	t.Run("이미 사용한 step의 코드는 거부", func(t *testing.T) {
+		_, ok := ValidateTOTP(secret, code, now, now.Unix()/30)
+		assert.False(t, ok)
+	})
+
+	t.Run("잘못된 코드 거부", func(t *testing.T) {
+		_, ok := ValidateTOTP(secret, "12345", now, 0)
+		assert.False(t, ok)
+		_, ok = ValidateTOTP(secret, code, now.Add(5*time.Minute), 0)
+		assert.False(t, ok)
+	})
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Ojeomneo", "user@example.com", "JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "otpauth://totp/Ojeomneo:user@example.com?")
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Ojeomneo")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+
+	// 하이픈/대소문자/공백과 무관하게 같은 해시
+	normalized := codes[0][:5] + codes[0][6:]
+	assert.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(" "+strings.ToUpper(normalized)+" "))
+	assert.NotEqual(t, HashRecoveryCode(codes[0]), HashRecoveryCode(codes[1]))
+}
+
+func TestSecretCipher(t *testing.T) {
+	cipher, err := NewSecretCipher(make([]byte, 32))
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt("JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "JBSWY3DPEHPK3PXP")
+
+	decrypted, err := cipher.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", decrypted)
+
+	_, err = NewSecretCipher([]byte("short"))
+	assert.Error(t, err)
+}
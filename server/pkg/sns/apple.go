@@ -16,10 +16,11 @@ import (
 
 // AppleUserInfo represents Apple user information
 type AppleUserInfo struct {
-	ID           string `json:"id"`
-	Email        string `json:"email"`
-	Name         string `json:"name"`
-	ProfileImage string `json:"profile_image"`
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	ProfileImage  string `json:"profile_image"`
 }
 
 // AppleJWKS represents Apple's JWKS response
@@ -44,17 +45,21 @@ type AppleClaims struct {
 	EmailVerified any    `json:"email_verified"` // can be bool or string
 }
 
+// DefaultAppleKeysURL is Apple's JWKS endpoint for identity tokens
+const DefaultAppleKeysURL = "https://appleid.apple.com/auth/keys"
+
 var (
 	applePublicKeys     map[string]*rsa.PublicKey
 	applePublicKeysMu   sync.RWMutex
 	applePublicKeysTime time.Time
+	applePublicKeysURL  string // 캐시된 키를 가져온 주소 (주소가 바뀌면 다시 조회)
 )
 
 // getApplePublicKeys fetches and caches Apple's public keys
 // Uses goroutine for concurrent fetching when cache is expired
-func getApplePublicKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+func getApplePublicKeys(ctx context.Context, keysURL string) (map[string]*rsa.PublicKey, error) {
 	applePublicKeysMu.RLock()
-	if applePublicKeys != nil && time.Since(applePublicKeysTime) < time.Hour {
+	if applePublicKeys != nil && applePublicKeysURL == keysURL && time.Since(applePublicKeysTime) < time.Hour {
 		defer applePublicKeysMu.RUnlock()
 		return applePublicKeys, nil
 	}
@@ -72,13 +77,13 @@ func getApplePublicKeys(ctx context.Context) (map[string]*rsa.PublicKey, error)
 		defer applePublicKeysMu.Unlock()
 
 		// Double check after acquiring write lock
-		if applePublicKeys != nil && time.Since(applePublicKeysTime) < time.Hour {
+		if applePublicKeys != nil && applePublicKeysURL == keysURL && time.Since(applePublicKeysTime) < time.Hour {
 			resultCh <- keysResult{applePublicKeys, nil}
 			return
 		}
 
 		client := &http.Client{Timeout: 10 * time.Second}
-		req, err := http.NewRequestWithContext(ctx, "GET", "https://appleid.apple.com/auth/keys", nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", keysURL, nil)
 		if err != nil {
 			resultCh <- keysResult{nil, fmt.Errorf("failed to create request: %w", err)}
 			return
@@ -103,11 +108,11 @@ func getApplePublicKeys(ctx context.Context) (map[string]*rsa.PublicKey, error)
 		}
 
 		keys := make(map[string]*rsa.PublicKey)
-		
+
 		// Process keys concurrently
 		var wg sync.WaitGroup
 		keysMu := sync.Mutex{}
-		
+
 		for _, jwk := range jwks.Keys {
 			if jwk.Kty != "RSA" {
 				continue
@@ -143,6 +148,7 @@ func getApplePublicKeys(ctx context.Context) (map[string]*rsa.PublicKey, error)
 
 		applePublicKeys = keys
 		applePublicKeysTime = time.Now()
+		applePublicKeysURL = keysURL
 
 		resultCh <- keysResult{keys, nil}
 	}()
@@ -152,7 +158,12 @@ func getApplePublicKeys(ctx context.Context) (map[string]*rsa.PublicKey, error)
 }
 
 // VerifyAppleToken verifies Apple identity token and returns user info
-func VerifyAppleToken(ctx context.Context, identityToken string, clientID string) (*AppleUserInfo, error) {
+// keysURL이 비어 있으면 DefaultAppleKeysURL 사용 (테스트에서는 stub 서버 주소 지정)
+func VerifyAppleToken(ctx context.Context, keysURL, identityToken, clientID string) (*AppleUserInfo, error) {
+	if keysURL == "" {
+		keysURL = DefaultAppleKeysURL
+	}
+
 	// Parse token header in goroutine for concurrent processing
 	type parseResult struct {
 		kid string
@@ -184,7 +195,7 @@ func VerifyAppleToken(ctx context.Context, identityToken string, clientID string
 	kid := result.kid
 
 	// Get Apple public keys (cached, non-blocking)
-	keys, err := getApplePublicKeys(ctx)
+	keys, err := getApplePublicKeys(ctx, keysURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get apple public keys: %w", err)
 	}
@@ -242,10 +253,22 @@ func VerifyAppleToken(ctx context.Context, identityToken string, clientID string
 	claims := verifyRes.claims
 
 	return &AppleUserInfo{
-		ID:           claims.Subject,
-		Email:        claims.Email,
-		Name:         "", // Apple doesn't provide name in JWT
-		ProfileImage: "", // Apple doesn't provide profile image
+		ID:            claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claimBool(claims.EmailVerified),
+		Name:          "", // Apple doesn't provide name in JWT
+		ProfileImage:  "", // Apple doesn't provide profile image
 	}, nil
 }
 
+// claimBool parses a boolean claim that Apple sends either as bool or as "true"/"false" string
+func claimBool(v any) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		return b == "true"
+	default:
+		return false
+	}
+}
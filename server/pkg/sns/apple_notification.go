@@ -0,0 +1,106 @@
+package sns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Apple server-to-server notification event types
+const (
+	AppleEventEmailDisabled  = "email-disabled"  // 비공개 릴레이 이메일 전달 중지
+	AppleEventEmailEnabled   = "email-enabled"   // 비공개 릴레이 이메일 전달 재개
+	AppleEventConsentRevoked = "consent-revoked" // 사용자가 앱의 Apple 로그인 사용 중단
+	AppleEventAccountDelete  = "account-delete"  // Apple 계정 영구 삭제
+)
+
+// appleIssuer is the iss claim of tokens signed by Apple
+const appleIssuer = "https://appleid.apple.com"
+
+// AppleNotificationEvent represents the events claim of an Apple notification
+type AppleNotificationEvent struct {
+	Type           string `json:"type"`
+	Sub            string `json:"sub"`
+	Email          string `json:"email,omitempty"`
+	IsPrivateEmail any    `json:"is_private_email,omitempty"` // can be bool or string
+	EventTime      int64  `json:"event_time"`
+}
+
+// appleNotificationClaims represents Apple notification JWT claims
+// events는 JSON 문자열로 오는 것이 일반적이지만 객체로 오는 경우도 처리
+type appleNotificationClaims struct {
+	jwt.RegisteredClaims
+	Events json.RawMessage `json:"events"`
+}
+
+// VerifyAppleNotification verifies the signed payload of an Apple server-to-server notification
+// keysURL이 비어 있으면 DefaultAppleKeysURL 사용
+// clientID(aud)가 없으면 다른 앱으로 보낸 알림까지 받게 되므로 검증하지 않고 거부
+func VerifyAppleNotification(ctx context.Context, keysURL, payload, clientID string) (*AppleNotificationEvent, error) {
+	if clientID == "" {
+		return nil, errors.New("apple client ID is required to verify notifications")
+	}
+	if keysURL == "" {
+		keysURL = DefaultAppleKeysURL
+	}
+
+	claims := &appleNotificationClaims{}
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(appleIssuer),
+		jwt.WithIssuedAt(),
+		jwt.WithAudience(clientID),
+	}
+
+	_, err := jwt.ParseWithClaims(payload, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, errors.New("missing kid in token header")
+		}
+
+		keys, err := getApplePublicKeys(ctx, keysURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get apple public keys: %w", err)
+		}
+
+		publicKey, ok := keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("public key not found for kid: %s", kid)
+		}
+		return publicKey, nil
+	}, parserOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify notification: %w", err)
+	}
+
+	event, err := decodeAppleEvents(claims.Events)
+	if err != nil {
+		return nil, err
+	}
+	if event.Type == "" || event.Sub == "" {
+		return nil, errors.New("notification missing event type or sub")
+	}
+	return event, nil
+}
+
+// decodeAppleEvents decodes the events claim (JSON string or object)
+func decodeAppleEvents(raw json.RawMessage) (*AppleNotificationEvent, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("notification missing events claim")
+	}
+
+	data := []byte(raw)
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		data = []byte(encoded)
+	}
+
+	var event AppleNotificationEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode notification events: %w", err)
+	}
+	return &event, nil
+}
@@ -0,0 +1,146 @@
+package sns
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultAppleAuthBaseURL is the base URL of Apple's Sign in with Apple REST API (/auth/token, /auth/revoke)
+const DefaultAppleAuthBaseURL = "https://appleid.apple.com"
+
+// appleClientSecretTTL is the lifetime of a generated client secret (Apple allows up to 6 months)
+const appleClientSecretTTL = time.Hour
+
+// AppleTokenResponse represents Apple's /auth/token response
+type AppleTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+}
+
+// AppleErrorResponse represents Apple's error response
+type AppleErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// ParseApplePrivateKey parses the .p8 private key downloaded from Apple Developer
+// 환경변수로 주입하면서 줄바꿈이 "\n" 문자열로 들어온 경우도 처리
+func ParseApplePrivateKey(pemData string) (*ecdsa.PrivateKey, error) {
+	pemData = strings.ReplaceAll(pemData, `\n`, "\n")
+
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, errors.New("failed to decode apple private key PEM")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse apple private key: %w", err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("apple private key is not an ECDSA key")
+	}
+	return ecKey, nil
+}
+
+// GenerateAppleClientSecret creates the ES256 client_secret JWT required by Apple's REST API
+func GenerateAppleClientSecret(teamID, keyID, clientID string, privateKey *ecdsa.PrivateKey, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Issuer:    teamID,
+		Subject:   clientID,
+		Audience:  jwt.ClaimStrings{DefaultAppleAuthBaseURL},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appleClientSecretTTL)),
+	})
+	token.Header["kid"] = keyID
+
+	secret, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign apple client secret: %w", err)
+	}
+	return secret, nil
+}
+
+// ExchangeAppleAuthorizationCode exchanges an authorization code for Apple tokens
+// baseURL이 비어 있으면 DefaultAppleAuthBaseURL 사용
+func ExchangeAppleAuthorizationCode(ctx context.Context, baseURL, clientID, clientSecret, code string) (*AppleTokenResponse, error) {
+	form := url.Values{
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"code":          {code},
+		"grant_type":    {"authorization_code"},
+	}
+
+	var tokens AppleTokenResponse
+	if err := postAppleForm(ctx, baseURL, "/auth/token", form, &tokens); err != nil {
+		return nil, err
+	}
+	if tokens.RefreshToken == "" {
+		return nil, errors.New("apple token response missing refresh_token")
+	}
+	return &tokens, nil
+}
+
+// RevokeAppleToken revokes an Apple refresh or access token
+// tokenTypeHint는 "refresh_token" 또는 "access_token"
+func RevokeAppleToken(ctx context.Context, baseURL, clientID, clientSecret, token, tokenTypeHint string) error {
+	form := url.Values{
+		"client_id":       {clientID},
+		"client_secret":   {clientSecret},
+		"token":           {token},
+		"token_type_hint": {tokenTypeHint},
+	}
+	return postAppleForm(ctx, baseURL, "/auth/revoke", form, nil)
+}
+
+// postAppleForm posts a form to Apple's REST API and decodes the JSON response into out (nil이면 본문 무시)
+func postAppleForm(ctx context.Context, baseURL, path string, form url.Values, out interface{}) error {
+	if baseURL == "" {
+		baseURL = DefaultAppleAuthBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimRight(baseURL, "/")+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call apple API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr AppleErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error != "" {
+			return fmt.Errorf("apple API returned status %d: %s", resp.StatusCode, apiErr.Error)
+		}
+		return fmt.Errorf("apple API returned status %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode apple response: %w", err)
+	}
+	return nil
+}
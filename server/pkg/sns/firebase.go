@@ -13,10 +13,11 @@ import (
 
 // FirebaseUserInfo represents Firebase user information
 type FirebaseUserInfo struct {
-	ID           string `json:"id"`
-	Email        string `json:"email"`
-	Name         string `json:"name"`
-	ProfileImage string `json:"profile_image"`
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	ProfileImage  string `json:"profile_image"`
 }
 
 var (
@@ -111,14 +112,16 @@ func VerifyFirebaseIDToken(ctx context.Context, idToken string) (*FirebaseUserIn
 		// If GetUser fails, we can still use token claims
 		// Extract email from token claims as fallback
 		email, _ := token.Claims["email"].(string)
+		emailVerified, _ := token.Claims["email_verified"].(bool)
 		name, _ := token.Claims["name"].(string)
 		picture, _ := token.Claims["picture"].(string)
 
 		return &FirebaseUserInfo{
-			ID:           token.UID,
-			Email:        email,
-			Name:         name,
-			ProfileImage: picture,
+			ID:            token.UID,
+			Email:         email,
+			EmailVerified: emailVerified,
+			Name:          name,
+			ProfileImage:  picture,
 		}, nil
 	}
 
@@ -126,9 +129,11 @@ func VerifyFirebaseIDToken(ctx context.Context, idToken string) (*FirebaseUserIn
 
 	// Extract user info
 	email := user.Email
+	emailVerified := user.EmailVerified
 	if email == "" {
 		// Fallback to token claims
 		email, _ = token.Claims["email"].(string)
+		emailVerified, _ = token.Claims["email_verified"].(bool)
 	}
 
 	name := user.DisplayName
@@ -144,10 +149,10 @@ func VerifyFirebaseIDToken(ctx context.Context, idToken string) (*FirebaseUserIn
 	}
 
 	return &FirebaseUserInfo{
-		ID:           user.UID,
-		Email:        email,
-		Name:         name,
-		ProfileImage: photoURL,
+		ID:            user.UID,
+		Email:         email,
+		EmailVerified: emailVerified,
+		Name:          name,
+		ProfileImage:  photoURL,
 	}, nil
 }
-
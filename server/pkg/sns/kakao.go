@@ -2,27 +2,36 @@ package sns
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
+// DefaultKakaoAPIBaseURL is the Kakao API base URL
+const DefaultKakaoAPIBaseURL = "https://kapi.kakao.com"
+
 // KakaoUserInfo represents Kakao user information
 type KakaoUserInfo struct {
-	ID           string `json:"id"`
-	Email        string `json:"email"`
-	Name         string `json:"name"`
-	ProfileImage string `json:"profile_image"`
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"` // 유효하고 인증된 이메일인 경우에만 true
+	Name          string `json:"name"`
+	ProfileImage  string `json:"profile_image"`
 }
 
 // KakaoAPIResponse represents Kakao API response structure
 type KakaoAPIResponse struct {
 	ID           int64 `json:"id"`
 	KakaoAccount struct {
-		Email   string `json:"email"`
-		Profile struct {
+		Email           string `json:"email"`
+		IsEmailValid    bool   `json:"is_email_valid"`
+		IsEmailVerified bool   `json:"is_email_verified"`
+		Profile         struct {
 			Nickname        string `json:"nickname"`
 			ProfileImageURL string `json:"profile_image_url"`
 			ThumbnailURL    string `json:"thumbnail_image_url"`
@@ -35,7 +44,12 @@ type KakaoAPIResponse struct {
 
 // VerifyKakaoToken verifies Kakao access token and returns user info
 // Uses goroutine for concurrent API call
-func VerifyKakaoToken(ctx context.Context, accessToken string) (*KakaoUserInfo, error) {
+// baseURL이 비어 있으면 DefaultKakaoAPIBaseURL 사용 (테스트에서는 stub 서버 주소 지정)
+func VerifyKakaoToken(ctx context.Context, baseURL, accessToken string) (*KakaoUserInfo, error) {
+	if baseURL == "" {
+		baseURL = DefaultKakaoAPIBaseURL
+	}
+
 	type apiResult struct {
 		resp *KakaoAPIResponse
 		err  error
@@ -47,7 +61,7 @@ func VerifyKakaoToken(ctx context.Context, accessToken string) (*KakaoUserInfo,
 	go func() {
 		client := &http.Client{Timeout: 10 * time.Second}
 
-		req, err := http.NewRequestWithContext(ctx, "GET", "https://kapi.kakao.com/v2/user/me", nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimRight(baseURL, "/")+"/v2/user/me", nil)
 		if err != nil {
 			resultCh <- apiResult{nil, fmt.Errorf("failed to create request: %w", err)}
 			return
@@ -102,10 +116,65 @@ func VerifyKakaoToken(ctx context.Context, accessToken string) (*KakaoUserInfo,
 	}
 
 	return &KakaoUserInfo{
-		ID:           fmt.Sprintf("%d", kakaoResp.ID),
-		Email:        kakaoResp.KakaoAccount.Email,
-		Name:         name,
-		ProfileImage: profileImage,
+		ID:            fmt.Sprintf("%d", kakaoResp.ID),
+		Email:         kakaoResp.KakaoAccount.Email,
+		EmailVerified: kakaoResp.KakaoAccount.IsEmailValid && kakaoResp.KakaoAccount.IsEmailVerified,
+		Name:          name,
+		ProfileImage:  profileImage,
 	}, nil
 }
 
+// KakaoUnlinkResponse represents Kakao unlink API response
+type KakaoUnlinkResponse struct {
+	ID int64 `json:"id"`
+}
+
+// UnlinkKakaoUser disconnects a Kakao user from the app using the admin key
+// baseURL이 비어 있으면 DefaultKakaoAPIBaseURL 사용
+func UnlinkKakaoUser(ctx context.Context, baseURL, adminKey, userID string) error {
+	if baseURL == "" {
+		baseURL = DefaultKakaoAPIBaseURL
+	}
+
+	form := url.Values{
+		"target_id_type": {"user_id"},
+		"target_id":      {userID},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimRight(baseURL, "/")+"/v1/user/unlink", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "KakaoAK "+adminKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("kakao unlink API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var unlinkResp KakaoUnlinkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&unlinkResp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if fmt.Sprintf("%d", unlinkResp.ID) != userID {
+		return fmt.Errorf("kakao unlink returned unexpected user id %d", unlinkResp.ID)
+	}
+	return nil
+}
+
+// VerifyKakaoCallbackAuthorization checks the Authorization header of a Kakao unlink callback
+// 카카오는 콜백 요청에 "KakaoAK {Admin 키}" 헤더를 담아 보냄
+func VerifyKakaoCallbackAuthorization(authorization, adminKey string) bool {
+	if adminKey == "" {
+		return false
+	}
+	expected := "KakaoAK " + adminKey
+	return subtle.ConstantTimeCompare([]byte(authorization), []byte(expected)) == 1
+}
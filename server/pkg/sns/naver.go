@@ -0,0 +1,91 @@
+package sns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultNaverAPIBaseURL is the Naver Open API base URL
+const DefaultNaverAPIBaseURL = "https://openapi.naver.com"
+
+// naverResultSuccess is the resultcode of a successful profile response
+const naverResultSuccess = "00"
+
+// NaverUserInfo represents Naver user information
+type NaverUserInfo struct {
+	ID           string `json:"id"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	ProfileImage string `json:"profile_image"`
+}
+
+// NaverAPIResponse represents Naver profile API response structure
+type NaverAPIResponse struct {
+	ResultCode string `json:"resultcode"`
+	Message    string `json:"message"`
+	Response   struct {
+		ID           string `json:"id"`
+		Email        string `json:"email"`
+		Name         string `json:"name"`
+		Nickname     string `json:"nickname"`
+		ProfileImage string `json:"profile_image"`
+	} `json:"response"`
+}
+
+// VerifyNaverToken verifies Naver access token with the profile API and returns user info
+// baseURL이 비어 있으면 DefaultNaverAPIBaseURL 사용 (테스트에서는 stub 서버 주소 지정)
+func VerifyNaverToken(ctx context.Context, baseURL, accessToken string) (*NaverUserInfo, error) {
+	if baseURL == "" {
+		baseURL = DefaultNaverAPIBaseURL
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimRight(baseURL, "/")+"/v1/nid/me", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Read response body for debugging
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("naver API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var naverResp NaverAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&naverResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if naverResp.ResultCode != naverResultSuccess {
+		return nil, fmt.Errorf("naver API returned result %s: %s", naverResp.ResultCode, naverResp.Message)
+	}
+	if naverResp.Response.ID == "" {
+		return nil, fmt.Errorf("invalid naver user id")
+	}
+
+	// Extract user info
+	name := naverResp.Response.Name
+	if name == "" {
+		name = naverResp.Response.Nickname
+	}
+
+	return &NaverUserInfo{
+		ID:           naverResp.Response.ID,
+		Email:        naverResp.Response.Email,
+		Name:         name,
+		ProfileImage: naverResp.Response.ProfileImage,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package sns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNaverStub 네이버 프로필 API stub 서버 (valid-token만 허용)
+func newNaverStub(t *testing.T, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/nid/me", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"resultcode":"024","message":"Authentication failed"}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVerifyNaverToken(t *testing.T) {
+	t.Run("프로필 조회 성공", func(t *testing.T) {
+		server := newNaverStub(t, `{"resultcode":"00","message":"success","response":{"id":"naver-123","email":"user@naver.com","name":"홍길동","nickname":"길동","profile_image":"https://example.com/p.png"}}`)
+
+		user, err := VerifyNaverToken(context.Background(), server.URL, "valid-token")
+		require.NoError(t, err)
+		assert.Equal(t, "naver-123", user.ID)
+		assert.Equal(t, "user@naver.com", user.Email)
+		assert.Equal(t, "홍길동", user.Name)
+	})
+
+	t.Run("이름이 없으면 별명 사용", func(t *testing.T) {
+		server := newNaverStub(t, `{"resultcode":"00","message":"success","response":{"id":"naver-123","nickname":"길동"}}`)
+
+		user, err := VerifyNaverToken(context.Background(), server.URL, "valid-token")
+		require.NoError(t, err)
+		assert.Equal(t, "길동", user.Name)
+		assert.Empty(t, user.Email)
+	})
+
+	t.Run("유효하지 않은 토큰 거부", func(t *testing.T) {
+		server := newNaverStub(t, "")
+
+		_, err := VerifyNaverToken(context.Background(), server.URL, "invalid-token")
+		assert.Error(t, err)
+	})
+
+	t.Run("실패 resultcode 거부", func(t *testing.T) {
+		server := newNaverStub(t, `{"resultcode":"028","message":"Authentication header not exists"}`)
+
+		_, err := VerifyNaverToken(context.Background(), server.URL, "valid-token")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+package sns
+
+import (
+	"context"
+	"sort"
+)
+
+// Provider names (internal/model.LoginMethod 값과 동일)
+const (
+	ProviderGoogle = "google"
+	ProviderApple  = "apple"
+	ProviderKakao  = "kakao"
+	ProviderNaver  = "naver"
+)
+
+// Identity is a verified SNS identity normalized across providers
+type Identity struct {
+	Subject string // 제공자 내 고유 사용자 ID
+	Email   string // 제공되지 않으면 빈 문자열 (Apple 재로그인, 이메일 미동의)
+	// EmailVerified 제공자가 이메일 소유를 확인했는지 여부 (확인 정보를 주지 않는 제공자는 false)
+	EmailVerified bool
+	Name          string
+}
+
+// IdentityProvider verifies a client-side SNS token and returns the identity
+type IdentityProvider interface {
+	// Name returns the provider name used in routes and stored identities
+	Name() string
+	// VerifyToken verifies the token with the provider
+	VerifyToken(ctx context.Context, token string) (*Identity, error)
+}
+
+// Registry holds identity providers by name
+type Registry struct {
+	providers map[string]IdentityProvider
+}
+
+// NewRegistry creates a registry with the given providers
+func NewRegistry(providers ...IdentityProvider) *Registry {
+	r := &Registry{providers: make(map[string]IdentityProvider, len(providers))}
+	for _, p := range providers {
+		r.Register(p)
+	}
+	return r
+}
+
+// Register adds or replaces a provider
+func (r *Registry) Register(p IdentityProvider) {
+	r.providers[p.Name()] = p
+}
+
+// Get returns the provider registered under name
+func (r *Registry) Get(name string) (IdentityProvider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Names returns registered provider names in sorted order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GoogleProvider verifies Firebase ID tokens (Google 로그인, InitFirebase 필요)
+type GoogleProvider struct{}
+
+// Name implements IdentityProvider
+func (GoogleProvider) Name() string { return ProviderGoogle }
+
+// VerifyToken implements IdentityProvider
+func (GoogleProvider) VerifyToken(ctx context.Context, token string) (*Identity, error) {
+	user, err := VerifyFirebaseIDToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{Subject: user.ID, Email: user.Email, EmailVerified: user.EmailVerified, Name: user.Name}, nil
+}
+
+// AppleProvider verifies Apple identity tokens
+type AppleProvider struct {
+	ClientID string // audience (Bundle ID)
+	KeysURL  string // 비어 있으면 DefaultAppleKeysURL
+}
+
+// Name implements IdentityProvider
+func (AppleProvider) Name() string { return ProviderApple }
+
+// VerifyToken implements IdentityProvider
+func (p AppleProvider) VerifyToken(ctx context.Context, token string) (*Identity, error) {
+	user, err := VerifyAppleToken(ctx, p.KeysURL, token, p.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{Subject: user.ID, Email: user.Email, EmailVerified: user.EmailVerified, Name: user.Name}, nil
+}
+
+// KakaoProvider verifies Kakao access tokens
+type KakaoProvider struct {
+	BaseURL string // 비어 있으면 DefaultKakaoAPIBaseURL
+}
+
+// Name implements IdentityProvider
+func (KakaoProvider) Name() string { return ProviderKakao }
+
+// VerifyToken implements IdentityProvider
+func (p KakaoProvider) VerifyToken(ctx context.Context, token string) (*Identity, error) {
+	user, err := VerifyKakaoToken(ctx, p.BaseURL, token)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{Subject: user.ID, Email: user.Email, EmailVerified: user.EmailVerified, Name: user.Name}, nil
+}
+
+// NaverProvider verifies Naver access tokens
+type NaverProvider struct {
+	BaseURL string // 비어 있으면 DefaultNaverAPIBaseURL
+}
+
+// Name implements IdentityProvider
+func (NaverProvider) Name() string { return ProviderNaver }
+
+// VerifyToken implements IdentityProvider
+func (p NaverProvider) VerifyToken(ctx context.Context, token string) (*Identity, error) {
+	user, err := VerifyNaverToken(ctx, p.BaseURL, token)
+	if err != nil {
+		return nil, err
+	}
+	// Naver는 이메일 인증 여부를 제공하지 않으므로 미확인으로 취급
+	return &Identity{Subject: user.ID, Email: user.Email, Name: user.Name}, nil
+}
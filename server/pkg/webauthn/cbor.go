@@ -0,0 +1,177 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CBOR (RFC 8949) 디코더: WebAuthn attestation object와 COSE 키 해석에 필요한 범위만 지원
+// 인증기는 CTAP2 canonical CBOR(정해진 길이)만 사용하므로 indefinite length는 지원하지 않음
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+const cborMaxDepth = 16
+
+// decodeCBOR decodes one CBOR item and returns it with the number of bytes consumed
+// 정수는 int64, 바이트열은 []byte, 문자열은 string, 배열은 []interface{},
+// 맵은 map[interface{}]interface{}로 반환
+func decodeCBOR(data []byte) (interface{}, int, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (interface{}, int, error) {
+	if depth > cborMaxDepth {
+		return nil, 0, errors.New("cbor: nesting too deep")
+	}
+	if len(data) == 0 {
+		return nil, 0, errCBORTruncated
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+
+	if major == 7 {
+		return decodeCBORSimple(data, info)
+	}
+
+	arg, offset, err := readCBORArgument(data, info)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch major {
+	case 0: // unsigned integer
+		if arg > math.MaxInt64 {
+			return nil, 0, errors.New("cbor: integer overflow")
+		}
+		return int64(arg), offset, nil
+
+	case 1: // negative integer
+		if arg > math.MaxInt64 {
+			return nil, 0, errors.New("cbor: integer overflow")
+		}
+		return -1 - int64(arg), offset, nil
+
+	case 2, 3: // byte string, text string
+		if arg > uint64(len(data)-offset) {
+			return nil, 0, errCBORTruncated
+		}
+		end := offset + int(arg)
+		if major == 2 {
+			value := make([]byte, arg)
+			copy(value, data[offset:end])
+			return value, end, nil
+		}
+		return string(data[offset:end]), end, nil
+
+	case 4: // array
+		if arg > uint64(len(data)) {
+			return nil, 0, errCBORTruncated
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, n, err := decodeCBORItem(data[offset:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, item)
+			offset += n
+		}
+		return items, offset, nil
+
+	case 5: // map
+		if arg > uint64(len(data)) {
+			return nil, 0, errCBORTruncated
+		}
+		items := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, n, err := decodeCBORItem(data[offset:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			offset += n
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, 0, errors.New("cbor: unsupported map key type")
+			}
+
+			value, n, err := decodeCBORItem(data[offset:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			offset += n
+			items[key] = value
+		}
+		return items, offset, nil
+
+	case 6: // tag: 태그는 무시하고 내부 값만 사용
+		value, n, err := decodeCBORItem(data[offset:], depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		return value, offset + n, nil
+	}
+
+	return nil, 0, fmt.Errorf("cbor: unsupported major type %d", major)
+}
+
+// readCBORArgument reads the length/value argument following the initial byte
+func readCBORArgument(data []byte, info byte) (uint64, int, error) {
+	switch {
+	case info < 24:
+		return uint64(info), 1, nil
+	case info == 24:
+		if len(data) < 2 {
+			return 0, 0, errCBORTruncated
+		}
+		return uint64(data[1]), 2, nil
+	case info == 25:
+		if len(data) < 3 {
+			return 0, 0, errCBORTruncated
+		}
+		return uint64(binary.BigEndian.Uint16(data[1:3])), 3, nil
+	case info == 26:
+		if len(data) < 5 {
+			return 0, 0, errCBORTruncated
+		}
+		return uint64(binary.BigEndian.Uint32(data[1:5])), 5, nil
+	case info == 27:
+		if len(data) < 9 {
+			return 0, 0, errCBORTruncated
+		}
+		return binary.BigEndian.Uint64(data[1:9]), 9, nil
+	default:
+		return 0, 0, errors.New("cbor: indefinite length is not supported")
+	}
+}
+
+// decodeCBORSimple decodes major type 7 (false, true, null, undefined, floats)
+func decodeCBORSimple(data []byte, info byte) (interface{}, int, error) {
+	switch info {
+	case 20:
+		return false, 1, nil
+	case 21:
+		return true, 1, nil
+	case 22, 23:
+		return nil, 1, nil
+	case 25:
+		if len(data) < 3 {
+			return nil, 0, errCBORTruncated
+		}
+		return nil, 3, nil // half precision float: WebAuthn에서 사용하지 않으므로 값은 버림
+	case 26:
+		if len(data) < 5 {
+			return nil, 0, errCBORTruncated
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data[1:5]))), 5, nil
+	case 27:
+		if len(data) < 9 {
+			return nil, 0, errCBORTruncated
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data[1:9])), 9, nil
+	}
+	return nil, 0, fmt.Errorf("cbor: unsupported simple value %d", info)
+}
@@ -0,0 +1,348 @@
+// Package webauthn implements the server side of WebAuthn (passkey) ceremonies
+// 등록(attestation)과 로그인(assertion) 응답 검증, 옵션 생성을 담당한다.
+// attestation은 "none"으로 요청하므로 인증기 인증서(attStmt)는 검증하지 않는다.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Ceremony types in clientDataJSON
+const (
+	ceremonyCreate = "webauthn.create"
+	ceremonyGet    = "webauthn.get"
+)
+
+// Authenticator data flags
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+// ceremonyTimeoutMs 클라이언트에 제시하는 ceremony 제한 시간
+const ceremonyTimeoutMs = 300000
+
+var (
+	ErrInvalidSignature    = errors.New("webauthn: invalid signature")
+	ErrChallengeMismatch   = errors.New("webauthn: challenge mismatch")
+	ErrOriginNotAllowed    = errors.New("webauthn: origin not allowed")
+	ErrRPIDMismatch        = errors.New("webauthn: rp id hash mismatch")
+	ErrUserNotVerified     = errors.New("webauthn: user presence/verification required")
+	ErrSignCountRegression = errors.New("webauthn: sign counter did not increase (possible cloned authenticator)")
+)
+
+// base64url (no padding) used for every binary field exchanged with clients
+var encoding = base64.RawURLEncoding
+
+// EncodeToString encodes binary WebAuthn values (challenge, credential ID) for JSON
+func EncodeToString(b []byte) string {
+	return encoding.EncodeToString(b)
+}
+
+// DecodeString decodes base64url values sent by clients (padding 허용)
+func DecodeString(s string) ([]byte, error) {
+	for len(s) > 0 && s[len(s)-1] == '=' {
+		s = s[:len(s)-1]
+	}
+	return encoding.DecodeString(s)
+}
+
+// Config is the relying party configuration
+type Config struct {
+	RPID    string   // 도메인 (예: ojeomneo.com)
+	RPName  string   // 사용자에게 표시되는 서비스 이름
+	Origins []string // 허용 origin (https://ojeomneo.com, android:apk-key-hash:...)
+}
+
+// Credential is a verified credential from a registration ceremony
+type Credential struct {
+	ID        []byte
+	PublicKey []byte // COSE_Key (CBOR)
+	Algorithm int64
+	SignCount uint32
+	AAGUID    []byte
+}
+
+// NewChallenge generates a random challenge (base64url)
+func NewChallenge() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return EncodeToString(b), nil
+}
+
+// RelyingParty identifies the server in creation options
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity identifies the account in creation options
+type UserEntity struct {
+	ID          string `json:"id"` // base64url user handle
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameter is an accepted key algorithm
+type CredentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int64  `json:"alg"`
+}
+
+// CredentialDescriptor references an existing credential
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// AuthenticatorSelection requests a discoverable, user-verifying credential (passkey)
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// CreationOptions is PublicKeyCredentialCreationOptions (JSON)
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingParty           `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int                    `json:"timeout"`
+	Attestation            string                 `json:"attestation"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+}
+
+// RequestOptions is PublicKeyCredentialRequestOptions (JSON)
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int                    `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	UserVerification string                 `json:"userVerification"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+}
+
+// CreationOptions builds registration options for a user
+func (c *Config) CreationOptions(challenge string, userHandle []byte, name, displayName string, exclude []CredentialDescriptor) *CreationOptions {
+	params := make([]CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, CredentialParameter{Type: "public-key", Algorithm: alg})
+	}
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+
+	return &CreationOptions{
+		Challenge:        challenge,
+		RP:               RelyingParty{ID: c.RPID, Name: c.RPName},
+		User:             UserEntity{ID: EncodeToString(userHandle), Name: name, DisplayName: displayName},
+		PubKeyCredParams: params,
+		Timeout:          ceremonyTimeoutMs,
+		Attestation:      "none",
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "required",
+		},
+		ExcludeCredentials: exclude,
+	}
+}
+
+// RequestOptions builds login options (빈 allowCredentials: 기기에 저장된 passkey 중 선택)
+func (c *Config) RequestOptions(challenge string) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          ceremonyTimeoutMs,
+		RPID:             c.RPID,
+		UserVerification: "required",
+		AllowCredentials: []CredentialDescriptor{},
+	}
+}
+
+// clientData is CollectedClientData
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// authenticatorData is the parsed authenticator data structure
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+// ChallengeFromClientData returns the challenge echoed in clientDataJSON
+// 저장된 challenge 조회용 (검증 전 값이므로 반드시 Verify* 로 다시 확인)
+func ChallengeFromClientData(clientDataJSON []byte) (string, error) {
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return "", fmt.Errorf("webauthn: invalid client data: %w", err)
+	}
+	if data.Challenge == "" {
+		return "", errors.New("webauthn: client data missing challenge")
+	}
+	return data.Challenge, nil
+}
+
+// VerifyRegistration verifies a registration (attestation) response
+func (c *Config) VerifyRegistration(challenge string, clientDataJSON, attestationObject []byte) (*Credential, error) {
+	if err := c.verifyClientData(clientDataJSON, ceremonyCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: invalid attestation object: %w", err)
+	}
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("webauthn: invalid attestation object")
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("webauthn: attestation object missing authData")
+	}
+
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyAuthenticatorData(authData); err != nil {
+		return nil, err
+	}
+	if authData.flags&flagAttestedData == 0 || len(authData.credentialID) == 0 {
+		return nil, errors.New("webauthn: attested credential data missing")
+	}
+
+	key, err := parsePublicKey(authData.publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: %w", err)
+	}
+
+	return &Credential{
+		ID:        authData.credentialID,
+		PublicKey: authData.publicKey,
+		Algorithm: key.algorithm,
+		SignCount: authData.signCount,
+		AAGUID:    authData.aaguid,
+	}, nil
+}
+
+// VerifyAssertion verifies a login (assertion) response and returns the new sign counter
+func (c *Config) VerifyAssertion(challenge string, credentialPublicKey []byte, storedSignCount uint32, clientDataJSON, rawAuthData, signature []byte) (uint32, error) {
+	if err := c.verifyClientData(clientDataJSON, ceremonyGet, challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.verifyAuthenticatorData(authData); err != nil {
+		return 0, err
+	}
+
+	key, err := parsePublicKey(credentialPublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("webauthn: %w", err)
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	message := make([]byte, 0, len(rawAuthData)+len(clientDataHash))
+	message = append(message, rawAuthData...)
+	message = append(message, clientDataHash[:]...)
+	if err := key.verify(message, signature); err != nil {
+		return 0, err
+	}
+
+	// 카운터를 지원하는 인증기에서 값이 증가하지 않으면 복제된 인증기로 간주
+	// (동기화되는 passkey는 항상 0을 보고함)
+	if (authData.signCount != 0 || storedSignCount != 0) && authData.signCount <= storedSignCount {
+		return 0, ErrSignCountRegression
+	}
+
+	return authData.signCount, nil
+}
+
+func (c *Config) verifyClientData(raw []byte, ceremony, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("webauthn: invalid client data: %w", err)
+	}
+	if data.Type != ceremony {
+		return fmt.Errorf("webauthn: unexpected ceremony type %q", data.Type)
+	}
+	if subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return ErrChallengeMismatch
+	}
+	for _, origin := range c.Origins {
+		if data.Origin == origin {
+			return nil
+		}
+	}
+	return ErrOriginNotAllowed
+}
+
+func (c *Config) verifyAuthenticatorData(authData *authenticatorData) error {
+	expected := sha256.Sum256([]byte(c.RPID))
+	if !bytes.Equal(authData.rpIDHash, expected[:]) {
+		return ErrRPIDMismatch
+	}
+	if authData.flags&flagUserPresent == 0 || authData.flags&flagUserVerified == 0 {
+		return ErrUserNotVerified
+	}
+	return nil
+}
+
+// parseAuthenticatorData parses rpIdHash(32) | flags(1) | signCount(4) | [attestedCredentialData]
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, errors.New("webauthn: authenticator data too short")
+	}
+
+	data := &authenticatorData{
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+
+	if data.flags&flagAttestedData != 0 {
+		rest := raw[37:]
+		if len(rest) < 18 {
+			return nil, errors.New("webauthn: attested credential data too short")
+		}
+		data.aaguid = rest[:16]
+		idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+		if len(rest) < 18+idLen {
+			return nil, errors.New("webauthn: credential id truncated")
+		}
+		data.credentialID = rest[18 : 18+idLen]
+
+		keyBytes := rest[18+idLen:]
+		_, n, err := decodeCBOR(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("webauthn: invalid credential public key: %w", err)
+		}
+		data.publicKey = keyBytes[:n]
+	}
+
+	return data, nil
+}
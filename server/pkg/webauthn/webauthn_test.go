@@ -0,0 +1,179 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAuthenticator 테스트용 ES256 인증기
+type testAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &testAuthenticator{key: key, credentialID: []byte("test-credential-id")}
+}
+
+// coseKey EC2 P-256 COSE_Key: {1: 2, 3: -7, -1: 1, -2: x, -3: y}
+func (a *testAuthenticator) coseKey() []byte {
+	x := a.key.PublicKey.X.FillBytes(make([]byte, 32))
+	y := a.key.PublicKey.Y.FillBytes(make([]byte, 32))
+
+	out := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}
+	out = append(out, x...)
+	out = append(out, 0x22, 0x58, 0x20)
+	return append(out, y...)
+}
+
+func (a *testAuthenticator) authData(rpID string, flags byte, signCount uint32, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	out := append([]byte{}, rpIDHash[:]...)
+	out = append(out, flags)
+	out = binary.BigEndian.AppendUint32(out, signCount)
+	if attested {
+		out = append(out, make([]byte, 16)...) // AAGUID
+		out = binary.BigEndian.AppendUint16(out, uint16(len(a.credentialID)))
+		out = append(out, a.credentialID...)
+		out = append(out, a.coseKey()...)
+	}
+	return out
+}
+
+// attestationObject {"fmt": "none", "attStmt": {}, "authData": authData}
+func attestationObject(authData []byte) []byte {
+	out := []byte{0xa3, 0x63}
+	out = append(out, "fmt"...)
+	out = append(out, 0x64)
+	out = append(out, "none"...)
+	out = append(out, 0x67)
+	out = append(out, "attStmt"...)
+	out = append(out, 0xa0, 0x68)
+	out = append(out, "authData"...)
+	out = append(out, 0x59)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(authData)))
+	return append(out, authData...)
+}
+
+func clientDataJSON(t *testing.T, ceremony, challenge, origin string) []byte {
+	data, err := json.Marshal(map[string]string{"type": ceremony, "challenge": challenge, "origin": origin})
+	require.NoError(t, err)
+	return data
+}
+
+func (a *testAuthenticator) sign(t *testing.T, authData, clientData []byte) []byte {
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+	return signature
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	cfg := &Config{RPID: "ojeomneo.com", RPName: "Ojeomneo", Origins: []string{"https://ojeomneo.com"}}
+	authenticator := newTestAuthenticator(t)
+	challenge, err := NewChallenge()
+	require.NoError(t, err)
+
+	attestation := attestationObject(authenticator.authData(cfg.RPID, flagUserPresent|flagUserVerified|flagAttestedData, 0, true))
+
+	t.Run("정상 등록 응답 검증", func(t *testing.T) {
+		credential, err := cfg.VerifyRegistration(challenge, clientDataJSON(t, ceremonyCreate, challenge, "https://ojeomneo.com"), attestation)
+		require.NoError(t, err)
+		assert.Equal(t, authenticator.credentialID, credential.ID)
+		assert.Equal(t, AlgES256, credential.Algorithm)
+		assert.Equal(t, authenticator.coseKey(), credential.PublicKey)
+	})
+
+	t.Run("challenge 불일치 거부", func(t *testing.T) {
+		_, err := cfg.VerifyRegistration(challenge, clientDataJSON(t, ceremonyCreate, "other", "https://ojeomneo.com"), attestation)
+		assert.ErrorIs(t, err, ErrChallengeMismatch)
+	})
+
+	t.Run("허용되지 않은 origin 거부", func(t *testing.T) {
+		_, err := cfg.VerifyRegistration(challenge, clientDataJSON(t, ceremonyCreate, challenge, "https://evil.example"), attestation)
+		assert.ErrorIs(t, err, ErrOriginNotAllowed)
+	})
+
+	t.Run("다른 RP ID 거부", func(t *testing.T) {
+		other := attestationObject(authenticator.authData("evil.example", flagUserPresent|flagUserVerified|flagAttestedData, 0, true))
+		_, err := cfg.VerifyRegistration(challenge, clientDataJSON(t, ceremonyCreate, challenge, "https://ojeomneo.com"), other)
+		assert.ErrorIs(t, err, ErrRPIDMismatch)
+	})
+
+	t.Run("사용자 확인(UV) 없으면 거부", func(t *testing.T) {
+		noUV := attestationObject(authenticator.authData(cfg.RPID, flagUserPresent|flagAttestedData, 0, true))
+		_, err := cfg.VerifyRegistration(challenge, clientDataJSON(t, ceremonyCreate, challenge, "https://ojeomneo.com"), noUV)
+		assert.ErrorIs(t, err, ErrUserNotVerified)
+	})
+
+	t.Run("로그인 ceremony 응답 거부", func(t *testing.T) {
+		_, err := cfg.VerifyRegistration(challenge, clientDataJSON(t, ceremonyGet, challenge, "https://ojeomneo.com"), attestation)
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	cfg := &Config{RPID: "ojeomneo.com", RPName: "Ojeomneo", Origins: []string{"https://ojeomneo.com"}}
+	authenticator := newTestAuthenticator(t)
+	challenge, err := NewChallenge()
+	require.NoError(t, err)
+	clientData := clientDataJSON(t, ceremonyGet, challenge, "https://ojeomneo.com")
+
+	t.Run("정상 서명 검증 및 카운터 반환", func(t *testing.T) {
+		authData := authenticator.authData(cfg.RPID, flagUserPresent|flagUserVerified, 5, false)
+		signCount, err := cfg.VerifyAssertion(challenge, authenticator.coseKey(), 4, clientData, authData, authenticator.sign(t, authData, clientData))
+		require.NoError(t, err)
+		assert.Equal(t, uint32(5), signCount)
+	})
+
+	t.Run("카운터 미지원 인증기 허용", func(t *testing.T) {
+		authData := authenticator.authData(cfg.RPID, flagUserPresent|flagUserVerified, 0, false)
+		_, err := cfg.VerifyAssertion(challenge, authenticator.coseKey(), 0, clientData, authData, authenticator.sign(t, authData, clientData))
+		assert.NoError(t, err)
+	})
+
+	t.Run("카운터 감소 시 거부", func(t *testing.T) {
+		authData := authenticator.authData(cfg.RPID, flagUserPresent|flagUserVerified, 3, false)
+		_, err := cfg.VerifyAssertion(challenge, authenticator.coseKey(), 3, clientData, authData, authenticator.sign(t, authData, clientData))
+		assert.ErrorIs(t, err, ErrSignCountRegression)
+	})
+
+	t.Run("다른 키의 서명 거부", func(t *testing.T) {
+		other := newTestAuthenticator(t)
+		authData := authenticator.authData(cfg.RPID, flagUserPresent|flagUserVerified, 6, false)
+		_, err := cfg.VerifyAssertion(challenge, authenticator.coseKey(), 0, clientData, authData, other.sign(t, authData, clientData))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("서명 후 변조된 client data 거부", func(t *testing.T) {
+		authData := authenticator.authData(cfg.RPID, flagUserPresent|flagUserVerified, 7, false)
+		signature := authenticator.sign(t, authData, clientData)
+		tampered := clientDataJSON(t, ceremonyGet, challenge, "https://ojeomneo.com/")
+		cfgWithSlash := &Config{RPID: cfg.RPID, Origins: []string{"https://ojeomneo.com/"}}
+		_, err := cfgWithSlash.VerifyAssertion(challenge, authenticator.coseKey(), 0, tampered, authData, signature)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestDecodeCBOR_Invalid(t *testing.T) {
+	t.Run("잘린 데이터 거부", func(t *testing.T) {
+		_, _, err := decodeCBOR([]byte{0x58, 0x20, 0x01})
+		assert.Error(t, err)
+	})
+
+	t.Run("indefinite length 거부", func(t *testing.T) {
+		_, _, err := decodeCBOR([]byte{0x9f, 0x01, 0xff})
+		assert.Error(t, err)
+	})
+}